@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"os"
+	"os/signal"
 
 	"github.com/spf13/cobra"
 
@@ -9,22 +11,31 @@ import (
 )
 
 var RootCmd = &cobra.Command{
-	Use:   "randomtalk",
-	Short: "Random chat CLI",
+	Use:          "randomtalk",
+	Short:        "Random chat CLI",
+	SilenceUsage: true,
 }
 
 var (
-	grpcAddr = RootCmd.Flags().String("grpc-addr", "localhost:50000", "gRPC server address")
+	grpcAddr = RootCmd.PersistentFlags().String("grpc-addr", "localhost:50000", "gRPC server address")
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, cancelSignal := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelSignal()
 
 	// add cobra commands
-	RootCmd.AddCommand(matchsessioncli.NewMatchSessionCobraCommand())
+	RootCmd.AddCommand(
+		matchsessioncli.NewMatchSessionCobraCommand(grpcAddr),
+		matchsessioncli.NewGetMatchCobraCommand(grpcAddr),
+		matchsessioncli.NewListWaitingCobraCommand(grpcAddr),
+		matchsessioncli.NewWatchCobraCommand(grpcAddr),
+		matchsessioncli.NewSimulateCobraCommand(),
+	)
 
 	err := RootCmd.ExecuteContext(ctx)
 	if err != nil {
-		panic(err)
+		cancelSignal()
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,11 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type AcceptMatchCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// MatchID is the proposed match the user accepts.
+	MatchID string `json:"match_id"`
+}
@@ -0,0 +1,70 @@
+package chatcommands
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const AcceptMatchCommandType = "randomtalk.chat.accept_match"
+
+func NewAcceptMatchCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	matchRequester chatdomain.MatchRequester,
+	logger zerolog.Logger,
+) AcceptMatchCommandHandler {
+	return AcceptMatchCommandHandler{
+		matchResponder: matchResponder{
+			logger:          logger,
+			chatSessionRepo: chatSessionRepo,
+			matchRequester:  matchRequester,
+		},
+	}
+}
+
+// AcceptMatchCommandHandler tells the matchmaker that the user accepts the proposed match.
+type AcceptMatchCommandHandler struct {
+	matchResponder
+}
+
+func (h AcceptMatchCommandHandler) Handle(ctx context.Context, cmd AcceptMatchCommand) error {
+	return h.respond(ctx, cmd.MatchID, true)
+}
+
+// matchResponder forwards the responses of the users to the proposed matches.
+type matchResponder struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	matchRequester  chatdomain.MatchRequester
+}
+
+func (r matchResponder) respond(ctx context.Context, matchID string, accepted bool) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	if chatdomain.ID(matchID).IsEmpty() {
+		return chatdomain.ErrInvalidChatSessionMatch
+	}
+
+	cs, err := r.chatSessionRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// the proposed matches are only started once both users accept them
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		return chatdomain.ErrChatSessionNotWaiting
+	}
+
+	r.logger.Debug().
+		Str("user_id", userID).
+		Str("match_id", matchID).
+		Bool("accepted", accepted).
+		Msg("an user responded to a proposed match")
+
+	return r.matchRequester.RespondToMatch(ctx, cs, chatdomain.ID(matchID), accepted)
+}
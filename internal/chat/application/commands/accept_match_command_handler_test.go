@@ -0,0 +1,49 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestMatchResponseCommandHandlers(t *testing.T) {
+	ctx := context.Background()
+	aliceCtx := auth.ContextWithUserID(ctx, "alice")
+
+	t.Run("should forward the responses of the waiting users", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		requester := &recordingMatchRequester{}
+		accept := chatcommands.NewAcceptMatchCommandHandler(sessionRepo, requester, zerolog.Nop())
+		decline := chatcommands.NewDeclineMatchCommandHandler(sessionRepo, requester, zerolog.Nop())
+
+		require.NoError(t, accept.Handle(aliceCtx, chatcommands.AcceptMatchCommand{MatchID: "match-1"}))
+		require.NoError(t, decline.Handle(auth.ContextWithUserID(ctx, "bob"), chatcommands.DeclineMatchCommand{MatchID: "match-1"}))
+		require.Equal(t, map[chatdomain.ID]bool{"alice": true, "bob": false}, requester.responses)
+	})
+
+	t.Run("should reject the responses of the matched users", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		requester := &recordingMatchRequester{}
+		accept := chatcommands.NewAcceptMatchCommandHandler(sessionRepo, requester, zerolog.Nop())
+
+		err := accept.Handle(aliceCtx, chatcommands.AcceptMatchCommand{MatchID: "match-1"})
+		require.ErrorIs(t, err, chatdomain.ErrChatSessionNotWaiting)
+		require.Empty(t, requester.responses)
+	})
+}
@@ -0,0 +1,12 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type BlockUserCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// UserID is the user to block. It defaults to the current partner,
+	// or to the last skipped one, of the user.
+	UserID string `json:"user_id"`
+}
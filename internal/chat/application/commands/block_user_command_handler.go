@@ -0,0 +1,80 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const BlockUserCommandType = "randomtalk.chat.block_user"
+
+func NewBlockUserCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	blockListRepo chatdomain.BlockListRepository,
+	logger zerolog.Logger,
+) BlockUserCommandHandler {
+	return BlockUserCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		blockListRepo:   blockListRepo,
+	}
+}
+
+// BlockUserCommandHandler adds an user to the block list of the user,
+// so the matchmaker never pairs them again.
+type BlockUserCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	blockListRepo   chatdomain.BlockListRepository
+}
+
+func (h BlockUserCommandHandler) Handle(ctx context.Context, cmd BlockUserCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	blockedUserID := chatdomain.ID(cmd.UserID)
+	if blockedUserID.IsEmpty() {
+		var err error
+		if blockedUserID, err = h.lastPartnerID(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	if blockedUserID.String() == userID {
+		return chatdomain.ErrUserCannotBlockItself
+	}
+
+	if err := h.blockListRepo.Block(ctx, chatdomain.ID(userID), blockedUserID); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("blocked_user_id", blockedUserID.String()).
+		Msg("an user blocked another user")
+	return nil
+}
+
+// lastPartnerID returns the current partner of the user or, when it has none, the last skipped one.
+func (h BlockUserCommandHandler) lastPartnerID(ctx context.Context, userID string) (chatdomain.ID, error) {
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return "", chatdomain.ErrBlockedUserNotProvided
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if partnerID := cs.PartnerID(); !partnerID.IsEmpty() {
+		return partnerID, nil
+	}
+	if lastPartnerID := cs.LastPartnerID(); !lastPartnerID.IsEmpty() {
+		return lastPartnerID, nil
+	}
+	return "", chatdomain.ErrBlockedUserNotProvided
+}
@@ -0,0 +1,73 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestBlockListCommandHandlers(t *testing.T) {
+	ctx := context.Background()
+	aliceCtx := auth.ContextWithUserID(ctx, "alice")
+
+	setup := func(t *testing.T) (*chatinmemory.BlockListRepository, chatcommands.BlockUserCommandHandler, chatcommands.UnblockUserCommandHandler) {
+		t.Helper()
+
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		blockListRepo := chatinmemory.NewBlockListRepository()
+		return blockListRepo,
+			chatcommands.NewBlockUserCommandHandler(sessionRepo, blockListRepo, zerolog.Nop()),
+			chatcommands.NewUnblockUserCommandHandler(blockListRepo, zerolog.Nop())
+	}
+
+	t.Run("should block the current partner by default", func(t *testing.T) {
+		blockListRepo, blockUser, _ := setup(t)
+
+		require.NoError(t, blockUser.Handle(aliceCtx, chatcommands.BlockUserCommand{}))
+
+		blocked, err := blockListRepo.FindBlockedUsers(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, []chatdomain.ID{"bob"}, blocked)
+
+		blocking, err := blockListRepo.FindBlockingUsers(ctx, "bob")
+		require.NoError(t, err)
+		require.Equal(t, []chatdomain.ID{"alice"}, blocking)
+	})
+
+	t.Run("should block the given user and unblock it", func(t *testing.T) {
+		blockListRepo, blockUser, unblockUser := setup(t)
+
+		require.NoError(t, blockUser.Handle(aliceCtx, chatcommands.BlockUserCommand{UserID: "carol"}))
+		require.NoError(t, unblockUser.Handle(aliceCtx, chatcommands.UnblockUserCommand{UserID: "carol"}))
+
+		blocked, err := blockListRepo.FindBlockedUsers(ctx, "alice")
+		require.NoError(t, err)
+		require.Empty(t, blocked)
+	})
+
+	t.Run("should fail when there is no user to block", func(t *testing.T) {
+		_, blockUser, unblockUser := setup(t)
+
+		err := blockUser.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.BlockUserCommand{})
+		require.ErrorIs(t, err, chatdomain.ErrBlockedUserNotProvided)
+
+		err = blockUser.Handle(aliceCtx, chatcommands.BlockUserCommand{UserID: "alice"})
+		require.ErrorIs(t, err, chatdomain.ErrUserCannotBlockItself)
+
+		err = unblockUser.Handle(aliceCtx, chatcommands.UnblockUserCommand{})
+		require.ErrorIs(t, err, chatdomain.ErrBlockedUserNotProvided)
+	})
+}
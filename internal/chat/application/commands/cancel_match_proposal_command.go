@@ -0,0 +1,23 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+// CancelMatchProposalCommand is dispatched by the server when a proposed match
+// was declined or not accepted in time.
+type CancelMatchProposalCommand struct {
+	messaging.BaseCommand
+
+	MatchID string `json:"-"`
+	Reason  string `json:"-"`
+	// Requeued reports whether the matchmaker keeps looking for a match for the user.
+	Requeued bool `json:"-"`
+}
+
+func NewCancelMatchProposalCommand(matchID, reason string, requeued bool) CancelMatchProposalCommand {
+	return CancelMatchProposalCommand{
+		BaseCommand: messaging.NewBaseCommand(CancelMatchProposalCommandType),
+		MatchID:     matchID,
+		Reason:      reason,
+		Requeued:    requeued,
+	}
+}
@@ -0,0 +1,82 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const CancelMatchProposalCommandType = "randomtalk.chat.cancel_match_proposal"
+
+// ChatSessionEndedByMatchCancelledReason is the reason the ChatSession ends with
+// when its proposed match is cancelled and the user is not requeued.
+const ChatSessionEndedByMatchCancelledReason = "match_cancelled"
+
+func NewCancelMatchProposalCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) CancelMatchProposalCommandHandler {
+	return CancelMatchProposalCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// CancelMatchProposalCommandHandler tells the user that its proposed match was cancelled.
+// The ChatSession ends unless the matchmaker keeps looking for a match for the user.
+type CancelMatchProposalCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h CancelMatchProposalCommandHandler) Handle(ctx context.Context, cmd CancelMatchProposalCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		// the user went away in the meantime
+		return nil
+	}
+
+	if !cmd.Requeued {
+		if err = cs.End(ChatSessionEndedByMatchCancelledReason); err != nil {
+			return err
+		}
+
+		if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+			return err
+		}
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("match_id", cmd.MatchID).
+		Str("reason", cmd.Reason).
+		Bool("requeued", cmd.Requeued).
+		Msg("proposed match cancelled")
+
+	err = h.userNotifier.NotifyMatchCancelled(ctx, cs.ID(), chatdomain.ID(cmd.MatchID), cmd.Reason, cmd.Requeued)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Msg("failed to notify match cancelled")
+	}
+	return nil
+}
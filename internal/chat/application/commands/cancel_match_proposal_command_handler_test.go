@@ -0,0 +1,50 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestCancelMatchProposalCommandHandler(t *testing.T) {
+	ctx := auth.ContextWithUserID(context.Background(), "alice")
+
+	t.Run("should keep the session waiting when the user is requeued", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewCancelMatchProposalCommandHandler(sessionRepo, notifier, zerolog.Nop())
+
+		cmd := chatcommands.NewCancelMatchProposalCommand("match-1", "declined", true)
+		require.NoError(t, handler.Handle(ctx, cmd))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, alice.Status())
+		require.Equal(t, []chatdomain.ID{"alice"}, notifier.cancelled)
+	})
+
+	t.Run("should end the session when the user is not requeued", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewCancelMatchProposalCommandHandler(sessionRepo, notifier, zerolog.Nop())
+
+		cmd := chatcommands.NewCancelMatchProposalCommand("match-1", "expired", false)
+		require.NoError(t, handler.Handle(ctx, cmd))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, alice.Status())
+		require.Equal(t, []chatdomain.ID{"alice"}, notifier.cancelled)
+	})
+}
@@ -0,0 +1,16 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+// CancelMatchRequestCommand is sent by the user to stop waiting for a match.
+// The server dispatches it as well when the connection of the user closes.
+type CancelMatchRequestCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+}
+
+func NewCancelMatchRequestCommand() CancelMatchRequestCommand {
+	return CancelMatchRequestCommand{
+		BaseCommand: messaging.NewBaseCommand(CancelMatchRequestCommandType),
+	}
+}
@@ -0,0 +1,70 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const CancelMatchRequestCommandType = "randomtalk.chat.cancel_match_request"
+
+func NewCancelMatchRequestCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	matchRequester chatdomain.MatchRequester,
+	logger zerolog.Logger,
+) CancelMatchRequestCommandHandler {
+	return CancelMatchRequestCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		matchRequester:  matchRequester,
+	}
+}
+
+// CancelMatchRequestCommandHandler tells the matchmaker to stop looking for a match
+// for the user and closes its waiting ChatSession.
+// It does nothing when the user is not waiting for a match.
+type CancelMatchRequestCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	matchRequester  chatdomain.MatchRequester
+}
+
+func (h CancelMatchRequestCommandHandler) Handle(ctx context.Context, _ CancelMatchRequestCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		return nil
+	}
+
+	// the user keeps waiting when the matchmaker is not told
+	if err = h.matchRequester.CancelMatchRequest(ctx, cs); err != nil {
+		return err
+	}
+
+	if err = cs.Leave(); err != nil {
+		return err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Msg("match request cancelled")
+	return nil
+}
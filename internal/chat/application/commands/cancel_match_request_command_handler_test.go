@@ -0,0 +1,51 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestCancelMatchRequestCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should cancel the match request and close the waiting session", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		requester := &recordingMatchRequester{}
+		handler := chatcommands.NewCancelMatchRequestCommandHandler(sessionRepo, requester, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewCancelMatchRequestCommand()))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionLeft, alice.Status())
+		require.Equal(t, []chatdomain.ID{"alice"}, requester.cancelled)
+	})
+
+	t.Run("should ignore the users that are not waiting for a match", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		requester := &recordingMatchRequester{}
+		handler := chatcommands.NewCancelMatchRequestCommandHandler(sessionRepo, requester, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewCancelMatchRequestCommand()))
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.NewCancelMatchRequestCommand()))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionMatched, alice.Status())
+		require.Empty(t, requester.cancelled)
+	})
+}
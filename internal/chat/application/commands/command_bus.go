@@ -17,7 +17,11 @@ type CommandBus = messaging.CommandBus
 func InitCommandBus(
 	ctx context.Context,
 	csrepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	blockListRepo chatdomain.BlockListRepository,
 	matchRequester chatdomain.MatchRequester,
+	messagePublisher chatdomain.MessagePublisher,
+	userNotifier chatdomain.UserNotifier,
 	logger zerolog.Logger,
 ) (CommandBus, func(), error) {
 	cmdbus := messaging.NewInMemoryCommandBus()
@@ -32,8 +36,173 @@ func InitCommandBus(
 		logger.Fatal().Err(err).Msg("failed to register command handler")
 	}
 
+	unsubCreateRoomCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		CreateRoomCommandType,
+		NewCreateRoomCommandHandler(csrepo, roomRepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubSendMessageCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		SendMessageCommandType,
+		NewSendMessageCommandHandler(csrepo, roomRepo, messagePublisher, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubLeaveChatSessionCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		LeaveChatSessionCommandType,
+		NewLeaveChatSessionCommandHandler(csrepo, roomRepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubCancelMatchRequestCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		CancelMatchRequestCommandType,
+		NewCancelMatchRequestCommandHandler(csrepo, matchRequester, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubSkipPartnerCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		SkipPartnerCommandType,
+		NewSkipPartnerCommandHandler(csrepo, matchRequester, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubExpireChatSessionCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		ExpireChatSessionCommandType,
+		NewExpireChatSessionCommandHandler(csrepo, roomRepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubExpireMatchRequestCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		ExpireMatchRequestCommandType,
+		NewExpireMatchRequestCommandHandler(csrepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubUpdateQueueStatusCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		UpdateQueueStatusCommandType,
+		NewUpdateQueueStatusCommandHandler(csrepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubAcceptMatchCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		AcceptMatchCommandType,
+		NewAcceptMatchCommandHandler(csrepo, matchRequester, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubDeclineMatchCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		DeclineMatchCommandType,
+		NewDeclineMatchCommandHandler(csrepo, matchRequester, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubProposeMatchCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		ProposeMatchCommandType,
+		NewProposeMatchCommandHandler(csrepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubCancelMatchProposalCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		CancelMatchProposalCommandType,
+		NewCancelMatchProposalCommandHandler(csrepo, userNotifier, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubBlockUserCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		BlockUserCommandType,
+		NewBlockUserCommandHandler(csrepo, blockListRepo, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubUnblockUserCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		UnblockUserCommandType,
+		NewUnblockUserCommandHandler(blockListRepo, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
+	unsubRatePartnerCmd, err := messaging.SubscribeCommand(
+		ctx,
+		cmdbus,
+		RatePartnerCommandType,
+		NewRatePartnerCommandHandler(csrepo, matchRequester, logger),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register command handler")
+	}
+
 	closer := func() {
 		unsubCreateChatSessionCmd()
+		unsubCreateRoomCmd()
+		unsubSendMessageCmd()
+		unsubLeaveChatSessionCmd()
+		unsubCancelMatchRequestCmd()
+		unsubSkipPartnerCmd()
+		unsubExpireChatSessionCmd()
+		unsubExpireMatchRequestCmd()
+		unsubUpdateQueueStatusCmd()
+		unsubAcceptMatchCmd()
+		unsubDeclineMatchCmd()
+		unsubProposeMatchCmd()
+		unsubCancelMatchProposalCmd()
+		unsubBlockUserCmd()
+		unsubUnblockUserCmd()
+		unsubRatePartnerCmd()
 	}
 
 	return cmdbus, closer, nil
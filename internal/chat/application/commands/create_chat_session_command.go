@@ -6,13 +6,26 @@ type CreateChatSessionCommand struct {
 	messaging.BaseCommand
 	CommandInfo
 
-	UserNickname                 string   `json:"user_nickname"`
-	UserAge                      int32    `json:"user_age"`
-	UserGender                   string   `json:"user_gender"`
-	UserMatchPreferenceMinAge    int32    `json:"user_match_preference_min_age"`
-	UserMatchPreferenceMaxAge    int32    `json:"user_match_preference_max_age"`
-	UserMatchPreferenceGender    string   `json:"user_match_preference_gender"`
-	UserMatchPreferenceInterests []string `json:"user_match_preference_interests"`
+	UserNickname                     string   `json:"user_nickname"`
+	UserAge                          int32    `json:"user_age"`
+	UserGender                       string   `json:"user_gender"`
+	UserLocationLatitude             float64  `json:"user_location_latitude"`
+	UserLocationLongitude            float64  `json:"user_location_longitude"`
+	UserLocationCountryCode          string   `json:"user_location_country_code"`
+	UserLocationCityCode             string   `json:"user_location_city_code"`
+	UserLanguages                    []string `json:"user_languages"`
+	UserMatchPreferenceMinAge        int32    `json:"user_match_preference_min_age"`
+	UserMatchPreferenceMaxAge        int32    `json:"user_match_preference_max_age"`
+	UserMatchPreferenceGender        string   `json:"user_match_preference_gender"`
+	UserMatchPreferenceInterests     []string `json:"user_match_preference_interests"`
+	UserMatchPreferenceMaxDistanceKm float64  `json:"user_match_preference_max_distance_km"`
+	UserMatchPreferenceLocationScope string   `json:"user_match_preference_location_scope"`
+	UserMatchPreferenceMaxWaitTime   int32    `json:"user_match_preference_max_wait_time_seconds"`
+
+	// UserMatchPreferenceRequireSharedLanguage only matches users speaking one of the UserLanguages.
+	UserMatchPreferenceRequireSharedLanguage bool `json:"user_match_preference_require_shared_language"`
+	// UserMatchPreferenceGroupSize asks for a group room of the given size instead of a one-to-one match.
+	UserMatchPreferenceGroupSize int32 `json:"user_match_preference_group_size"`
 }
 
 type CreateChatSessionResponse struct {
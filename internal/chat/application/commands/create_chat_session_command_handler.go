@@ -2,11 +2,13 @@ package chatcommands
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog"
 	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
 	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	geo "github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -42,13 +44,9 @@ func (h CreateChatSessionCommandHandler) Handle(ctx context.Context, cmd CreateC
 		Int32("user_age", cmd.UserAge).
 		Msg("an user requested a new random chat session")
 
-	if exists, err := h.chatSessionRepo.Exists(ctx, userID); err != nil {
-		return err
-	} else if exists {
-		return chatdomain.
-			ErrChatSessionAlreadyExists.
-			WithAggregateID(userID).
-			WithAggregateName(chatdomain.AggregateName)
+	// the preferences ignore the invalid group sizes
+	if !matchmaking.IsValidGroupSize(cmd.UserMatchPreferenceGroupSize) {
+		return chatdomain.ErrUserGroupSizeInvalid
 	}
 
 	user, err := chatdomain.NewUser(
@@ -60,13 +58,19 @@ func (h CreateChatSessionCommandHandler) Handle(ctx context.Context, cmd CreateC
 			WithMinAge(cmd.UserMatchPreferenceMinAge).
 			WithMaxAge(cmd.UserMatchPreferenceMaxAge).
 			WithGender(gender.Parse(cmd.UserMatchPreferenceGender)).
-			WithInterests(cmd.UserMatchPreferenceInterests),
+			WithInterests(cmd.UserMatchPreferenceInterests).
+			WithMaxDistanceKm(cmd.UserMatchPreferenceMaxDistanceKm).
+			WithLocationScope(matchmaking.ParseLocationScope(cmd.UserMatchPreferenceLocationScope)).
+			WithMaxWaitTimeSeconds(cmd.UserMatchPreferenceMaxWaitTime).
+			WithRequireSharedLanguage(cmd.UserMatchPreferenceRequireSharedLanguage).
+			WithGroupSize(cmd.UserMatchPreferenceGroupSize),
+		append(userLocationOptions(cmd), chatdomain.WithLanguages(cmd.UserLanguages...))...,
 	)
 	if err != nil {
 		return err
 	}
 
-	cs, err := chatdomain.NewChatSession(user.ID(), user)
+	cs, err := h.startChatSession(ctx, user)
 	if err != nil {
 		return err
 	}
@@ -81,3 +85,27 @@ func (h CreateChatSessionCommandHandler) Handle(ctx context.Context, cmd CreateC
 
 	return nil
 }
+
+// userLocationOptions returns the location option of the user, when it has been shared.
+func userLocationOptions(cmd CreateChatSessionCommand) []chatdomain.NewUserOption {
+	location := geo.New(cmd.UserLocationLatitude, cmd.UserLocationLongitude).
+		WithCountryCode(cmd.UserLocationCountryCode).
+		WithCityCode(cmd.UserLocationCityCode)
+	if location.IsEmpty() && location.CountryCode == "" && location.CityCode == "" {
+		return nil
+	}
+	return []chatdomain.NewUserOption{chatdomain.WithLocation(&location)}
+}
+
+// startChatSession creates the user ChatSession, or restarts it when the previous one was closed.
+func (h CreateChatSessionCommandHandler) startChatSession(ctx context.Context, user chatdomain.User) (*chatdomain.ChatSession, error) {
+	cs, err := h.chatSessionRepo.FindByID(ctx, user.ID().String())
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return chatdomain.NewChatSession(user.ID(), user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.Restart(user)
+}
@@ -0,0 +1,19 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type CreateRoomCommand struct {
+	messaging.BaseCommand
+
+	RoomID         string   `json:"room_id"`
+	ParticipantIDs []string `json:"participant_ids"`
+}
+
+// NewCreateRoomCommand creates a new CreateRoomCommand for the users of a match.
+func NewCreateRoomCommand(matchID string, participantIDs ...string) CreateRoomCommand {
+	return CreateRoomCommand{
+		BaseCommand:    messaging.NewBaseCommand(CreateRoomCommandType),
+		RoomID:         matchID,
+		ParticipantIDs: participantIDs,
+	}
+}
@@ -0,0 +1,120 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const CreateRoomCommandType = "randomtalk.chat.create_room"
+
+func NewCreateRoomCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) CreateRoomCommandHandler {
+	return CreateRoomCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		roomRepo:        roomRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// CreateRoomCommandHandler opens the Room where the users of a match talk to each other.
+// It is dispatched by the system when a match notification is received,
+// moves the ChatSession of every participant to the matched status and tells them.
+// Match notifications can be redelivered, so creating the same Room again
+// only completes what the previous attempt left undone.
+type CreateRoomCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	roomRepo        chatdomain.RoomRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h CreateRoomCommandHandler) Handle(ctx context.Context, cmd CreateRoomCommand) error {
+	participants := make([]chatdomain.ID, 0, len(cmd.ParticipantIDs))
+	for _, id := range cmd.ParticipantIDs {
+		participants = append(participants, chatdomain.ID(id))
+	}
+
+	room, err := chatdomain.NewRoom(chatdomain.ID(cmd.RoomID), participants...)
+	if err != nil {
+		return err
+	}
+
+	err = h.roomRepo.Save(ctx, room)
+	if errors.Is(err, chatdomain.ErrRoomAlreadyExists) {
+		room, err = h.roomRepo.FindByID(ctx, room.ID())
+	}
+	if err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("room_id", cmd.RoomID).
+		Strs("participant_ids", cmd.ParticipantIDs).
+		Msg("a new room was created")
+
+	for _, participant := range room.Participants() {
+		matched, err := h.matchChatSession(ctx, room, participant)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		if err = h.userNotifier.NotifyNewMatch(ctx, participant, room.ID(), participants); err != nil {
+			h.logger.Warn().
+				Err(err).
+				Str("user_id", participant.String()).
+				Str("room_id", cmd.RoomID).
+				Msg("failed to notify new match")
+		}
+	}
+	return nil
+}
+
+// matchChatSession moves the ChatSession of the user to the Room.
+// It reports whether the ChatSession was matched now, rather than before or never,
+// as the ChatSession of a user that is no longer waiting is left as it is.
+func (h CreateRoomCommandHandler) matchChatSession(ctx context.Context, room *chatdomain.Room, userID chatdomain.ID) (bool, error) {
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID.String())
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		// users of the gRPC API may not have a chat session
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if cs.Status() == chatdomain.ChatSessionMatched && cs.MatchID() == room.ID() {
+		// matched by a previous delivery of the notification
+		return false, nil
+	}
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		// the user went away in the meantime, the other participants still get the room
+		h.logger.Warn().
+			Str("user_id", userID.String()).
+			Str("room_id", room.ID().String()).
+			Str("status", cs.Status().String()).
+			Msg("matched chat session is no longer waiting")
+		return false, nil
+	}
+
+	partners := room.PartnersOf(userID)
+	if room.IsGroup() {
+		err = cs.MatchGroup(partners, room.ID())
+	} else {
+		err = cs.Match(partners[0], room.ID())
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, h.chatSessionRepo.Save(ctx, cs)
+}
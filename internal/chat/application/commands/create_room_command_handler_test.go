@@ -0,0 +1,81 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestCreateRoomCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should match the sessions and notify the users", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		for _, userID := range []string{"alice", "bob"} {
+			cs, err := sessionRepo.FindByID(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, chatdomain.ChatSessionMatched, cs.Status())
+			require.Equal(t, chatdomain.ID("match-1"), cs.MatchID())
+		}
+		require.Equal(t, []chatdomain.ID{"alice", "bob"}, notifier.matched)
+	})
+
+	t.Run("should complete a room created by a previous delivery", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		roomRepo := chatinmemory.NewRoomRepository()
+		room, err := chatdomain.NewRoom("match-1", "alice", "bob")
+		require.NoError(t, err)
+		require.NoError(t, roomRepo.Save(ctx, room))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewCreateRoomCommandHandler(sessionRepo, roomRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+		require.Equal(t, []chatdomain.ID{"alice", "bob"}, notifier.matched)
+
+		// once complete, a redelivery changes nothing
+		require.NoError(t, handler.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+		require.Equal(t, []chatdomain.ID{"alice", "bob"}, notifier.matched)
+	})
+
+	t.Run("should match the other users when a session is no longer waiting", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+		saveTestChatSession(t, sessionRepo, "carol")
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.NoError(t, alice.Leave())
+		require.NoError(t, sessionRepo.Save(ctx, alice))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob", "carol")))
+
+		alice, err = sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionLeft, alice.Status())
+		for _, userID := range []string{"bob", "carol"} {
+			cs, err := sessionRepo.FindByID(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, chatdomain.ChatSessionMatched, cs.Status())
+		}
+		require.Equal(t, []chatdomain.ID{"bob", "carol"}, notifier.matched)
+	})
+}
@@ -0,0 +1,33 @@
+package chatcommands
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const DeclineMatchCommandType = "randomtalk.chat.decline_match"
+
+func NewDeclineMatchCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	matchRequester chatdomain.MatchRequester,
+	logger zerolog.Logger,
+) DeclineMatchCommandHandler {
+	return DeclineMatchCommandHandler{
+		matchResponder: matchResponder{
+			logger:          logger,
+			chatSessionRepo: chatSessionRepo,
+			matchRequester:  matchRequester,
+		},
+	}
+}
+
+// DeclineMatchCommandHandler tells the matchmaker that the user declines the proposed match.
+type DeclineMatchCommandHandler struct {
+	matchResponder
+}
+
+func (h DeclineMatchCommandHandler) Handle(ctx context.Context, cmd DeclineMatchCommand) error {
+	return h.respond(ctx, cmd.MatchID, false)
+}
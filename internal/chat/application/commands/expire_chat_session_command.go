@@ -0,0 +1,14 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+// ExpireChatSessionCommand is dispatched by the server when the user did not reconnect in time.
+type ExpireChatSessionCommand struct {
+	messaging.BaseCommand
+}
+
+func NewExpireChatSessionCommand() ExpireChatSessionCommand {
+	return ExpireChatSessionCommand{
+		BaseCommand: messaging.NewBaseCommand(ExpireChatSessionCommandType),
+	}
+}
@@ -0,0 +1,95 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const ExpireChatSessionCommandType = "randomtalk.chat.expire_chat_session"
+
+// ChatSessionEndedByExpirationReason is the reason the ChatSession of the partner ends with
+// when the ChatSession of the user expires.
+const ChatSessionEndedByExpirationReason = "partner_expired"
+
+func NewExpireChatSessionCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) ExpireChatSessionCommandHandler {
+	return ExpireChatSessionCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		roomRepo:        roomRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// ExpireChatSessionCommandHandler expires the ChatSession of a user that went away
+// and, when the user was matched, ends the ChatSession of its partner.
+// The other members of a group room keep talking to each other.
+type ExpireChatSessionCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	roomRepo        chatdomain.RoomRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h ExpireChatSessionCommandHandler) Handle(ctx context.Context, _ ExpireChatSessionCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		// the user never started a chat session
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.IsClosed() {
+		return nil
+	}
+
+	partnerID, matchID, inGroup := cs.PartnerID(), cs.MatchID(), cs.IsInGroup()
+	if err = cs.Expire(); err != nil {
+		return err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("match_id", matchID.String()).
+		Msg("chat session expired")
+
+	if inGroup {
+		return leaveGroupRoom(ctx, h.chatSessionRepo, h.roomRepo, h.userNotifier, h.logger, cs.ID(), matchID, ChatSessionEndedByExpirationReason)
+	}
+
+	if partnerID.IsEmpty() {
+		return nil
+	}
+
+	if err = endPartnerChatSession(ctx, h.chatSessionRepo, partnerID, matchID, ChatSessionEndedByExpirationReason); err != nil {
+		return err
+	}
+
+	if err = h.userNotifier.NotifyUserLeft(ctx, partnerID, cs.ID(), matchID); err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", partnerID.String()).
+			Str("match_id", matchID.String()).
+			Msg("failed to notify partner")
+	}
+	return nil
+}
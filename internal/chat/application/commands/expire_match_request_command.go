@@ -0,0 +1,22 @@
+package chatcommands
+
+import (
+	"time"
+
+	"github.com/xfrr/go-cqrsify/messaging"
+)
+
+// ExpireMatchRequestCommand is dispatched by the server when the matchmaker
+// could not find a match before the user wait deadline.
+type ExpireMatchRequestCommand struct {
+	messaging.BaseCommand
+
+	Waited time.Duration `json:"-"`
+}
+
+func NewExpireMatchRequestCommand(waited time.Duration) ExpireMatchRequestCommand {
+	return ExpireMatchRequestCommand{
+		BaseCommand: messaging.NewBaseCommand(ExpireMatchRequestCommandType),
+		Waited:      waited,
+	}
+}
@@ -0,0 +1,77 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const ExpireMatchRequestCommandType = "randomtalk.chat.expire_match_request"
+
+// ChatSessionEndedByNoMatchReason is the reason the ChatSession ends with
+// when no match was found before the user wait deadline.
+const ChatSessionEndedByNoMatchReason = "no_match_found"
+
+func NewExpireMatchRequestCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) ExpireMatchRequestCommandHandler {
+	return ExpireMatchRequestCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// ExpireMatchRequestCommandHandler ends the ChatSession of a user that is still waiting
+// for a match, so it can request a new one, and tells the user that no match was found.
+type ExpireMatchRequestCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h ExpireMatchRequestCommandHandler) Handle(ctx context.Context, cmd ExpireMatchRequestCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		// the user was matched or went away in the meantime
+		return nil
+	}
+
+	if err = cs.End(ChatSessionEndedByNoMatchReason); err != nil {
+		return err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Dur("waited", cmd.Waited).
+		Msg("no match found for chat session")
+
+	if err = h.userNotifier.NotifyNoMatchFound(ctx, cs.ID(), cmd.Waited); err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Msg("failed to notify no match found")
+	}
+	return nil
+}
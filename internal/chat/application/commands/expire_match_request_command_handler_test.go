@@ -0,0 +1,55 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestExpireMatchRequestCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should end the waiting session and notify the user", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewExpireMatchRequestCommandHandler(sessionRepo, notifier, zerolog.Nop())
+
+		cmd := chatcommands.NewExpireMatchRequestCommand(2 * time.Minute)
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), cmd))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, alice.Status())
+		require.Equal(t, []chatdomain.ID{"alice"}, notifier.noMatch)
+	})
+
+	t.Run("should ignore users that are already matched", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewExpireMatchRequestCommandHandler(sessionRepo, notifier, zerolog.Nop())
+
+		cmd := chatcommands.NewExpireMatchRequestCommand(time.Minute)
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), cmd))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionMatched, alice.Status())
+		require.Empty(t, notifier.noMatch)
+	})
+}
@@ -0,0 +1,8 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type LeaveChatSessionCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+}
@@ -0,0 +1,169 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const LeaveChatSessionCommandType = "randomtalk.chat.leave_chat_session"
+
+// ChatSessionEndedByPartnerReason is the reason the ChatSession of the partner ends with
+// when a user leaves.
+const ChatSessionEndedByPartnerReason = "partner_left"
+
+// maxRoomUpdateAttempts bounds the retries of the members leaving a group room at the same time.
+const maxRoomUpdateAttempts = 5
+
+func NewLeaveChatSessionCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) LeaveChatSessionCommandHandler {
+	return LeaveChatSessionCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		roomRepo:        roomRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// LeaveChatSessionCommandHandler closes the ChatSession of the user and,
+// when the user was matched, ends the ChatSession of its partner.
+// The other members of a group room keep talking to each other.
+type LeaveChatSessionCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	roomRepo        chatdomain.RoomRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h LeaveChatSessionCommandHandler) Handle(ctx context.Context, _ LeaveChatSessionCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	partnerID, matchID, inGroup := cs.PartnerID(), cs.MatchID(), cs.IsInGroup()
+	if err = cs.Leave(); err != nil {
+		return err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("match_id", matchID.String()).
+		Msg("an user left the chat session")
+
+	if inGroup {
+		return leaveGroupRoom(ctx, h.chatSessionRepo, h.roomRepo, h.userNotifier, h.logger, cs.ID(), matchID, ChatSessionEndedByPartnerReason)
+	}
+
+	if partnerID.IsEmpty() {
+		return nil
+	}
+	return endPartnerChatSession(ctx, h.chatSessionRepo, partnerID, matchID, ChatSessionEndedByPartnerReason)
+}
+
+// leaveGroupRoom removes the user from its group room and tells the remaining members.
+// The ChatSession of the last member ends with the given reason, since nobody is left to talk to.
+func leaveGroupRoom(
+	ctx context.Context,
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+	userID, roomID chatdomain.ID,
+	reason string,
+) error {
+	room, err := leaveRoom(ctx, roomRepo, userID, roomID)
+	if err != nil || room == nil {
+		return err
+	}
+
+	members := room.Participants()
+	for _, memberID := range members {
+		if err = userNotifier.NotifyUserLeft(ctx, memberID, userID, roomID); err != nil {
+			logger.Warn().
+				Err(err).
+				Str("user_id", memberID.String()).
+				Str("room_id", roomID.String()).
+				Msg("failed to notify group member")
+		}
+	}
+
+	if len(members) != 1 {
+		return nil
+	}
+	return endPartnerChatSession(ctx, chatSessionRepo, members[0], roomID, reason)
+}
+
+// leaveRoom removes the user from the room, retrying on the latest room when other
+// members left it at the same time. It returns nil when the room or the user are gone.
+func leaveRoom(ctx context.Context, roomRepo chatdomain.RoomRepository, userID, roomID chatdomain.ID) (*chatdomain.Room, error) {
+	for range maxRoomUpdateAttempts {
+		room, err := roomRepo.FindByID(ctx, roomID)
+		if errors.Is(err, chatdomain.ErrRoomNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err = room.Leave(userID); errors.Is(err, chatdomain.ErrUserNotInRoom) {
+			// the user already left
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = roomRepo.Update(ctx, room)
+		if errors.Is(err, chatdomain.ErrRoomVersionMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return room, nil
+	}
+	return nil, chatdomain.ErrRoomVersionMismatch
+}
+
+// endPartnerChatSession ends the ChatSession of the partner when it is still in the given match.
+func endPartnerChatSession(
+	ctx context.Context,
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	partnerID, matchID chatdomain.ID,
+	reason string,
+) error {
+	partnerSession, err := chatSessionRepo.FindByID(ctx, partnerID.String())
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the partner may already be talking to someone else
+	if partnerSession.IsClosed() || partnerSession.MatchID() != matchID {
+		return nil
+	}
+
+	if err = partnerSession.End(reason); err != nil {
+		return err
+	}
+	return chatSessionRepo.Save(ctx, partnerSession)
+}
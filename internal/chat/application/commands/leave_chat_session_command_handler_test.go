@@ -0,0 +1,127 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func saveTestChatSession(t *testing.T, repo chatdomain.ChatSessionRepository, userID chatdomain.ID) {
+	t.Helper()
+
+	user, err := chatdomain.NewUser(userID, "nick", 25, gender.Male, matchmaking.DefaultPreferences())
+	require.NoError(t, err)
+
+	cs, err := chatdomain.NewChatSession(userID, user)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), cs))
+}
+
+func TestLeaveChatSessionCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should close the session of the user and end the partner one", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		roomRepo := chatinmemory.NewRoomRepository()
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, roomRepo, &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		handler := chatcommands.NewLeaveChatSessionCommandHandler(sessionRepo, roomRepo, &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.LeaveChatSessionCommand{}))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionLeft, alice.Status())
+
+		bob, err := sessionRepo.FindByID(ctx, "bob")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, bob.Status())
+	})
+
+	t.Run("should keep the group room open for the other members", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		for _, userID := range []chatdomain.ID{"alice", "bob", "carol"} {
+			saveTestChatSession(t, sessionRepo, userID)
+		}
+
+		roomRepo := chatinmemory.NewRoomRepository()
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, roomRepo, &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob", "carol")))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewLeaveChatSessionCommandHandler(sessionRepo, roomRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.LeaveChatSessionCommand{}))
+
+		room, err := roomRepo.FindByID(ctx, "match-1")
+		require.NoError(t, err)
+		require.Equal(t, []chatdomain.ID{"bob", "carol"}, room.Participants())
+		require.ElementsMatch(t, []chatdomain.ID{"bob", "carol"}, notifier.notified)
+
+		for _, userID := range []string{"bob", "carol"} {
+			cs, err := sessionRepo.FindByID(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, chatdomain.ChatSessionMatched, cs.Status())
+		}
+
+		// the last member has nobody left to talk to
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "bob"), chatcommands.LeaveChatSessionCommand{}))
+
+		carol, err := sessionRepo.FindByID(ctx, "carol")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, carol.Status())
+	})
+
+	t.Run("should allow the user to start a new session after leaving", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		handler := chatcommands.NewLeaveChatSessionCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		userCtx := auth.ContextWithUserID(ctx, "alice")
+		require.NoError(t, handler.Handle(userCtx, chatcommands.LeaveChatSessionCommand{}))
+		require.ErrorIs(t, handler.Handle(userCtx, chatcommands.LeaveChatSessionCommand{}), chatdomain.ErrChatSessionClosed)
+
+		createSession := chatcommands.NewCreateChatSessionCommandHandler(sessionRepo, noopMatchRequester{}, zerolog.Nop())
+		require.NoError(t, createSession.Handle(userCtx, chatcommands.CreateChatSessionCommand{
+			UserNickname: "alice",
+			UserAge:      30,
+			UserGender:   "female",
+		}))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, alice.Status())
+
+		err = createSession.Handle(userCtx, chatcommands.CreateChatSessionCommand{UserNickname: "alice", UserAge: 30})
+		require.ErrorIs(t, err, chatdomain.ErrChatSessionAlreadyExists)
+	})
+}
+
+type noopMatchRequester struct{}
+
+func (noopMatchRequester) RequestMatch(context.Context, *chatdomain.ChatSession) error {
+	return nil
+}
+
+func (noopMatchRequester) RespondToMatch(context.Context, *chatdomain.ChatSession, chatdomain.ID, bool) error {
+	return nil
+}
+
+func (noopMatchRequester) CancelMatchRequest(context.Context, *chatdomain.ChatSession) error {
+	return nil
+}
+
+func (noopMatchRequester) RatePartner(context.Context, *chatdomain.ChatSession, chatdomain.Rating) error {
+	return nil
+}
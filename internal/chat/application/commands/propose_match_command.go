@@ -0,0 +1,21 @@
+package chatcommands
+
+import (
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+// ProposeMatchCommand is dispatched by the server when the matchmaker proposes a match
+// that the user has to accept or decline.
+type ProposeMatchCommand struct {
+	messaging.BaseCommand
+
+	Proposal chatdomain.MatchProposal `json:"-"`
+}
+
+func NewProposeMatchCommand(proposal chatdomain.MatchProposal) ProposeMatchCommand {
+	return ProposeMatchCommand{
+		BaseCommand: messaging.NewBaseCommand(ProposeMatchCommandType),
+		Proposal:    proposal,
+	}
+}
@@ -0,0 +1,63 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const ProposeMatchCommandType = "randomtalk.chat.propose_match"
+
+func NewProposeMatchCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) ProposeMatchCommandHandler {
+	return ProposeMatchCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// ProposeMatchCommandHandler tells the user the match proposed to it, so it can accept
+// or decline it from any chat instance. The ChatSession keeps waiting until the match is confirmed.
+type ProposeMatchCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h ProposeMatchCommandHandler) Handle(ctx context.Context, cmd ProposeMatchCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		// the user went away in the meantime, the proposal expires
+		return nil
+	}
+
+	err = h.userNotifier.NotifyMatchProposed(ctx, cs.ID(), cmd.Proposal)
+	if err != nil {
+		// the proposal expires unless the user accepts it anyway
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Str("match_id", cmd.Proposal.MatchID.String()).
+			Msg("failed to notify match proposed")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+)
+
+func TestProposeMatchCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	proposal := chatdomain.MatchProposal{
+		MatchID:   "match-1",
+		ExpiresAt: time.Now().Add(30 * time.Second),
+		Partner: chatdomain.MatchPartner{
+			UserID: "bob",
+			Age:    30,
+			Gender: gender.Male,
+		},
+	}
+
+	t.Run("should notify the waiting user", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewProposeMatchCommandHandler(sessionRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewProposeMatchCommand(proposal)))
+
+		require.Equal(t, map[chatdomain.ID]chatdomain.MatchProposal{"alice": proposal}, notifier.proposed)
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, alice.Status())
+	})
+
+	t.Run("should skip the users no longer waiting", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.NoError(t, alice.Leave())
+		require.NoError(t, sessionRepo.Save(ctx, alice))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewProposeMatchCommandHandler(sessionRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewProposeMatchCommand(proposal)))
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.NewProposeMatchCommand(proposal)))
+
+		require.Empty(t, notifier.proposed)
+	})
+}
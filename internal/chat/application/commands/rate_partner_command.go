@@ -0,0 +1,16 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type RatePartnerCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// UserID is the user to rate. It defaults to the current partner,
+	// or to the last skipped one, of the user.
+	UserID string `json:"user_id"`
+	// Liked is the thumbs up (true) or down (false) of the user.
+	Liked bool `json:"liked"`
+	// Tags qualify the rating: "friendly", "rude", "spam" or "inappropriate".
+	Tags []string `json:"tags,omitempty"`
+}
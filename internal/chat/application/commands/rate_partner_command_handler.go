@@ -0,0 +1,66 @@
+package chatcommands
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+const RatePartnerCommandType = "randomtalk.chat.rate_partner"
+
+func NewRatePartnerCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	matchRequester chatdomain.MatchRequester,
+	logger zerolog.Logger,
+) RatePartnerCommandHandler {
+	return RatePartnerCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		matchRequester:  matchRequester,
+	}
+}
+
+// RatePartnerCommandHandler sends the rating of the user for a partner it talked with
+// to the matchmaker, which builds the reputation of the partner from it.
+type RatePartnerCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	matchRequester  chatdomain.MatchRequester
+}
+
+func (h RatePartnerCommandHandler) Handle(ctx context.Context, cmd RatePartnerCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	tags, err := matchmaking.ParseRatingTags(cmd.Tags...)
+	if err != nil {
+		return err
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	rating, err := cs.RatePartner(chatdomain.ID(cmd.UserID), cmd.Liked, tags...)
+	if err != nil {
+		return err
+	}
+
+	if err = h.matchRequester.RatePartner(ctx, cs, rating); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("rated_user_id", rating.RatedUserID.String()).
+		Str("match_id", rating.MatchID.String()).
+		Bool("liked", rating.Liked).
+		Msg("an user rated its partner")
+	return nil
+}
@@ -0,0 +1,85 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestRatePartnerCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*recordingMatchRequester, chatcommands.RatePartnerCommandHandler, chatcommands.SkipPartnerCommandHandler) {
+		t.Helper()
+
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+		saveTestChatSession(t, sessionRepo, "carol")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		requester := &recordingMatchRequester{}
+		rate := chatcommands.NewRatePartnerCommandHandler(sessionRepo, requester, zerolog.Nop())
+		skip := chatcommands.NewSkipPartnerCommandHandler(sessionRepo, requester, &recordingUserNotifier{}, zerolog.Nop())
+		return requester, rate, skip
+	}
+
+	t.Run("should rate the current partner by default", func(t *testing.T) {
+		requester, rate, _ := setup(t)
+
+		err := rate.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.RatePartnerCommand{
+			Liked: false,
+			Tags:  []string{"rude", "Spam", "rude"},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []chatdomain.Rating{{
+			RatedUserID: "bob",
+			MatchID:     "match-1",
+			Tags:        []matchmaking.RatingTag{matchmaking.RatingTagRude, matchmaking.RatingTagSpam},
+		}}, requester.ratings)
+	})
+
+	t.Run("should rate the last partner after skipping it", func(t *testing.T) {
+		requester, rate, skip := setup(t)
+
+		userCtx := auth.ContextWithUserID(ctx, "alice")
+		require.NoError(t, skip.Handle(userCtx, chatcommands.SkipPartnerCommand{}))
+		require.NoError(t, rate.Handle(userCtx, chatcommands.RatePartnerCommand{UserID: "bob", Liked: true}))
+
+		require.Len(t, requester.ratings, 1)
+		require.Equal(t, chatdomain.ID("bob"), requester.ratings[0].RatedUserID)
+		require.Equal(t, chatdomain.ID("match-1"), requester.ratings[0].MatchID)
+		require.True(t, requester.ratings[0].Liked)
+	})
+
+	t.Run("should fail when rating an user the user did not talk with", func(t *testing.T) {
+		requester, rate, _ := setup(t)
+
+		userCtx := auth.ContextWithUserID(ctx, "alice")
+		err := rate.Handle(userCtx, chatcommands.RatePartnerCommand{UserID: "carol"})
+		require.ErrorIs(t, err, chatdomain.ErrRatedUserNotPartner)
+
+		err = rate.Handle(userCtx, chatcommands.RatePartnerCommand{UserID: "alice"})
+		require.ErrorIs(t, err, chatdomain.ErrUserCannotRateItself)
+		require.Empty(t, requester.ratings)
+	})
+
+	t.Run("should fail with unknown tags", func(t *testing.T) {
+		requester, rate, _ := setup(t)
+
+		err := rate.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.RatePartnerCommand{Tags: []string{"boring"}})
+		require.ErrorIs(t, err, matchmaking.ErrInvalidRatingTag)
+		require.Empty(t, requester.ratings)
+	})
+}
@@ -0,0 +1,13 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type SendMessageCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// RoomID is the room the message is sent to.
+	// When empty, the last room joined by the sender is used.
+	RoomID string `json:"room_id"`
+	Text   string `json:"text"`
+}
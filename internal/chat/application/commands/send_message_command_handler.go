@@ -0,0 +1,97 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const SendMessageCommandType = "randomtalk.chat.send_message"
+
+func NewSendMessageCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	roomRepo chatdomain.RoomRepository,
+	messagePublisher chatdomain.MessagePublisher,
+	logger zerolog.Logger,
+) SendMessageCommandHandler {
+	return SendMessageCommandHandler{
+		logger:           logger,
+		chatSessionRepo:  chatSessionRepo,
+		roomRepo:         roomRepo,
+		messagePublisher: messagePublisher,
+	}
+}
+
+type SendMessageCommandHandler struct {
+	logger           zerolog.Logger
+	chatSessionRepo  chatdomain.ChatSessionRepository
+	roomRepo         chatdomain.RoomRepository
+	messagePublisher chatdomain.MessagePublisher
+}
+
+func (h SendMessageCommandHandler) Handle(ctx context.Context, cmd SendMessageCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	senderID := chatdomain.ID(userID)
+	room, err := h.findRoom(ctx, senderID, chatdomain.ID(cmd.RoomID))
+	if err != nil {
+		return err
+	}
+
+	if !room.HasParticipant(senderID) {
+		return chatdomain.ErrUserNotInRoom
+	}
+
+	msg, err := chatdomain.NewTextMessage(room.ID(), senderID, cmd.Text)
+	if err != nil {
+		return err
+	}
+
+	if err = h.checkChatSession(ctx, senderID, msg.RoomID()); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("room_id", room.ID().String()).
+		Str("message_id", msg.ID().String()).
+		Msg("an user sent a new message")
+
+	// the sender receives its own message back as the delivery acknowledgement,
+	// carrying the server assigned message ID and timestamp.
+	return h.messagePublisher.Publish(ctx, msg, room.Participants()...)
+}
+
+// checkChatSession checks the sender ChatSession is matched in the room the message is sent to.
+// The messages are not recorded in the ChatSession, its history only holds the conversation lifecycle.
+func (h SendMessageCommandHandler) checkChatSession(ctx context.Context, senderID, roomID chatdomain.ID) error {
+	cs, err := h.chatSessionRepo.FindByID(ctx, senderID.String())
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		// users of the gRPC API may not have a chat session
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionMatched {
+		return chatdomain.ErrChatSessionNotMatched
+	}
+	if cs.MatchID() != roomID {
+		return chatdomain.ErrUserNotInRoom
+	}
+	return nil
+}
+
+func (h SendMessageCommandHandler) findRoom(ctx context.Context, userID, roomID chatdomain.ID) (*chatdomain.Room, error) {
+	if roomID.IsEmpty() {
+		return h.roomRepo.FindByUserID(ctx, userID)
+	}
+	return h.roomRepo.FindByID(ctx, roomID)
+}
@@ -0,0 +1,94 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+type publishedMessage struct {
+	msg        chatdomain.Message
+	recipients []chatdomain.ID
+}
+
+type fakeMessagePublisher struct {
+	published []publishedMessage
+}
+
+func (p *fakeMessagePublisher) Publish(_ context.Context, msg chatdomain.Message, recipients ...chatdomain.ID) error {
+	p.published = append(p.published, publishedMessage{msg: msg, recipients: recipients})
+	return nil
+}
+
+func TestSendMessageCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (chatcommands.SendMessageCommandHandler, *fakeMessagePublisher) {
+		t.Helper()
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		repo := chatinmemory.NewRoomRepository()
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, repo, &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		publisher := &fakeMessagePublisher{}
+		return chatcommands.NewSendMessageCommandHandler(sessionRepo, repo, publisher, zerolog.Nop()), publisher
+	}
+
+	t.Run("should deliver the message to every participant of the room", func(t *testing.T) {
+		handler, publisher := setup(t)
+
+		err := handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.SendMessageCommand{
+			RoomID: "match-1",
+			Text:   "hello bob",
+		})
+		require.NoError(t, err)
+		require.Len(t, publisher.published, 1)
+
+		published := publisher.published[0]
+		require.Equal(t, chatdomain.ID("alice"), published.msg.SenderID())
+		require.Equal(t, chatdomain.ID("match-1"), published.msg.RoomID())
+		require.Equal(t, "hello bob", published.msg.Text())
+		require.ElementsMatch(t, []chatdomain.ID{"alice", "bob"}, published.recipients)
+	})
+
+	t.Run("should use the last room of the sender when no room is given", func(t *testing.T) {
+		handler, publisher := setup(t)
+
+		err := handler.Handle(auth.ContextWithUserID(ctx, "bob"), chatcommands.SendMessageCommand{Text: "hi"})
+		require.NoError(t, err)
+		require.Len(t, publisher.published, 1)
+		require.Equal(t, chatdomain.ID("match-1"), publisher.published[0].msg.RoomID())
+	})
+
+	t.Run("should reject senders outside the room", func(t *testing.T) {
+		handler, publisher := setup(t)
+
+		err := handler.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.SendMessageCommand{
+			RoomID: "match-1",
+			Text:   "hello",
+		})
+		require.ErrorIs(t, err, chatdomain.ErrUserNotInRoom)
+		require.Empty(t, publisher.published)
+	})
+
+	t.Run("should fail when the user is not in any room", func(t *testing.T) {
+		handler, _ := setup(t)
+
+		err := handler.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.SendMessageCommand{Text: "hello"})
+		require.ErrorIs(t, err, chatdomain.ErrRoomNotFound)
+	})
+
+	t.Run("should fail without an authenticated user", func(t *testing.T) {
+		handler, _ := setup(t)
+
+		err := handler.Handle(ctx, chatcommands.SendMessageCommand{RoomID: "match-1", Text: "hello"})
+		require.ErrorIs(t, err, chatcommands.ErrMissingUserIDFromContext)
+	})
+}
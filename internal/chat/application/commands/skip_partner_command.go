@@ -0,0 +1,12 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type SkipPartnerCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// RequeuePartner requests a new match for the skipped partner too,
+	// instead of ending its chat session.
+	RequeuePartner bool `json:"requeue_partner"`
+}
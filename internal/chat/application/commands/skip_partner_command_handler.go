@@ -0,0 +1,127 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const SkipPartnerCommandType = "randomtalk.chat.skip_partner"
+
+// ChatSessionEndedBySkipReason is the reason the ChatSession of the partner ends with
+// when it is skipped and not requeued.
+const ChatSessionEndedBySkipReason = "partner_skipped"
+
+func NewSkipPartnerCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	matchRequester chatdomain.MatchRequester,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) SkipPartnerCommandHandler {
+	return SkipPartnerCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		matchRequester:  matchRequester,
+		userNotifier:    userNotifier,
+	}
+}
+
+// SkipPartnerCommandHandler ends the current match of the user, lets the partner know
+// and requests a new match for the user and, optionally, for the partner.
+type SkipPartnerCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	matchRequester  chatdomain.MatchRequester
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h SkipPartnerCommandHandler) Handle(ctx context.Context, cmd SkipPartnerCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	partnerID, matchID := cs.PartnerID(), cs.MatchID()
+	if err = cs.SkipPartner(); err != nil {
+		return err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, cs); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("partner_id", partnerID.String()).
+		Str("match_id", matchID.String()).
+		Bool("requeue_partner", cmd.RequeuePartner).
+		Msg("an user skipped its partner")
+
+	partnerSession, err := h.releasePartnerChatSession(ctx, partnerID, matchID, cmd.RequeuePartner)
+	if err != nil {
+		return err
+	}
+
+	// the match is already over, a missed notification must not keep the users waiting
+	if err = h.userNotifier.NotifyUserLeft(ctx, partnerID, cs.ID(), matchID); err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", partnerID.String()).
+			Str("match_id", matchID.String()).
+			Msg("failed to notify partner")
+	}
+
+	if err = h.matchRequester.RequestMatch(ctx, cs); err != nil {
+		return err
+	}
+
+	if partnerSession == nil {
+		return nil
+	}
+	return h.matchRequester.RequestMatch(ctx, partnerSession)
+}
+
+// releasePartnerChatSession unpairs the ChatSession of the partner from the skipped match.
+// It returns the ChatSession of the partner only when it must be requeued.
+func (h SkipPartnerCommandHandler) releasePartnerChatSession(
+	ctx context.Context,
+	partnerID, matchID chatdomain.ID,
+	requeue bool,
+) (*chatdomain.ChatSession, error) {
+	partnerSession, err := h.chatSessionRepo.FindByID(ctx, partnerID.String())
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the partner may already be talking to someone else
+	if partnerSession.IsClosed() || partnerSession.MatchID() != matchID {
+		return nil, nil
+	}
+
+	if !requeue {
+		if err = partnerSession.End(ChatSessionEndedBySkipReason); err != nil {
+			return nil, err
+		}
+		return nil, h.chatSessionRepo.Save(ctx, partnerSession)
+	}
+
+	if err = partnerSession.SkippedByPartner(); err != nil {
+		return nil, err
+	}
+
+	if err = h.chatSessionRepo.Save(ctx, partnerSession); err != nil {
+		return nil, err
+	}
+	return partnerSession, nil
+}
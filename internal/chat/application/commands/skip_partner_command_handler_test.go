@@ -0,0 +1,155 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestSkipPartnerCommandHandler(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (chatdomain.ChatSessionRepository, *recordingMatchRequester, *recordingUserNotifier, chatcommands.SkipPartnerCommandHandler) {
+		t.Helper()
+
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		requester := &recordingMatchRequester{}
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewSkipPartnerCommandHandler(sessionRepo, requester, notifier, zerolog.Nop())
+		return sessionRepo, requester, notifier, handler
+	}
+
+	t.Run("should requeue the user and end the partner session", func(t *testing.T) {
+		sessionRepo, requester, notifier, handler := setup(t)
+
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.SkipPartnerCommand{}))
+
+		alice, err := sessionRepo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, alice.Status())
+		require.Equal(t, chatdomain.ID("bob"), alice.LastPartnerID())
+		require.True(t, alice.MatchID().IsEmpty())
+
+		bob, err := sessionRepo.FindByID(ctx, "bob")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, bob.Status())
+
+		require.Equal(t, []chatdomain.ID{"alice"}, requester.requested)
+		require.Equal(t, []chatdomain.ID{"bob"}, notifier.notified)
+	})
+
+	t.Run("should requeue both users when requested", func(t *testing.T) {
+		sessionRepo, requester, notifier, handler := setup(t)
+
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.SkipPartnerCommand{RequeuePartner: true}))
+
+		bob, err := sessionRepo.FindByID(ctx, "bob")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, bob.Status())
+		require.Equal(t, chatdomain.ID("alice"), bob.LastPartnerID())
+
+		require.Equal(t, []chatdomain.ID{"alice", "bob"}, requester.requested)
+		require.Equal(t, []chatdomain.ID{"bob"}, notifier.notified)
+	})
+
+	t.Run("should fail when the user is not matched", func(t *testing.T) {
+		_, requester, notifier, handler := setup(t)
+
+		userCtx := auth.ContextWithUserID(ctx, "alice")
+		require.NoError(t, handler.Handle(userCtx, chatcommands.SkipPartnerCommand{}))
+
+		err := handler.Handle(userCtx, chatcommands.SkipPartnerCommand{})
+		require.ErrorIs(t, err, chatdomain.ErrChatSessionNotMatched)
+		require.Len(t, requester.requested, 1)
+		require.Len(t, notifier.notified, 1)
+	})
+}
+
+type recordingMatchRequester struct {
+	requested []chatdomain.ID
+	responses map[chatdomain.ID]bool
+	cancelled []chatdomain.ID
+	ratings   []chatdomain.Rating
+}
+
+func (r *recordingMatchRequester) RequestMatch(_ context.Context, cs *chatdomain.ChatSession) error {
+	r.requested = append(r.requested, cs.ID())
+	return nil
+}
+
+func (r *recordingMatchRequester) RespondToMatch(_ context.Context, cs *chatdomain.ChatSession, _ chatdomain.ID, accepted bool) error {
+	if r.responses == nil {
+		r.responses = make(map[chatdomain.ID]bool)
+	}
+	r.responses[cs.ID()] = accepted
+	return nil
+}
+
+func (r *recordingMatchRequester) CancelMatchRequest(_ context.Context, cs *chatdomain.ChatSession) error {
+	r.cancelled = append(r.cancelled, cs.ID())
+	return nil
+}
+
+func (r *recordingMatchRequester) RatePartner(_ context.Context, _ *chatdomain.ChatSession, rating chatdomain.Rating) error {
+	r.ratings = append(r.ratings, rating)
+	return nil
+}
+
+type recordingUserNotifier struct {
+	notified    []chatdomain.ID
+	matched     []chatdomain.ID
+	noMatch     []chatdomain.ID
+	proposed    map[chatdomain.ID]chatdomain.MatchProposal
+	cancelled   []chatdomain.ID
+	queueStatus map[chatdomain.ID]chatdomain.QueueStatus
+}
+
+func (n *recordingUserNotifier) NotifyUserLeft(_ context.Context, recipientID, _, _ chatdomain.ID) error {
+	n.notified = append(n.notified, recipientID)
+	return nil
+}
+
+func (n *recordingUserNotifier) NotifyNewMatch(_ context.Context, recipientID, _ chatdomain.ID, _ []chatdomain.ID) error {
+	n.matched = append(n.matched, recipientID)
+	return nil
+}
+
+func (n *recordingUserNotifier) NotifyNoMatchFound(_ context.Context, recipientID chatdomain.ID, _ time.Duration) error {
+	n.noMatch = append(n.noMatch, recipientID)
+	return nil
+}
+
+func (n *recordingUserNotifier) NotifyMatchProposed(_ context.Context, recipientID chatdomain.ID, proposal chatdomain.MatchProposal) error {
+	if n.proposed == nil {
+		n.proposed = make(map[chatdomain.ID]chatdomain.MatchProposal)
+	}
+	n.proposed[recipientID] = proposal
+	return nil
+}
+
+func (n *recordingUserNotifier) NotifyMatchCancelled(_ context.Context, recipientID, _ chatdomain.ID, _ string, _ bool) error {
+	n.cancelled = append(n.cancelled, recipientID)
+	return nil
+}
+
+func (n *recordingUserNotifier) NotifyQueueStatus(_ context.Context, recipientID chatdomain.ID, status chatdomain.QueueStatus) error {
+	if n.queueStatus == nil {
+		n.queueStatus = make(map[chatdomain.ID]chatdomain.QueueStatus)
+	}
+	n.queueStatus[recipientID] = status
+	return nil
+}
@@ -0,0 +1,11 @@
+package chatcommands
+
+import "github.com/xfrr/go-cqrsify/messaging"
+
+type UnblockUserCommand struct {
+	messaging.BaseCommand
+	CommandInfo
+
+	// UserID is the user to remove from the block list.
+	UserID string `json:"user_id"`
+}
@@ -0,0 +1,48 @@
+package chatcommands
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const UnblockUserCommandType = "randomtalk.chat.unblock_user"
+
+func NewUnblockUserCommandHandler(
+	blockListRepo chatdomain.BlockListRepository,
+	logger zerolog.Logger,
+) UnblockUserCommandHandler {
+	return UnblockUserCommandHandler{
+		logger:        logger,
+		blockListRepo: blockListRepo,
+	}
+}
+
+// UnblockUserCommandHandler removes an user from the block list of the user.
+type UnblockUserCommandHandler struct {
+	logger        zerolog.Logger
+	blockListRepo chatdomain.BlockListRepository
+}
+
+func (h UnblockUserCommandHandler) Handle(ctx context.Context, cmd UnblockUserCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	if chatdomain.ID(cmd.UserID).IsEmpty() {
+		return chatdomain.ErrBlockedUserNotProvided
+	}
+
+	if err := h.blockListRepo.Unblock(ctx, chatdomain.ID(userID), chatdomain.ID(cmd.UserID)); err != nil {
+		return err
+	}
+
+	h.logger.Debug().
+		Str("user_id", userID).
+		Str("unblocked_user_id", cmd.UserID).
+		Msg("an user unblocked another user")
+	return nil
+}
@@ -0,0 +1,21 @@
+package chatcommands
+
+import (
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+// UpdateQueueStatusCommand is dispatched by the server when the matchmaker reports
+// the progress of a user waiting for a match.
+type UpdateQueueStatusCommand struct {
+	messaging.BaseCommand
+
+	Status chatdomain.QueueStatus `json:"-"`
+}
+
+func NewUpdateQueueStatusCommand(status chatdomain.QueueStatus) UpdateQueueStatusCommand {
+	return UpdateQueueStatusCommand{
+		BaseCommand: messaging.NewBaseCommand(UpdateQueueStatusCommandType),
+		Status:      status,
+	}
+}
@@ -0,0 +1,61 @@
+package chatcommands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+const UpdateQueueStatusCommandType = "randomtalk.chat.update_queue_status"
+
+func NewUpdateQueueStatusCommandHandler(
+	chatSessionRepo chatdomain.ChatSessionRepository,
+	userNotifier chatdomain.UserNotifier,
+	logger zerolog.Logger,
+) UpdateQueueStatusCommandHandler {
+	return UpdateQueueStatusCommandHandler{
+		logger:          logger,
+		chatSessionRepo: chatSessionRepo,
+		userNotifier:    userNotifier,
+	}
+}
+
+// UpdateQueueStatusCommandHandler tells the user its queue status while it is still
+// waiting for a match. Outdated statuses are dropped.
+type UpdateQueueStatusCommandHandler struct {
+	logger          zerolog.Logger
+	chatSessionRepo chatdomain.ChatSessionRepository
+	userNotifier    chatdomain.UserNotifier
+}
+
+func (h UpdateQueueStatusCommandHandler) Handle(ctx context.Context, cmd UpdateQueueStatusCommand) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return ErrMissingUserIDFromContext
+	}
+
+	cs, err := h.chatSessionRepo.FindByID(ctx, userID)
+	if errors.Is(err, chatdomain.ErrChatSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cs.Status() != chatdomain.ChatSessionWaiting {
+		// the user was matched or went away in the meantime
+		return nil
+	}
+
+	err = h.userNotifier.NotifyQueueStatus(ctx, cs.ID(), cmd.Status)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Msg("failed to notify queue status")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package chatcommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestUpdateQueueStatusCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	status := chatdomain.QueueStatus{CompatibleUsers: 3, Position: 2, EstimatedWait: 20 * time.Second}
+
+	t.Run("should notify the waiting user", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewUpdateQueueStatusCommandHandler(sessionRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewUpdateQueueStatusCommand(status)))
+
+		require.Equal(t, map[chatdomain.ID]chatdomain.QueueStatus{"alice": status}, notifier.queueStatus)
+	})
+
+	t.Run("should drop the status of the users no longer waiting", func(t *testing.T) {
+		sessionRepo := chatinmemory.NewChatSessionRepository()
+		saveTestChatSession(t, sessionRepo, "alice")
+		saveTestChatSession(t, sessionRepo, "bob")
+
+		createRoom := chatcommands.NewCreateRoomCommandHandler(sessionRepo, chatinmemory.NewRoomRepository(), &recordingUserNotifier{}, zerolog.Nop())
+		require.NoError(t, createRoom.Handle(ctx, chatcommands.NewCreateRoomCommand("match-1", "alice", "bob")))
+
+		notifier := &recordingUserNotifier{}
+		handler := chatcommands.NewUpdateQueueStatusCommandHandler(sessionRepo, notifier, zerolog.Nop())
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "alice"), chatcommands.NewUpdateQueueStatusCommand(status)))
+		require.NoError(t, handler.Handle(auth.ContextWithUserID(ctx, "carol"), chatcommands.NewUpdateQueueStatusCommand(status)))
+
+		require.Empty(t, notifier.queueStatus)
+	})
+}
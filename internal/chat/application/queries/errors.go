@@ -0,0 +1,7 @@
+package chatqueries
+
+import "errors"
+
+var (
+	ErrMissingUserID = errors.New("missing user ID")
+)
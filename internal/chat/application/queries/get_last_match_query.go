@@ -0,0 +1,27 @@
+package chatqueries
+
+import (
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+// GetLastMatchQuery retrieves the most recent match of the given user.
+type GetLastMatchQuery struct {
+	messaging.BaseQuery
+
+	UserID string `json:"user_id"`
+}
+
+func NewGetLastMatchQuery(userID string) GetLastMatchQuery {
+	return GetLastMatchQuery{
+		BaseQuery: messaging.NewBaseQuery(GetLastMatchQueryType),
+		UserID:    userID,
+	}
+}
+
+// GetLastMatchQueryReply is the reply to a GetLastMatchQuery.
+type GetLastMatchQueryReply struct {
+	messaging.BaseQueryReply
+
+	Match chatdomain.MatchHistoryEntry `json:"match"`
+}
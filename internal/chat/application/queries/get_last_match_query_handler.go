@@ -0,0 +1,37 @@
+package chatqueries
+
+import (
+	"context"
+
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const GetLastMatchQueryType = "randomtalk.chat.get_last_match"
+
+func NewGetLastMatchQueryHandler(matchHistory chatdomain.MatchHistory) GetLastMatchQueryHandler {
+	return GetLastMatchQueryHandler{
+		matchHistory: matchHistory,
+	}
+}
+
+// GetLastMatchQueryHandler replies with the most recent match of the user.
+type GetLastMatchQueryHandler struct {
+	matchHistory chatdomain.MatchHistory
+}
+
+func (h GetLastMatchQueryHandler) Handle(ctx context.Context, qry GetLastMatchQuery) error {
+	if chatdomain.ID(qry.UserID).IsEmpty() {
+		return ErrMissingUserID
+	}
+
+	match, err := h.matchHistory.GetLastMatch(ctx, chatdomain.ID(qry.UserID))
+	if err != nil {
+		return err
+	}
+
+	return qry.Reply(ctx, GetLastMatchQueryReply{
+		BaseQueryReply: messaging.NewBaseQueryReply(qry),
+		Match:          match,
+	})
+}
@@ -0,0 +1,27 @@
+package chatqueries
+
+import (
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+// ListBlockedUsersQuery retrieves the users blocked by the given user.
+type ListBlockedUsersQuery struct {
+	messaging.BaseQuery
+
+	UserID string `json:"user_id"`
+}
+
+func NewListBlockedUsersQuery(userID string) ListBlockedUsersQuery {
+	return ListBlockedUsersQuery{
+		BaseQuery: messaging.NewBaseQuery(ListBlockedUsersQueryType),
+		UserID:    userID,
+	}
+}
+
+// ListBlockedUsersQueryReply is the reply to a ListBlockedUsersQuery.
+type ListBlockedUsersQueryReply struct {
+	messaging.BaseQueryReply
+
+	BlockedUserIDs []chatdomain.ID `json:"blocked_user_ids"`
+}
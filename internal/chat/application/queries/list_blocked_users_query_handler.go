@@ -0,0 +1,37 @@
+package chatqueries
+
+import (
+	"context"
+
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const ListBlockedUsersQueryType = "randomtalk.chat.list_blocked_users"
+
+func NewListBlockedUsersQueryHandler(blockListRepo chatdomain.BlockListRepository) ListBlockedUsersQueryHandler {
+	return ListBlockedUsersQueryHandler{
+		blockListRepo: blockListRepo,
+	}
+}
+
+// ListBlockedUsersQueryHandler replies with the block list of the user.
+type ListBlockedUsersQueryHandler struct {
+	blockListRepo chatdomain.BlockListRepository
+}
+
+func (h ListBlockedUsersQueryHandler) Handle(ctx context.Context, qry ListBlockedUsersQuery) error {
+	if chatdomain.ID(qry.UserID).IsEmpty() {
+		return ErrMissingUserID
+	}
+
+	blocked, err := h.blockListRepo.FindBlockedUsers(ctx, chatdomain.ID(qry.UserID))
+	if err != nil {
+		return err
+	}
+
+	return qry.Reply(ctx, ListBlockedUsersQueryReply{
+		BaseQueryReply: messaging.NewBaseQueryReply(qry),
+		BlockedUserIDs: blocked,
+	})
+}
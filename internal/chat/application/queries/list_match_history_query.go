@@ -0,0 +1,33 @@
+package chatqueries
+
+import (
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+// ListMatchHistoryQuery retrieves a page of the match history of the given user.
+type ListMatchHistoryQuery struct {
+	messaging.BaseQuery
+
+	UserID string `json:"user_id"`
+	// Cursor is the next cursor of the previous page, empty for the first page.
+	Cursor string `json:"cursor"`
+	// Limit is the maximum number of matches of the page. The matchmaker default is used when zero.
+	Limit int `json:"limit"`
+}
+
+func NewListMatchHistoryQuery(userID, cursor string, limit int) ListMatchHistoryQuery {
+	return ListMatchHistoryQuery{
+		BaseQuery: messaging.NewBaseQuery(ListMatchHistoryQueryType),
+		UserID:    userID,
+		Cursor:    cursor,
+		Limit:     limit,
+	}
+}
+
+// ListMatchHistoryQueryReply is the reply to a ListMatchHistoryQuery.
+type ListMatchHistoryQueryReply struct {
+	messaging.BaseQueryReply
+
+	Page chatdomain.MatchHistoryPage `json:"page"`
+}
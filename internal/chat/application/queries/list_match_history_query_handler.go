@@ -0,0 +1,37 @@
+package chatqueries
+
+import (
+	"context"
+
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const ListMatchHistoryQueryType = "randomtalk.chat.list_match_history"
+
+func NewListMatchHistoryQueryHandler(matchHistory chatdomain.MatchHistory) ListMatchHistoryQueryHandler {
+	return ListMatchHistoryQueryHandler{
+		matchHistory: matchHistory,
+	}
+}
+
+// ListMatchHistoryQueryHandler replies with a page of the match history of the user.
+type ListMatchHistoryQueryHandler struct {
+	matchHistory chatdomain.MatchHistory
+}
+
+func (h ListMatchHistoryQueryHandler) Handle(ctx context.Context, qry ListMatchHistoryQuery) error {
+	if chatdomain.ID(qry.UserID).IsEmpty() {
+		return ErrMissingUserID
+	}
+
+	page, err := h.matchHistory.ListMatchesByUser(ctx, chatdomain.ID(qry.UserID), qry.Cursor, qry.Limit)
+	if err != nil {
+		return err
+	}
+
+	return qry.Reply(ctx, ListMatchHistoryQueryReply{
+		BaseQueryReply: messaging.NewBaseQueryReply(qry),
+		Page:           page,
+	})
+}
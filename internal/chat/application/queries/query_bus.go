@@ -3,13 +3,57 @@ package chatqueries
 import (
 	"context"
 
+	"github.com/rs/zerolog"
 	"github.com/xfrr/go-cqrsify/messaging"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
 )
 
 type QueryBus = messaging.QueryBus
 
-func InitQueryBus(_ context.Context) (QueryBus, func(), error) {
+// InitQueryBus initializes and configures a new query bus instance.
+func InitQueryBus(
+	ctx context.Context,
+	blockListRepo chatdomain.BlockListRepository,
+	matchHistory chatdomain.MatchHistory,
+	logger zerolog.Logger,
+) (QueryBus, func(), error) {
 	qrybus := messaging.NewInMemoryQueryBus()
-	// TODO: add query handlers
-	return qrybus, func() {}, nil
+
+	unsubListBlockedUsersQry, err := messaging.SubscribeQuery(
+		ctx,
+		qrybus,
+		ListBlockedUsersQueryType,
+		NewListBlockedUsersQueryHandler(blockListRepo),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register query handler")
+	}
+
+	unsubListMatchHistoryQry, err := messaging.SubscribeQuery(
+		ctx,
+		qrybus,
+		ListMatchHistoryQueryType,
+		NewListMatchHistoryQueryHandler(matchHistory),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register query handler")
+	}
+
+	unsubGetLastMatchQry, err := messaging.SubscribeQuery(
+		ctx,
+		qrybus,
+		GetLastMatchQueryType,
+		NewGetLastMatchQueryHandler(matchHistory),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to register query handler")
+	}
+
+	closer := func() {
+		unsubListBlockedUsersQry()
+		unsubListMatchHistoryQry()
+		unsubGetLastMatchQry()
+	}
+
+	return qrybus, closer, nil
 }
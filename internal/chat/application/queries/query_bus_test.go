@@ -0,0 +1,87 @@
+package chatqueries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/messaging"
+
+	chatqueries "github.com/xfrr/randomtalk/internal/chat/application/queries"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+type fakeMatchHistory struct {
+	entries map[chatdomain.ID][]chatdomain.MatchHistoryEntry
+}
+
+func (h fakeMatchHistory) ListMatchesByUser(_ context.Context, userID chatdomain.ID, _ string, limit int) (chatdomain.MatchHistoryPage, error) {
+	entries := h.entries[userID]
+	return chatdomain.MatchHistoryPage{Entries: entries[:min(limit, len(entries))]}, nil
+}
+
+func (h fakeMatchHistory) GetLastMatch(_ context.Context, userID chatdomain.ID) (chatdomain.MatchHistoryEntry, error) {
+	if len(h.entries[userID]) == 0 {
+		return chatdomain.MatchHistoryEntry{}, chatdomain.ErrMatchNotFound
+	}
+	return h.entries[userID][0], nil
+}
+
+func TestQueryBus(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	blockListRepo := chatinmemory.NewBlockListRepository()
+	require.NoError(t, blockListRepo.Block(ctx, "alice", "bob"))
+
+	matchHistory := fakeMatchHistory{entries: map[chatdomain.ID][]chatdomain.MatchHistoryEntry{
+		"alice": {
+			{MatchID: "match-2", PartnerID: "carol", CreatedAt: createdAt.Add(time.Minute)},
+			{MatchID: "match-1", PartnerID: "bob", CreatedAt: createdAt},
+		},
+	}}
+
+	qrybus, closer, err := chatqueries.InitQueryBus(ctx, blockListRepo, matchHistory, zerolog.Nop())
+	require.NoError(t, err)
+	t.Cleanup(closer)
+
+	t.Run("should list the blocked users", func(t *testing.T) {
+		reply, err := messaging.DispatchQuery[chatqueries.ListBlockedUsersQuery, chatqueries.ListBlockedUsersQueryReply](
+			ctx, qrybus, chatqueries.NewListBlockedUsersQuery("alice"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []chatdomain.ID{"bob"}, reply.BlockedUserIDs)
+	})
+
+	t.Run("should list the match history", func(t *testing.T) {
+		reply, err := messaging.DispatchQuery[chatqueries.ListMatchHistoryQuery, chatqueries.ListMatchHistoryQueryReply](
+			ctx, qrybus, chatqueries.NewListMatchHistoryQuery("alice", "", 1),
+		)
+		require.NoError(t, err)
+		require.Len(t, reply.Page.Entries, 1)
+		require.Equal(t, chatdomain.ID("match-2"), reply.Page.Entries[0].MatchID)
+	})
+
+	t.Run("should get the last match", func(t *testing.T) {
+		reply, err := messaging.DispatchQuery[chatqueries.GetLastMatchQuery, chatqueries.GetLastMatchQueryReply](
+			ctx, qrybus, chatqueries.NewGetLastMatchQuery("alice"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ID("carol"), reply.Match.PartnerID)
+
+		_, err = messaging.DispatchQuery[chatqueries.GetLastMatchQuery, chatqueries.GetLastMatchQueryReply](
+			ctx, qrybus, chatqueries.NewGetLastMatchQuery("dave"),
+		)
+		require.ErrorIs(t, err, chatdomain.ErrMatchNotFound)
+	})
+
+	t.Run("should require the user", func(t *testing.T) {
+		_, err := messaging.DispatchQuery[chatqueries.GetLastMatchQuery, chatqueries.GetLastMatchQueryReply](
+			ctx, qrybus, chatqueries.NewGetLastMatchQuery(""),
+		)
+		require.ErrorIs(t, err, chatqueries.ErrMissingUserID)
+	})
+}
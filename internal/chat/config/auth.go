@@ -0,0 +1,31 @@
+package chatconfig
+
+import "time"
+
+// Auth holds the configuration used to authenticate the users connecting to the chat.
+// Every enabled method is tried in order: signed JWTs, token introspection and guest tokens.
+type Auth struct {
+	// JWKSFile is the path of the JSON Web Key Set used to verify HS256/RS256 tokens.
+	// JWT authentication is disabled when empty.
+	JWKSFile string `env:"JWKS_FILE" default:""`
+	// JWTIssuer is the issuer the JWTs must be issued by. Any issuer is accepted when empty.
+	JWTIssuer string `env:"JWT_ISSUER" default:""`
+	// JWTAudience is the audience the JWTs must be issued for. Any audience is accepted when empty.
+	JWTAudience string `env:"JWT_AUDIENCE" default:""`
+
+	// IntrospectionURL is the OAuth 2.0 introspection endpoint opaque tokens are resolved with.
+	// Token introspection is disabled when empty.
+	IntrospectionURL string `env:"INTROSPECTION_URL" default:""`
+	// IntrospectionClientID is the client ID used to call the introspection endpoint.
+	IntrospectionClientID string `env:"INTROSPECTION_CLIENT_ID" default:""`
+	// IntrospectionClientSecret is the client secret used to call the introspection endpoint.
+	IntrospectionClientSecret string `env:"INTROSPECTION_CLIENT_SECRET" default:""`
+
+	// GuestEnabled allows anonymous users to connect with guest tokens issued by the server.
+	GuestEnabled bool `env:"GUEST_ENABLED" default:"true"`
+	// GuestSecret is the secret guest tokens are signed with. It must be shared by every instance.
+	// A random secret is generated on start when empty.
+	GuestSecret string `env:"GUEST_SECRET" default:""`
+	// GuestTokenTTL is how long the guest tokens are valid for.
+	GuestTokenTTL time.Duration `env:"GUEST_TOKEN_TTL" default:"24h"`
+}
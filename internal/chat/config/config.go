@@ -12,9 +12,13 @@ type Config struct {
 	ServiceEnvironment string `env:"SERVICE_ENVIRONMENT" default:"development"`
 
 	MatchNotificationsConsumerConfig `envPrefix:"NATS_MATCH_NOTIFICATIONS_CONSUMER_"`
+	QueueStatusConsumerConfig        `envPrefix:"NATS_QUEUE_STATUS_CONSUMER_"`
 	ChatSessionStreamConfig          `envPrefix:"CHAT_SESSION_STREAM_"`
 	NotificationStreamConfig         `envPrefix:"NATS_NOTIFICATION_STREAM_"`
 	HubWebsocketServer               `envPrefix:"HUB_WEBSOCKET_SERVER_"`
+	GrpcAPIServer                    `envPrefix:"GRPC_API_SERVER_"`
+	MatchmakingGrpcAPI               `envPrefix:"MATCHMAKING_GRPC_API_"`
+	Auth                             `envPrefix:"AUTH_"`
 	LoggingConfig                    `envPrefix:"LOGGING_"`
 	NatsConfig                       `envPrefix:"NATS_"`
 	Observability                    `envPrefix:"OBSERVABILITY_"`
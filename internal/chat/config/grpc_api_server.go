@@ -0,0 +1,9 @@
+package chatconfig
+
+// GrpcAPIServer holds the configuration for the chat gRPC API and its HTTP gateway.
+type GrpcAPIServer struct {
+	// Addr is the address the gRPC server will listen on.
+	Addr string `env:"ADDR" default:"0.0.0.0:51001"`
+	// GatewayAddr is the address the grpc-gateway HTTP server will listen on.
+	GatewayAddr string `env:"GATEWAY_ADDR" default:"0.0.0.0:51002"`
+}
@@ -0,0 +1,7 @@
+package chatconfig
+
+// MatchmakingGrpcAPI holds the configuration of the matchmaking gRPC API used by the chat service.
+type MatchmakingGrpcAPI struct {
+	// Addr is the address of the matchmaking gRPC server.
+	Addr string `env:"ADDR" default:"localhost:50000"`
+}
@@ -26,3 +26,8 @@ type MatchNotificationsConsumerConfig struct {
 	Name       string          `env:"NAME" default:"randomtalk_chat_match_events_consumer"`
 	StreamName string          `env:"STREAM_NAME" default:"randomtalk_matchmaking_match_events"`
 }
+
+type QueueStatusConsumerConfig struct {
+	Name       string `env:"NAME" default:"randomtalk_chat_queue_status_consumer"`
+	StreamName string `env:"STREAM_NAME" default:"randomtalk_matchmaking_queue_statuses"`
+}
@@ -24,4 +24,16 @@ type HubWebsocketServer struct {
 	MaxMessageSizeBytes int `env:"MAX_MESSAGE_SIZE" default:"1024"`
 	// MaxConnections is the maximum number of connections the server will accept.
 	MaxConnections int `env:"MAX_CONNECTIONS" default:"1000"`
+	// TokenQueryParam is the query parameter the access token can be sent in.
+	TokenQueryParam string `env:"TOKEN_QUERY_PARAM" default:"access_token"`
+	// TokenCookieName is the cookie the access token can be sent in.
+	TokenCookieName string `env:"TOKEN_COOKIE_NAME" default:"randomtalk_access_token"`
+	// ResumeGracePeriodSeconds is how long a disconnected user can reconnect and resume its session
+	// before its chat session expires. Sessions are kept by the instance the user was connected to,
+	// running several instances needs sticky routing for the users to resume.
+	ResumeGracePeriodSeconds int `env:"RESUME_GRACE_PERIOD_SECONDS" default:"30"`
+	// ResumeBufferSize is the number of messages kept to be replayed to a reconnecting user.
+	ResumeBufferSize int `env:"RESUME_BUFFER_SIZE" default:"256"`
+	// GuestTokenPath is the path guest tokens are issued on, when guest access is enabled.
+	GuestTokenPath string `env:"GUEST_TOKEN_PATH" default:"/guest-tokens"`
 }
@@ -0,0 +1,27 @@
+package chatdomain
+
+import (
+	"context"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+var (
+	ErrBlockedUserNotProvided = domainerror.New("blocked user not provided")
+	ErrUserCannotBlockItself  = domainerror.New("user cannot block itself")
+)
+
+// BlockListRepository persists the users that each user never wants to be matched with.
+type BlockListRepository interface {
+	// Block adds the blocked user to the block list of the given user.
+	Block(ctx context.Context, userID, blockedUserID ID) error
+
+	// Unblock removes the blocked user from the block list of the given user.
+	Unblock(ctx context.Context, userID, blockedUserID ID) error
+
+	// FindBlockedUsers retrieves the users blocked by the given user.
+	FindBlockedUsers(ctx context.Context, userID ID) ([]ID, error)
+
+	// FindBlockingUsers retrieves the users that blocked the given user.
+	FindBlockingUsers(ctx context.Context, userID ID) ([]ID, error)
+}
@@ -2,6 +2,8 @@ package chatdomain
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/xfrr/go-cqrsify/domain"
 	"github.com/xfrr/randomtalk/internal/shared/identity"
@@ -16,21 +18,41 @@ const AggregateName = "session"
 
 var (
 	ErrInvalidChatSessionID = domain_error.New("invalid chat session unique identifier")
+	// ErrChatSessionClosed is returned when acting on a ChatSession that was already closed.
+	ErrChatSessionClosed = domain_error.New("chat session is closed")
+	// ErrChatSessionNotWaiting is returned when matching a ChatSession that is not waiting for a match.
+	ErrChatSessionNotWaiting = domain_error.New("chat session is not waiting for a match")
+	// ErrChatSessionNotMatched is returned when sending messages from a ChatSession without partner.
+	ErrChatSessionNotMatched = domain_error.New("chat session is not matched")
+	// ErrInvalidChatSessionMatch is returned when matching a ChatSession without partner or match.
+	ErrInvalidChatSessionMatch = domain_error.New("invalid chat session partner or match")
+	// ErrChatSessionInGroup is returned when skipping the partner of a ChatSession that joined a group room.
+	ErrChatSessionInGroup = domain_error.New("chat session is in a group room")
 )
 
 type ID = identity.ID
 type MatchPreferences = matchmaking.Preferences
 
 // ChatSession is the main Aggregate of the Chat bounded context.
-// It represents a chat session between two users.
+// It represents a chat session between two users, or of a user in a group room.
 type ChatSession struct {
 	*domain.BaseAggregate[string]
 
 	state *chatSessionState
+	// sequence is the position of the last stored event of the ChatSession,
+	// set by the repositories to detect concurrent changes.
+	sequence uint64
 }
 
 type chatSessionState struct {
-	User *User
+	User           *User
+	Status         ChatSessionStatus
+	PartnerID      ID
+	MemberIDs      []ID
+	LastPartnerID  ID
+	LastMatchID    ID
+	MatchID        ID
+	LastActivityAt time.Time
 }
 
 // NewChatSession creates a new ChatSession instance.
@@ -87,25 +109,302 @@ func (cs ChatSession) User() *User {
 	return cs.state.User
 }
 
+// Status returns the current ChatSession status.
+func (cs ChatSession) Status() ChatSessionStatus {
+	if cs.state == nil {
+		return ChatSessionWaiting
+	}
+	return cs.state.Status
+}
+
+// IsClosed reports whether the ChatSession was left, ended or expired.
+func (cs ChatSession) IsClosed() bool {
+	return cs.Status().IsClosed()
+}
+
+// PartnerID returns the ID of the user matched with the ChatSession user, if any.
+func (cs ChatSession) PartnerID() ID {
+	if cs.state == nil {
+		return ""
+	}
+	return cs.state.PartnerID
+}
+
+// MemberIDs returns the IDs of the other participants of the group room the ChatSession user joined, if any.
+func (cs ChatSession) MemberIDs() []ID {
+	if cs.state == nil {
+		return nil
+	}
+	return slices.Clone(cs.state.MemberIDs)
+}
+
+// IsInGroup reports whether the ChatSession user joined a group room.
+func (cs ChatSession) IsInGroup() bool {
+	return len(cs.MemberIDs()) > 0
+}
+
+// LastPartnerID returns the ID of the partner of the last skipped match, if any.
+func (cs ChatSession) LastPartnerID() ID {
+	if cs.state == nil {
+		return ""
+	}
+	return cs.state.LastPartnerID
+}
+
+// LastMatchID returns the ID of the last skipped match, if any.
+func (cs ChatSession) LastMatchID() ID {
+	if cs.state == nil {
+		return ""
+	}
+	return cs.state.LastMatchID
+}
+
+// MatchID returns the ID of the current match, if any.
+func (cs ChatSession) MatchID() ID {
+	if cs.state == nil {
+		return ""
+	}
+	return cs.state.MatchID
+}
+
+// LastActivityAt returns the time of the last change of the ChatSession.
+func (cs ChatSession) LastActivityAt() time.Time {
+	if cs.state == nil {
+		return time.Time{}
+	}
+	return cs.state.LastActivityAt
+}
+
+// Restart opens a new conversation for the user of a closed ChatSession.
+// The conversation is a new ChatSession, replacing the closed one once saved,
+// so the history of every ChatSession only holds a single conversation.
+func (cs ChatSession) Restart(user User) (*ChatSession, error) {
+	if !cs.IsClosed() {
+		return nil, ErrChatSessionAlreadyExists
+	}
+
+	next, err := NewChatSession(cs.ID(), user)
+	if err != nil {
+		return nil, err
+	}
+	next.sequence = cs.sequence
+	return next, nil
+}
+
+// Sequence returns the position of the last stored event of the ChatSession.
+func (cs ChatSession) Sequence() uint64 {
+	return cs.sequence
+}
+
+// SetSequence sets the position of the last stored event of the ChatSession.
+// It is meant to be called by the repositories only.
+func (cs *ChatSession) SetSequence(sequence uint64) {
+	cs.sequence = sequence
+}
+
+// Match pairs the ChatSession user with a partner.
+func (cs *ChatSession) Match(partnerID, matchID ID) error {
+	if cs.Status() != ChatSessionWaiting {
+		return ErrChatSessionNotWaiting
+	}
+
+	if partnerID.IsEmpty() || matchID.IsEmpty() {
+		return ErrInvalidChatSessionMatch
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionMatched{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionMatched{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID: cs.ID().String(),
+		MatchID:   matchID.String(),
+		PartnerID: partnerID.String(),
+	})
+}
+
+// MatchGroup joins the ChatSession user to a group room with the other members.
+func (cs *ChatSession) MatchGroup(memberIDs []ID, matchID ID) error {
+	if cs.Status() != ChatSessionWaiting {
+		return ErrChatSessionNotWaiting
+	}
+
+	if len(memberIDs) == 0 || matchID.IsEmpty() {
+		return ErrInvalidChatSessionMatch
+	}
+
+	members := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if memberID.IsEmpty() {
+			return ErrInvalidChatSessionMatch
+		}
+		members = append(members, memberID.String())
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionMatched{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionMatched{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID: cs.ID().String(),
+		MatchID:   matchID.String(),
+		MemberIDs: members,
+	})
+}
+
+// SkipPartner unpairs the ChatSession user from its partner on behalf of the user,
+// so the ChatSession waits for a new match.
+func (cs *ChatSession) SkipPartner() error {
+	return cs.raisePartnerSkippedEvent(cs.ID())
+}
+
+// SkippedByPartner unpairs the ChatSession user from its partner on behalf of the partner,
+// so the ChatSession waits for a new match.
+func (cs *ChatSession) SkippedByPartner() error {
+	return cs.raisePartnerSkippedEvent(cs.PartnerID())
+}
+
+func (cs *ChatSession) raisePartnerSkippedEvent(skippedByID ID) error {
+	if cs.Status() != ChatSessionMatched {
+		return ErrChatSessionNotMatched
+	}
+
+	// group rooms are left instead
+	if cs.IsInGroup() {
+		return ErrChatSessionInGroup
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionPartnerSkipped{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionPartnerSkipped{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID:   cs.ID().String(),
+		MatchID:     cs.MatchID().String(),
+		PartnerID:   cs.PartnerID().String(),
+		SkippedByID: skippedByID.String(),
+	})
+}
+
+// RatePartner returns the rating of the ChatSession user for a user it talked with: the current
+// partner, the members of its group room or the last skipped partner. An empty ratedUserID rates
+// the current partner or, when there is none, the last skipped one.
+func (cs ChatSession) RatePartner(ratedUserID ID, liked bool, tags ...matchmaking.RatingTag) (Rating, error) {
+	if ratedUserID.IsEmpty() {
+		ratedUserID = cs.PartnerID()
+		if ratedUserID.IsEmpty() {
+			ratedUserID = cs.LastPartnerID()
+		}
+	}
+
+	switch {
+	case ratedUserID.IsEmpty():
+		return Rating{}, ErrRatedUserNotProvided
+	case cs.User() != nil && ratedUserID == cs.User().ID():
+		return Rating{}, ErrUserCannotRateItself
+	}
+
+	var matchID ID
+	switch {
+	case ratedUserID == cs.PartnerID(), slices.Contains(cs.MemberIDs(), ratedUserID):
+		matchID = cs.MatchID()
+	case ratedUserID == cs.LastPartnerID():
+		matchID = cs.LastMatchID()
+	default:
+		return Rating{}, ErrRatedUserNotPartner
+	}
+
+	return Rating{
+		RatedUserID: ratedUserID,
+		MatchID:     matchID,
+		Liked:       liked,
+		Tags:        slices.Clone(tags),
+	}, nil
+}
+
+// Leave closes the ChatSession on behalf of its user.
+func (cs *ChatSession) Leave() error {
+	if cs.IsClosed() {
+		return ErrChatSessionClosed
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionLeft{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionLeft{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID: cs.ID().String(),
+		MatchID:   cs.MatchID().String(),
+	})
+}
+
+// End closes the ChatSession on behalf of the partner or the system.
+func (cs *ChatSession) End(reason string) error {
+	if cs.IsClosed() {
+		return ErrChatSessionClosed
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionEnded{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionEnded{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID: cs.ID().String(),
+		MatchID:   cs.MatchID().String(),
+		Reason:    reason,
+	})
+}
+
+// Expire closes the ChatSession after being inactive for too long.
+func (cs *ChatSession) Expire() error {
+	if cs.IsClosed() {
+		return ErrChatSessionClosed
+	}
+
+	return cs.raiseEvent(chatdomaineventsv1.ChatSessionExpired{
+		BaseEvent: domain.NewEvent(
+			chatdomaineventsv1.ChatSessionExpired{}.EventName(),
+			domain.CreateEventAggregateRef(cs),
+		),
+		SessionID: cs.ID().String(),
+	})
+}
+
 func (cs *ChatSession) raiseChatSessionCreatedEvent(user User) error {
 	chatSessionCreatedEvent := chatdomaineventsv1.ChatSessionCreated{
 		BaseEvent: domain.NewEvent(
-			EventSourceName,
+			chatdomaineventsv1.ChatSessionCreated{}.EventName(),
 			domain.CreateEventAggregateRef(cs),
 		),
-		SessionID:    cs.ID().String(),
-		UserID:       user.ID().String(),
-		UserNickname: user.Nickname(),
-		UserAge:      user.Age(),
-		UserGender:   user.Gender().String(),
+		SessionID:     cs.ID().String(),
+		UserID:        user.ID().String(),
+		UserNickname:  user.Nickname(),
+		UserAge:       user.Age(),
+		UserGender:    user.Gender().String(),
+		UserLanguages: user.Languages(),
 		UserPreference: chatdomaineventsv1.UserPref{
-			MinAge:    user.MatchPreferences().MinAge,
-			MaxAge:    user.MatchPreferences().MaxAge,
-			Gender:    user.MatchPreferences().Gender.String(),
-			Interests: user.MatchPreferences().Interests,
+			MinAge:        user.MatchPreferences().MinAge,
+			MaxAge:        user.MatchPreferences().MaxAge,
+			Gender:        user.MatchPreferences().Gender.String(),
+			Interests:     user.MatchPreferences().Interests,
+			MaxDistanceKm: user.MatchPreferences().MaxDistanceKm,
+			LocationScope: string(user.MatchPreferences().LocationScope),
+			MaxWaitTime:   user.MatchPreferences().MaxWaitTimeSeconds,
+
+			RequireSharedLanguage: user.MatchPreferences().RequireSharedLanguage,
+			GroupSize:             user.MatchPreferences().GroupSize,
 		},
 	}
 
+	if location := user.Location(); location != nil {
+		chatSessionCreatedEvent.UserLocation = &chatdomaineventsv1.UserLocation{
+			Latitude:    location.Coordinates.Latitude,
+			Longitude:   location.Coordinates.Longitude,
+			CountryCode: location.CountryCode,
+			CityCode:    location.CityCode,
+		}
+	}
+
 	err := domain.NextEvent(cs, chatSessionCreatedEvent)
 	if err != nil {
 		return fmt.Errorf("failed to raise ChatSessionCreated event: %w", err)
@@ -114,6 +413,13 @@ func (cs *ChatSession) raiseChatSessionCreatedEvent(user User) error {
 	return nil
 }
 
+func (cs *ChatSession) raiseEvent(evt domain.Event) error {
+	if err := domain.NextEvent(cs, evt); err != nil {
+		return fmt.Errorf("failed to raise %s event: %w", evt.Name(), err)
+	}
+	return nil
+}
+
 func (cs ChatSession) validate() error {
 	if cs.ID() == "" {
 		return ErrInvalidChatSessionID
@@ -122,7 +428,19 @@ func (cs ChatSession) validate() error {
 }
 
 func (cs *ChatSession) registerEventHandlers() {
-	var chatSessionCreatedEvent chatdomaineventsv1.ChatSessionCreated
+	var (
+		chatSessionCreatedEvent chatdomaineventsv1.ChatSessionCreated
+		chatSessionMatchedEvent chatdomaineventsv1.ChatSessionMatched
+		chatSessionSkippedEvent chatdomaineventsv1.ChatSessionPartnerSkipped
+		chatSessionLeftEvent    chatdomaineventsv1.ChatSessionLeft
+		chatSessionEndedEvent   chatdomaineventsv1.ChatSessionEnded
+		chatSessionExpiredEvent chatdomaineventsv1.ChatSessionExpired
+	)
 
 	cs.HandleEvent(chatSessionCreatedEvent.EventName(), cs.chatSessionCreatedDomainEventHandlerV1)
+	cs.HandleEvent(chatSessionMatchedEvent.EventName(), cs.chatSessionMatchedDomainEventHandlerV1)
+	cs.HandleEvent(chatSessionSkippedEvent.EventName(), cs.chatSessionPartnerSkippedDomainEventHandlerV1)
+	cs.HandleEvent(chatSessionLeftEvent.EventName(), cs.chatSessionLeftDomainEventHandlerV1)
+	cs.HandleEvent(chatSessionEndedEvent.EventName(), cs.chatSessionEndedDomainEventHandlerV1)
+	cs.HandleEvent(chatSessionExpiredEvent.EventName(), cs.chatSessionExpiredDomainEventHandlerV1)
 }
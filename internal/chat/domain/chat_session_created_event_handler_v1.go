@@ -6,6 +6,7 @@ import (
 	"github.com/xfrr/go-cqrsify/domain"
 	chatdomaineventsv1 "github.com/xfrr/randomtalk/internal/chat/domain/events/v1"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	geo "github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -16,10 +17,10 @@ func (cs *ChatSession) chatSessionCreatedDomainEventHandlerV1(evt domain.Event)
 		return fmt.Errorf("unexpected event type: %T, expected: %T", evt, chatdomaineventsv1.ChatSessionCreated{})
 	}
 
-	if cs.state == nil {
-		cs.state = &chatSessionState{
-			User: new(User),
-		}
+	// a new conversation starts from a clean state, even when the session is restarted
+	cs.state = &chatSessionState{
+		Status:         ChatSessionWaiting,
+		LastActivityAt: evt.Timestamp(),
 	}
 
 	cs.state.User = &User{
@@ -32,7 +33,20 @@ func (cs *ChatSession) chatSessionCreatedDomainEventHandlerV1(evt domain.Event)
 			WithGender(gender.Parse(payload.UserPreference.Gender)).
 			WithMinAge(payload.UserPreference.MinAge).
 			WithMaxAge(payload.UserPreference.MaxAge).
-			WithInterests(payload.UserPreference.Interests),
+			WithInterests(payload.UserPreference.Interests).
+			WithMaxDistanceKm(payload.UserPreference.MaxDistanceKm).
+			WithLocationScope(matchmaking.ParseLocationScope(payload.UserPreference.LocationScope)).
+			WithMaxWaitTimeSeconds(payload.UserPreference.MaxWaitTime).
+			WithRequireSharedLanguage(payload.UserPreference.RequireSharedLanguage).
+			WithGroupSize(payload.UserPreference.GroupSize),
+		languages: payload.UserLanguages,
+	}
+
+	if payload.UserLocation != nil {
+		location := geo.New(payload.UserLocation.Latitude, payload.UserLocation.Longitude).
+			WithCountryCode(payload.UserLocation.CountryCode).
+			WithCityCode(payload.UserLocation.CityCode)
+		cs.state.User.location = &location
 	}
 	return nil
 }
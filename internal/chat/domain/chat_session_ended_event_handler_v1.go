@@ -0,0 +1,23 @@
+package chatdomain
+
+import (
+	"fmt"
+
+	"github.com/xfrr/go-cqrsify/domain"
+	chatdomaineventsv1 "github.com/xfrr/randomtalk/internal/chat/domain/events/v1"
+)
+
+// chatSessionEndedDomainEventHandlerV1 is a domain event handler for ChatSessionEnded events.
+func (cs *ChatSession) chatSessionEndedDomainEventHandlerV1(evt domain.Event) error {
+	if _, ok := evt.(chatdomaineventsv1.ChatSessionEnded); !ok {
+		return fmt.Errorf("unexpected event type: %T, expected: %T", evt, chatdomaineventsv1.ChatSessionEnded{})
+	}
+
+	if cs.state == nil {
+		return ErrChatSessionClosed
+	}
+
+	cs.state.Status = ChatSessionEnded
+	cs.state.LastActivityAt = evt.Timestamp()
+	return nil
+}
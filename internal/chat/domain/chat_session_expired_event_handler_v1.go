@@ -0,0 +1,23 @@
+package chatdomain
+
+import (
+	"fmt"
+
+	"github.com/xfrr/go-cqrsify/domain"
+	chatdomaineventsv1 "github.com/xfrr/randomtalk/internal/chat/domain/events/v1"
+)
+
+// chatSessionExpiredDomainEventHandlerV1 is a domain event handler for ChatSessionExpired events.
+func (cs *ChatSession) chatSessionExpiredDomainEventHandlerV1(evt domain.Event) error {
+	if _, ok := evt.(chatdomaineventsv1.ChatSessionExpired); !ok {
+		return fmt.Errorf("unexpected event type: %T, expected: %T", evt, chatdomaineventsv1.ChatSessionExpired{})
+	}
+
+	if cs.state == nil {
+		return ErrChatSessionClosed
+	}
+
+	cs.state.Status = ChatSessionExpired
+	cs.state.LastActivityAt = evt.Timestamp()
+	return nil
+}
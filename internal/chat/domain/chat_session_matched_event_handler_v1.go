@@ -0,0 +1,30 @@
+package chatdomain
+
+import (
+	"fmt"
+
+	"github.com/xfrr/go-cqrsify/domain"
+	chatdomaineventsv1 "github.com/xfrr/randomtalk/internal/chat/domain/events/v1"
+)
+
+// chatSessionMatchedDomainEventHandlerV1 is a domain event handler for ChatSessionMatched events.
+func (cs *ChatSession) chatSessionMatchedDomainEventHandlerV1(evt domain.Event) error {
+	payload, ok := evt.(chatdomaineventsv1.ChatSessionMatched)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T, expected: %T", evt, chatdomaineventsv1.ChatSessionMatched{})
+	}
+
+	if cs.state == nil {
+		return ErrChatSessionNotWaiting
+	}
+
+	cs.state.Status = ChatSessionMatched
+	cs.state.PartnerID = ID(payload.PartnerID)
+	cs.state.MemberIDs = make([]ID, 0, len(payload.MemberIDs))
+	for _, memberID := range payload.MemberIDs {
+		cs.state.MemberIDs = append(cs.state.MemberIDs, ID(memberID))
+	}
+	cs.state.MatchID = ID(payload.MatchID)
+	cs.state.LastActivityAt = evt.Timestamp()
+	return nil
+}
@@ -0,0 +1,29 @@
+package chatdomain
+
+import (
+	"fmt"
+
+	"github.com/xfrr/go-cqrsify/domain"
+	chatdomaineventsv1 "github.com/xfrr/randomtalk/internal/chat/domain/events/v1"
+)
+
+// chatSessionPartnerSkippedDomainEventHandlerV1 is a domain event handler for ChatSessionPartnerSkipped events.
+func (cs *ChatSession) chatSessionPartnerSkippedDomainEventHandlerV1(evt domain.Event) error {
+	payload, ok := evt.(chatdomaineventsv1.ChatSessionPartnerSkipped)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T, expected: %T", evt, chatdomaineventsv1.ChatSessionPartnerSkipped{})
+	}
+
+	if cs.state == nil {
+		return ErrChatSessionNotMatched
+	}
+
+	cs.state.Status = ChatSessionWaiting
+	cs.state.LastPartnerID = ID(payload.PartnerID)
+	cs.state.LastMatchID = ID(payload.MatchID)
+	cs.state.PartnerID = ""
+	cs.state.MemberIDs = nil
+	cs.state.MatchID = ""
+	cs.state.LastActivityAt = evt.Timestamp()
+	return nil
+}
@@ -9,13 +9,18 @@ import (
 var (
 	ErrChatSessionNotFound      = domainerror.New("chat session not found")
 	ErrChatSessionAlreadyExists = domainerror.New("chat session already exists with the given ID")
+	// ErrChatSessionVersionMismatch is returned when the ChatSession was modified since it was loaded.
+	ErrChatSessionVersionMismatch = domainerror.New("chat session was modified concurrently")
 )
 
 type ChatSessionRepository interface {
-	// Save persists the state of the ChatSession Aggregate.
+	// Save persists the uncommitted events of the ChatSession Aggregate.
+	// It fails with ErrChatSessionVersionMismatch when the stored ChatSession
+	// changed since it was loaded. A restarted ChatSession replaces the closed one.
 	Save(ctx context.Context, cs *ChatSession) error
 
 	// FindByID retrieves a ChatSession by its unique identifier.
+	// A ChatSession whose creation is no longer stored is not found.
 	FindByID(ctx context.Context, id string) (*ChatSession, error)
 
 	// Exists checks if a ChatSession with the given ID exists.
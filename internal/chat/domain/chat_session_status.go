@@ -0,0 +1,38 @@
+package chatdomain
+
+// ChatSessionStatus represents the stage of the ChatSession lifecycle.
+type ChatSessionStatus int
+
+const (
+	// ChatSessionWaiting is the status of a ChatSession waiting for a match.
+	ChatSessionWaiting ChatSessionStatus = iota
+	// ChatSessionMatched is the status of a ChatSession whose user is talking to a partner.
+	ChatSessionMatched
+	// ChatSessionLeft is the status of a ChatSession closed by its user.
+	ChatSessionLeft
+	// ChatSessionEnded is the status of a ChatSession closed by the partner or the system.
+	ChatSessionEnded
+	// ChatSessionExpired is the status of a ChatSession closed due to inactivity.
+	ChatSessionExpired
+)
+
+func (s ChatSessionStatus) String() string {
+	switch s {
+	case ChatSessionWaiting:
+		return "waiting"
+	case ChatSessionMatched:
+		return "matched"
+	case ChatSessionLeft:
+		return "left"
+	case ChatSessionEnded:
+		return "ended"
+	case ChatSessionExpired:
+		return "expired"
+	}
+	return "waiting"
+}
+
+// IsClosed reports whether the status ends the ChatSession.
+func (s ChatSessionStatus) IsClosed() bool {
+	return s == ChatSessionLeft || s == ChatSessionEnded || s == ChatSessionExpired
+}
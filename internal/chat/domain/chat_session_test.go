@@ -0,0 +1,128 @@
+package chatdomain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func newTestChatSession(t *testing.T, userID chatdomain.ID) *chatdomain.ChatSession {
+	t.Helper()
+
+	user, err := chatdomain.NewUser(userID, "nick", 25, gender.Female, matchmaking.DefaultPreferences())
+	require.NoError(t, err)
+
+	cs, err := chatdomain.NewChatSession(userID, user)
+	require.NoError(t, err)
+	return cs
+}
+
+func TestChatSessionLifecycle(t *testing.T) {
+	t.Run("should start waiting for a match", func(t *testing.T) {
+		cs := newTestChatSession(t, "alice")
+
+		require.Equal(t, chatdomain.ChatSessionWaiting, cs.Status())
+		require.Equal(t, chatdomain.ID("alice"), cs.User().ID())
+		require.False(t, cs.IsClosed())
+	})
+
+	t.Run("should track the partner once matched", func(t *testing.T) {
+		cs := newTestChatSession(t, "alice")
+
+		require.NoError(t, cs.Match("bob", "match-1"))
+		require.Equal(t, chatdomain.ChatSessionMatched, cs.Status())
+		require.Equal(t, chatdomain.ID("bob"), cs.PartnerID())
+		require.Equal(t, chatdomain.ID("match-1"), cs.MatchID())
+
+		require.ErrorIs(t, cs.Match("carol", "match-2"), chatdomain.ErrChatSessionNotWaiting)
+	})
+
+	t.Run("should join a group room without partner", func(t *testing.T) {
+		cs := newTestChatSession(t, "alice")
+
+		require.ErrorIs(t, cs.MatchGroup(nil, "match-1"), chatdomain.ErrInvalidChatSessionMatch)
+		require.NoError(t, cs.MatchGroup([]chatdomain.ID{"bob", "carol"}, "match-1"))
+		require.Equal(t, chatdomain.ChatSessionMatched, cs.Status())
+		require.True(t, cs.IsInGroup())
+		require.True(t, cs.PartnerID().IsEmpty())
+		require.Equal(t, []chatdomain.ID{"bob", "carol"}, cs.MemberIDs())
+
+		require.ErrorIs(t, cs.SkipPartner(), chatdomain.ErrChatSessionInGroup)
+	})
+
+	t.Run("should close the session once", func(t *testing.T) {
+		for name, closeFn := range map[string]func(cs *chatdomain.ChatSession) error{
+			"left":    (*chatdomain.ChatSession).Leave,
+			"ended":   func(cs *chatdomain.ChatSession) error { return cs.End("partner_left") },
+			"expired": (*chatdomain.ChatSession).Expire,
+		} {
+			t.Run(name, func(t *testing.T) {
+				cs := newTestChatSession(t, "alice")
+
+				require.NoError(t, closeFn(cs))
+				require.True(t, cs.IsClosed())
+				require.Equal(t, name, cs.Status().String())
+				require.ErrorIs(t, closeFn(cs), chatdomain.ErrChatSessionClosed)
+			})
+		}
+	})
+
+	t.Run("should restart only closed sessions", func(t *testing.T) {
+		cs := newTestChatSession(t, "alice")
+		user := *cs.User()
+
+		_, err := cs.Restart(user)
+		require.ErrorIs(t, err, chatdomain.ErrChatSessionAlreadyExists)
+
+		require.NoError(t, cs.Match("bob", "match-1"))
+		require.NoError(t, cs.Leave())
+		cs.SetSequence(3)
+
+		restarted, err := cs.Restart(user)
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, restarted.Status())
+		require.True(t, restarted.PartnerID().IsEmpty())
+		require.Zero(t, restarted.AggregateVersion())
+		require.Len(t, restarted.AggregateEvents(), 1)
+		require.Equal(t, uint64(3), restarted.Sequence())
+	})
+
+	t.Run("should restore the state from its events", func(t *testing.T) {
+		cs := newTestChatSession(t, "alice")
+		require.NoError(t, cs.Match("bob", "match-1"))
+		require.NoError(t, cs.End("partner_left"))
+
+		restored, err := chatdomain.NewChatSessionFromEvents(cs.ID(), cs.AggregateEvents())
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionEnded, restored.Status())
+		require.Equal(t, chatdomain.ID("bob"), restored.PartnerID())
+		require.Equal(t, int(cs.AggregateVersion())+len(cs.AggregateEvents()), int(restored.AggregateVersion()))
+	})
+}
+
+func TestUserLanguages(t *testing.T) {
+	prefs := matchmaking.DefaultPreferences().WithRequireSharedLanguage(true)
+
+	t.Run("should reject languages that are not BCP-47 tags", func(t *testing.T) {
+		_, err := chatdomain.NewUser("alice", "nick", 25, gender.Female, prefs, chatdomain.WithLanguages("en", "english"))
+		require.ErrorIs(t, err, chatdomain.ErrUserLanguageInvalid)
+	})
+
+	t.Run("should restore the languages from the session events", func(t *testing.T) {
+		user, err := chatdomain.NewUser("alice", "nick", 25, gender.Female, prefs, chatdomain.WithLanguages("es", "pt-br", "es"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"es", "pt-BR"}, user.Languages())
+
+		cs, err := chatdomain.NewChatSession("alice", user)
+		require.NoError(t, err)
+
+		restored, err := chatdomain.NewChatSessionFromEvents(cs.ID(), cs.AggregateEvents())
+		require.NoError(t, err)
+		require.Equal(t, []string{"es", "pt-BR"}, restored.User().Languages())
+		require.True(t, restored.User().MatchPreferences().RequireSharedLanguage)
+	})
+}
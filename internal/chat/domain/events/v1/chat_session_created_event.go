@@ -6,20 +6,36 @@ import "github.com/xfrr/go-cqrsify/domain"
 type ChatSessionCreated struct {
 	domain.BaseEvent
 
-	SessionID      string   `json:"session_id"`
-	UserID         string   `json:"user_id"`
-	UserNickname   string   `json:"user_nickname"`
-	UserAge        int32    `json:"user_age"`
-	UserGender     string   `json:"user_gender"`
-	UserPreference UserPref `json:"user_preference"`
+	SessionID      string        `json:"session_id"`
+	UserID         string        `json:"user_id"`
+	UserNickname   string        `json:"user_nickname"`
+	UserAge        int32         `json:"user_age"`
+	UserGender     string        `json:"user_gender"`
+	UserLocation   *UserLocation `json:"user_location,omitempty"`
+	UserLanguages  []string      `json:"user_languages,omitempty"`
+	UserPreference UserPref      `json:"user_preference"`
+}
+
+// UserLocation is a struct that holds the user's location.
+type UserLocation struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	CountryCode string  `json:"country_code,omitempty"`
+	CityCode    string  `json:"city_code,omitempty"`
 }
 
 // UserPref is a struct that holds the user's preferences.
 type UserPref struct {
-	MinAge    int32    `json:"min_age"`
-	MaxAge    int32    `json:"max_age"`
-	Gender    string   `json:"gender"`
-	Interests []string `json:"interests"`
+	MinAge        int32    `json:"min_age"`
+	MaxAge        int32    `json:"max_age"`
+	Gender        string   `json:"gender"`
+	Interests     []string `json:"interests"`
+	MaxDistanceKm float64  `json:"max_distance_km,omitempty"`
+	LocationScope string   `json:"location_scope,omitempty"`
+	MaxWaitTime   int32    `json:"max_wait_time_seconds,omitempty"`
+
+	RequireSharedLanguage bool  `json:"require_shared_language,omitempty"`
+	GroupSize             int32 `json:"group_size,omitempty"`
 }
 
 func (e ChatSessionCreated) EventName() string {
@@ -0,0 +1,17 @@
+package chatdomaineventsv1
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// ChatSessionEnded is an event that is published when a chat session is closed
+// by someone other than its user, e.g. when the partner leaves.
+type ChatSessionEnded struct {
+	domain.BaseEvent
+
+	SessionID string `json:"session_id"`
+	MatchID   string `json:"match_id,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+func (e ChatSessionEnded) EventName() string {
+	return "chat_session_ended"
+}
@@ -0,0 +1,14 @@
+package chatdomaineventsv1
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// ChatSessionExpired is an event that is published when a chat session is closed after being inactive for too long.
+type ChatSessionExpired struct {
+	domain.BaseEvent
+
+	SessionID string `json:"session_id"`
+}
+
+func (e ChatSessionExpired) EventName() string {
+	return "chat_session_expired"
+}
@@ -0,0 +1,15 @@
+package chatdomaineventsv1
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// ChatSessionLeft is an event that is published when the user leaves its chat session.
+type ChatSessionLeft struct {
+	domain.BaseEvent
+
+	SessionID string `json:"session_id"`
+	MatchID   string `json:"match_id,omitempty"`
+}
+
+func (e ChatSessionLeft) EventName() string {
+	return "chat_session_left"
+}
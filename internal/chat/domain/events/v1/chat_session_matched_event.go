@@ -0,0 +1,18 @@
+package chatdomaineventsv1
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// ChatSessionMatched is an event that is published when the user of a chat session is matched with a partner.
+type ChatSessionMatched struct {
+	domain.BaseEvent
+
+	SessionID string `json:"session_id"`
+	MatchID   string `json:"match_id"`
+	PartnerID string `json:"partner_id"`
+	// MemberIDs are the other participants when the user joins a group room. The PartnerID is empty then.
+	MemberIDs []string `json:"member_ids,omitempty"`
+}
+
+func (e ChatSessionMatched) EventName() string {
+	return "chat_session_matched"
+}
@@ -0,0 +1,18 @@
+package chatdomaineventsv1
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// ChatSessionPartnerSkipped is an event that is published when one of the users of a match
+// skips the other one and the chat session goes back to wait for a new match.
+type ChatSessionPartnerSkipped struct {
+	domain.BaseEvent
+
+	SessionID   string `json:"session_id"`
+	MatchID     string `json:"match_id"`
+	PartnerID   string `json:"partner_id"`
+	SkippedByID string `json:"skipped_by_id"`
+}
+
+func (e ChatSessionPartnerSkipped) EventName() string {
+	return "chat_session_partner_skipped"
+}
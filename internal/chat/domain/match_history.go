@@ -0,0 +1,39 @@
+package chatdomain
+
+import (
+	"context"
+	"time"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+var (
+	ErrMatchNotFound             = domainerror.New("match not found")
+	ErrInvalidMatchHistoryCursor = domainerror.New("invalid match history cursor")
+)
+
+// MatchHistoryEntry is a past match of an user.
+type MatchHistoryEntry struct {
+	MatchID   ID        `json:"match_id"`
+	PartnerID ID        `json:"partner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// MemberIDs are the other participants of a group room. The PartnerID is empty then.
+	MemberIDs []ID `json:"member_ids,omitempty"`
+}
+
+// MatchHistoryPage is a page of the match history of an user, from the most recent match.
+type MatchHistoryPage struct {
+	Entries []MatchHistoryEntry `json:"entries"`
+	// NextCursor lists the following page. It is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// MatchHistory reads the match history of the users kept by the matchmaker.
+type MatchHistory interface {
+	// ListMatchesByUser lists the matches of the user from the most recent one,
+	// starting after the match of the cursor, if any.
+	ListMatchesByUser(ctx context.Context, userID ID, cursor string, limit int) (MatchHistoryPage, error)
+
+	// GetLastMatch retrieves the most recent match of the user.
+	GetLastMatch(ctx context.Context, userID ID) (MatchHistoryEntry, error)
+}
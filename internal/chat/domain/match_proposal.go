@@ -0,0 +1,27 @@
+package chatdomain
+
+import (
+	"time"
+
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+)
+
+// MatchProposal is a match the user has to accept before its room is opened.
+type MatchProposal struct {
+	MatchID ID
+	// ExpiresAt is when the match is cancelled unless both users accepted it.
+	ExpiresAt time.Time
+	Partner   MatchPartner
+}
+
+// MatchPartner is the public profile of the other user of a MatchProposal.
+// The exact location of the partner is never shared.
+type MatchPartner struct {
+	UserID      ID
+	Age         int32
+	Gender      gender.Gender
+	Interests   []string
+	Languages   []string
+	CountryCode string
+	CityCode    string
+}
@@ -5,4 +5,13 @@ import "context"
 // MatchRequester defines the interface for requesting a match+ for a given ChatSession.
 type MatchRequester interface {
 	RequestMatch(ctx context.Context, cs *ChatSession) error
+
+	// RespondToMatch tells the matchmaker whether the ChatSession user accepts the proposed match.
+	RespondToMatch(ctx context.Context, cs *ChatSession, matchID ID, accepted bool) error
+
+	// CancelMatchRequest tells the matchmaker that the ChatSession user no longer waits for a match.
+	CancelMatchRequest(ctx context.Context, cs *ChatSession) error
+
+	// RatePartner tells the matchmaker how the ChatSession user rated a partner it talked with.
+	RatePartner(ctx context.Context, cs *ChatSession, rating Rating) error
 }
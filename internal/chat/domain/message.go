@@ -0,0 +1,76 @@
+package chatdomain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xfrr/randomtalk/internal/shared/identity"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+// MaxMessageTextLength is the maximum number of characters of a text Message.
+const MaxMessageTextLength = 4096
+
+var (
+	// ErrEmptyMessage is returned when a Message has no content.
+	ErrEmptyMessage = domainerror.New("message is empty")
+	// ErrMessageTooLong is returned when a Message exceeds MaxMessageTextLength.
+	ErrMessageTooLong = domainerror.New("message is too long")
+)
+
+// Message is a text message sent by a participant of a Room.
+// Its ID and timestamp are always assigned by the server.
+type Message struct {
+	id       ID
+	roomID   ID
+	senderID ID
+	text     string
+	sentAt   time.Time
+}
+
+// NewTextMessage creates a new text Message sent by the given user to a Room.
+func NewTextMessage(roomID, senderID ID, text string) (Message, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Message{}, ErrEmptyMessage
+	}
+
+	if utf8.RuneCountInString(text) > MaxMessageTextLength {
+		return Message{}, ErrMessageTooLong
+	}
+
+	return Message{
+		id:       identity.NewUUID(),
+		roomID:   roomID,
+		senderID: senderID,
+		text:     text,
+		sentAt:   time.Now().UTC(),
+	}, nil
+}
+
+// ID returns the Message ID.
+func (m Message) ID() ID {
+	return m.id
+}
+
+// RoomID returns the ID of the Room the Message was sent to.
+func (m Message) RoomID() ID {
+	return m.roomID
+}
+
+// SenderID returns the ID of the user who sent the Message.
+func (m Message) SenderID() ID {
+	return m.senderID
+}
+
+// Text returns the Message text.
+func (m Message) Text() string {
+	return m.text
+}
+
+// SentAt returns the time the Message was accepted by the server.
+func (m Message) SentAt() time.Time {
+	return m.sentAt
+}
@@ -0,0 +1,8 @@
+package chatdomain
+
+import "context"
+
+// MessagePublisher defines the interface for delivering a Message to the participants of a Room.
+type MessagePublisher interface {
+	Publish(ctx context.Context, msg Message, recipients ...ID) error
+}
@@ -0,0 +1,13 @@
+package chatdomain
+
+import "time"
+
+// QueueStatus is the progress of a user waiting for a match, as reported by the matchmaker.
+type QueueStatus struct {
+	// CompatibleUsers is the number of waiting users the user can be matched with.
+	CompatibleUsers int
+	// Position is the 1-based position of the user in the queue.
+	Position int
+	// EstimatedWait is the expected time until the user is matched, zero when unknown.
+	EstimatedWait time.Duration
+}
@@ -0,0 +1,23 @@
+package chatdomain
+
+import (
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+var (
+	ErrRatedUserNotProvided = domainerror.New("rated user not provided")
+	ErrUserCannotRateItself = domainerror.New("user cannot rate itself")
+	// ErrRatedUserNotPartner is returned when rating a user the ChatSession user did not talk with.
+	ErrRatedUserNotPartner = domainerror.New("rated user is not a partner of the user")
+)
+
+// Rating is the feedback the ChatSession user gives about a partner it talked with.
+type Rating struct {
+	RatedUserID ID
+	MatchID     ID
+	// Liked is the thumbs up (true) or down (false) of the user.
+	Liked bool
+	Tags  []matchmaking.RatingTag
+}
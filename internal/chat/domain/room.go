@@ -0,0 +1,163 @@
+package chatdomain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+// MinRoomParticipants is the minimum number of participants of a Room.
+const MinRoomParticipants = 2
+
+var (
+	// ErrInvalidRoomID is returned when the Room ID is not provided.
+	ErrInvalidRoomID = domainerror.New("invalid room unique identifier")
+	// ErrInvalidRoomParticipants is returned when the Room has not enough distinct participants.
+	ErrInvalidRoomParticipants = domainerror.New("invalid room participants")
+	// ErrUserNotInRoom is returned when a user acts on a Room it does not belong to.
+	ErrUserNotInRoom = domainerror.New("user is not a participant of the room")
+)
+
+// Room is the conversation opened between the users of a match.
+// Its ID is the ID of the match that created it.
+//
+// The participants of a group room can leave it while the others keep talking.
+type Room struct {
+	id           ID
+	participants []ID
+	left         []ID
+	createdAt    time.Time
+	// version is the stored version the Room was loaded with, zero until it is saved.
+	version uint64
+}
+
+// NewRoom creates a new Room for the given participants.
+func NewRoom(id ID, participants ...ID) (*Room, error) {
+	room := &Room{
+		id:           id,
+		participants: slices.Clone(participants),
+		createdAt:    time.Now().UTC(),
+	}
+
+	if err := room.validate(); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// ID returns the Room ID.
+func (r Room) ID() ID {
+	return r.id
+}
+
+// Participants returns the IDs of the Room participants that did not leave it.
+func (r Room) Participants() []ID {
+	participants := make([]ID, 0, len(r.participants))
+	for _, participant := range r.participants {
+		if !slices.Contains(r.left, participant) {
+			participants = append(participants, participant)
+		}
+	}
+	return participants
+}
+
+// IsGroup reports whether the Room was opened for more than two participants.
+func (r Room) IsGroup() bool {
+	return len(r.participants) > MinRoomParticipants
+}
+
+// CreatedAt returns the time the Room was created.
+func (r Room) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Version returns the stored version the Room was loaded with.
+// The repositories only update the Room while it is still the stored version.
+func (r Room) Version() uint64 {
+	return r.version
+}
+
+// SetVersion sets the stored version of the Room, once it is loaded or saved.
+func (r *Room) SetVersion(version uint64) {
+	r.version = version
+}
+
+// HasParticipant reports whether the user is a participant of the Room that did not leave it.
+func (r Room) HasParticipant(userID ID) bool {
+	return slices.Contains(r.participants, userID) && !slices.Contains(r.left, userID)
+}
+
+// PartnersOf returns the participants of the Room other than the given user.
+func (r Room) PartnersOf(userID ID) []ID {
+	participants := r.Participants()
+	partners := make([]ID, 0, len(participants))
+	for _, participant := range participants {
+		if participant != userID {
+			partners = append(partners, participant)
+		}
+	}
+	return partners
+}
+
+// Leave removes the user from the participants of the Room,
+// so it no longer receives nor sends its messages.
+func (r *Room) Leave(userID ID) error {
+	if !r.HasParticipant(userID) {
+		return ErrUserNotInRoom
+	}
+
+	r.left = append(r.left, userID)
+	return nil
+}
+
+func (r Room) validate() error {
+	if r.id.IsEmpty() {
+		return ErrInvalidRoomID
+	}
+
+	unique := make(map[ID]struct{}, len(r.participants))
+	for _, participant := range r.participants {
+		if participant.IsEmpty() {
+			return ErrInvalidRoomParticipants
+		}
+		unique[participant] = struct{}{}
+	}
+
+	if len(unique) < MinRoomParticipants || len(unique) != len(r.participants) {
+		return ErrInvalidRoomParticipants
+	}
+	return nil
+}
+
+type roomDTO struct {
+	ID           ID        `json:"id"`
+	Participants []ID      `json:"participants"`
+	Left         []ID      `json:"left,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MarshalJSON serializes the Room to JSON, preserving encapsulation.
+func (r Room) MarshalJSON() ([]byte, error) {
+	return json.Marshal(roomDTO{
+		ID:           r.id,
+		Participants: r.participants,
+		Left:         r.left,
+		CreatedAt:    r.createdAt,
+	})
+}
+
+// UnmarshalJSON deserializes JSON into a Room, preserving encapsulation.
+func (r *Room) UnmarshalJSON(data []byte) error {
+	var dto roomDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	r.id = dto.ID
+	r.participants = dto.Participants
+	r.left = dto.Left
+	r.createdAt = dto.CreatedAt
+	return r.validate()
+}
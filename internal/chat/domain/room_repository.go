@@ -0,0 +1,29 @@
+package chatdomain
+
+import (
+	"context"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+var (
+	ErrRoomNotFound      = domainerror.New("room not found")
+	ErrRoomAlreadyExists = domainerror.New("room already exists with the given ID")
+	// ErrRoomVersionMismatch is returned when the Room was modified since it was loaded.
+	ErrRoomVersionMismatch = domainerror.New("room was modified concurrently")
+)
+
+type RoomRepository interface {
+	// Save persists a new Room and indexes it by its participants.
+	Save(ctx context.Context, room *Room) error
+
+	// Update persists the changes of an existing Room. It fails with ErrRoomVersionMismatch
+	// when the stored version is not the version the Room was loaded with.
+	Update(ctx context.Context, room *Room) error
+
+	// FindByID retrieves a Room by its unique identifier.
+	FindByID(ctx context.Context, id ID) (*Room, error)
+
+	// FindByUserID retrieves the last Room the given user joined.
+	FindByUserID(ctx context.Context, userID ID) (*Room, error)
+}
@@ -0,0 +1,95 @@
+package chatdomain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+func TestNewRoom(t *testing.T) {
+	t.Run("should create a room for the given participants", func(t *testing.T) {
+		room, err := chatdomain.NewRoom("match-1", "alice", "bob")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ID("match-1"), room.ID())
+		require.Equal(t, []chatdomain.ID{"alice", "bob"}, room.Participants())
+		require.True(t, room.HasParticipant("alice"))
+		require.False(t, room.HasParticipant("carol"))
+		require.Equal(t, []chatdomain.ID{"bob"}, room.PartnersOf("alice"))
+	})
+
+	t.Run("should fail when the room ID is empty", func(t *testing.T) {
+		_, err := chatdomain.NewRoom("", "alice", "bob")
+		require.ErrorIs(t, err, chatdomain.ErrInvalidRoomID)
+	})
+
+	t.Run("should fail without two distinct participants", func(t *testing.T) {
+		_, err := chatdomain.NewRoom("match-1", "alice")
+		require.ErrorIs(t, err, chatdomain.ErrInvalidRoomParticipants)
+
+		_, err = chatdomain.NewRoom("match-1", "alice", "alice")
+		require.ErrorIs(t, err, chatdomain.ErrInvalidRoomParticipants)
+
+		_, err = chatdomain.NewRoom("match-1", "alice", "")
+		require.ErrorIs(t, err, chatdomain.ErrInvalidRoomParticipants)
+	})
+
+	t.Run("should round trip through JSON", func(t *testing.T) {
+		room, err := chatdomain.NewRoom("match-1", "alice", "bob")
+		require.NoError(t, err)
+
+		body, err := json.Marshal(room)
+		require.NoError(t, err)
+
+		var decoded chatdomain.Room
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		require.Equal(t, room.ID(), decoded.ID())
+		require.Equal(t, room.Participants(), decoded.Participants())
+		require.True(t, room.CreatedAt().Equal(decoded.CreatedAt()))
+	})
+}
+
+func TestRoomLeave(t *testing.T) {
+	room, err := chatdomain.NewRoom("match-1", "alice", "bob", "carol")
+	require.NoError(t, err)
+	require.True(t, room.IsGroup())
+
+	require.NoError(t, room.Leave("alice"))
+	require.ErrorIs(t, room.Leave("alice"), chatdomain.ErrUserNotInRoom)
+	require.False(t, room.HasParticipant("alice"))
+	require.Equal(t, []chatdomain.ID{"bob", "carol"}, room.Participants())
+	require.Equal(t, []chatdomain.ID{"carol"}, room.PartnersOf("bob"))
+
+	body, err := json.Marshal(room)
+	require.NoError(t, err)
+
+	var decoded chatdomain.Room
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, room.Participants(), decoded.Participants())
+}
+
+func TestNewTextMessage(t *testing.T) {
+	t.Run("should trim the text and assign an ID", func(t *testing.T) {
+		msg, err := chatdomain.NewTextMessage("match-1", "alice", "  hello  ")
+		require.NoError(t, err)
+		require.Equal(t, "hello", msg.Text())
+		require.False(t, msg.ID().IsEmpty())
+		require.False(t, msg.SentAt().IsZero())
+	})
+
+	t.Run("should fail when the text is blank", func(t *testing.T) {
+		_, err := chatdomain.NewTextMessage("match-1", "alice", " \n ")
+		require.ErrorIs(t, err, chatdomain.ErrEmptyMessage)
+	})
+
+	t.Run("should fail when the text is too long", func(t *testing.T) {
+		text := make([]rune, chatdomain.MaxMessageTextLength+1)
+		for i := range text {
+			text[i] = 'a'
+		}
+		_, err := chatdomain.NewTextMessage("match-1", "alice", string(text))
+		require.ErrorIs(t, err, chatdomain.ErrMessageTooLong)
+	})
+}
@@ -3,6 +3,7 @@ package chatdomain
 import (
 	domainerr "github.com/xfrr/randomtalk/internal/shared/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/language"
 	geo "github.com/xfrr/randomtalk/internal/shared/location"
 )
 
@@ -18,6 +19,12 @@ var (
 	ErrUserAgeTooLow = domainerr.New("user age is too low")
 	// ErrUserAgeTooHigh is returned when the User age is too high.
 	ErrUserAgeTooHigh = domainerr.New("user age is too high")
+	// ErrUserLocationInvalid is returned when the User location has out-of-range coordinates.
+	ErrUserLocationInvalid = domainerr.New("user location is invalid")
+	// ErrUserLanguageInvalid is returned when a User language is not a valid BCP-47 tag.
+	ErrUserLanguageInvalid = domainerr.New("user language is invalid")
+	// ErrUserGroupSizeInvalid is returned when the User asks for a group room of an unsupported size.
+	ErrUserGroupSizeInvalid = domainerr.New("user group size is invalid")
 )
 
 type NewUserOption func(u *User)
@@ -29,6 +36,13 @@ func WithLocation(location *geo.Location) NewUserOption {
 	}
 }
 
+// WithLanguages sets the BCP-47 tags of the languages spoken by the User.
+func WithLanguages(languages ...string) NewUserOption {
+	return func(u *User) {
+		u.languages = languages
+	}
+}
+
 // User represents a user in the Chat bounded context.
 type User struct {
 	id               ID
@@ -37,6 +51,7 @@ type User struct {
 	location         *geo.Location
 	gender           gender.Gender
 	matchPreferences MatchPreferences
+	languages        []string
 }
 
 // ID returns the User ID.
@@ -69,6 +84,11 @@ func (u User) MatchPreferences() MatchPreferences {
 	return u.matchPreferences
 }
 
+// Languages returns the BCP-47 tags of the languages spoken by the User.
+func (u User) Languages() []string {
+	return u.languages
+}
+
 func (u User) validate() error {
 	if u.age < MinUserAge {
 		return ErrUserAgeTooLow
@@ -77,6 +97,16 @@ func (u User) validate() error {
 	if u.age > MaxUserAge {
 		return ErrUserAgeTooHigh
 	}
+
+	if u.location != nil && !u.location.Coordinates.IsValid() {
+		return ErrUserLocationInvalid
+	}
+
+	for _, lang := range u.languages {
+		if _, err := language.Parse(lang); err != nil {
+			return ErrUserLanguageInvalid
+		}
+	}
 	return nil
 }
 
@@ -105,5 +135,8 @@ func NewUser(
 		return User{}, err
 	}
 
+	// the languages are valid, keep them in their canonical form
+	user.languages, _ = language.ParseAll(user.languages...)
+
 	return user, nil
 }
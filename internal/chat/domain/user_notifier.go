@@ -0,0 +1,29 @@
+package chatdomain
+
+import (
+	"context"
+	"time"
+)
+
+// UserNotifier defines the interface for pushing system notifications to the users.
+type UserNotifier interface {
+	// NotifyUserLeft tells the recipient that the given user left the room they shared.
+	NotifyUserLeft(ctx context.Context, recipientID, userID, roomID ID) error
+
+	// NotifyNewMatch tells the recipient it was matched, and the room it talks to the participants in.
+	// The first participants are the requester and the matched user of the match.
+	NotifyNewMatch(ctx context.Context, recipientID, roomID ID, participantIDs []ID) error
+
+	// NotifyNoMatchFound tells the recipient that no match was found after waiting for the given time.
+	NotifyNoMatchFound(ctx context.Context, recipientID ID, waited time.Duration) error
+
+	// NotifyMatchProposed tells the recipient it was matched, pending the acceptance of both users.
+	NotifyMatchProposed(ctx context.Context, recipientID ID, proposal MatchProposal) error
+
+	// NotifyMatchCancelled tells the recipient that the proposed match was cancelled for the given reason,
+	// and whether it keeps waiting for a new match.
+	NotifyMatchCancelled(ctx context.Context, recipientID, matchID ID, reason string, requeued bool) error
+
+	// NotifyQueueStatus tells the recipient its progress while waiting for a match.
+	NotifyQueueStatus(ctx context.Context, recipientID ID, status QueueStatus) error
+}
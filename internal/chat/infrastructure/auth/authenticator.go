@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMissingToken is returned when the request does not carry any token.
+	ErrMissingToken = errors.New("missing access token")
+	// ErrInvalidToken is returned when the token is malformed, its signature does not match
+	// or any of its claims is not valid.
+	ErrInvalidToken = errors.New("invalid access token")
+	// ErrTokenExpired is returned when the token is expired or not valid yet.
+	ErrTokenExpired = errors.New("access token expired")
+)
+
+// Authenticator verifies an access token and returns the session of its owner.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (SessionContext, error)
+}
+
+// AuthenticatorFunc adapts a function to the Authenticator interface.
+type AuthenticatorFunc func(ctx context.Context, token string) (SessionContext, error)
+
+// Authenticate implements Authenticator.
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, token string) (SessionContext, error) {
+	return f(ctx, token)
+}
+
+// ChainAuthenticator tries each authenticator in order and returns the first session opened.
+type ChainAuthenticator []Authenticator
+
+// Chain combines the given authenticators, so a token is accepted by any of them.
+func Chain(authenticators ...Authenticator) ChainAuthenticator {
+	return ChainAuthenticator(authenticators)
+}
+
+// Authenticate implements Authenticator.
+// It returns the error of the last authenticator when none of them accepts the token.
+func (c ChainAuthenticator) Authenticate(ctx context.Context, token string) (SessionContext, error) {
+	if token == "" {
+		return SessionContext{}, ErrMissingToken
+	}
+
+	err := ErrInvalidToken
+	for _, authenticator := range c {
+		var session SessionContext
+		session, err = authenticator.Authenticate(ctx, token)
+		if err == nil {
+			return session, nil
+		}
+	}
+	return SessionContext{}, err
+}
@@ -1,17 +1,42 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type contextKey string
 
+// Claims holds the claims of an authenticated token.
+type Claims map[string]any
+
+// String returns the claim as a string, if present.
+func (c Claims) String(name string) (string, bool) {
+	value, ok := c[name].(string)
+	return value, ok
+}
+
+// SessionContext holds the identity of the authenticated user.
 type SessionContext struct {
 	UserID string
+	// Guest reports whether the session was opened with a guest token issued by the server.
+	Guest bool
+	// Claims holds every claim of the token the session was opened with.
+	Claims Claims
+	// ExpiresAt is the time the token expires at. Zero when it does not expire.
+	ExpiresAt time.Time
 }
 
 func (a SessionContext) GetUserID() string {
 	return a.UserID
 }
 
+// Claim returns the value of a claim of the session token, if present.
+func (a SessionContext) Claim(name string) (any, bool) {
+	value, ok := a.Claims[name]
+	return value, ok
+}
+
 func ContextWithSession(ctx context.Context, session SessionContext) context.Context {
 	return context.WithValue(ctx, contextKey("session"), session)
 }
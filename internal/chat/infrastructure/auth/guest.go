@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// GuestClaim is the claim set on the guest tokens issued by the server.
+	GuestClaim = "guest"
+
+	guestKeyID      = "guest"
+	guestUserPrefix = "guest-"
+)
+
+var _ Authenticator = (*GuestTokenIssuer)(nil)
+
+// GuestTokenIssuer issues anonymous guest tokens and authenticates them.
+// Tokens are HS256 signed JWTs, so every instance sharing the secret accepts them.
+type GuestTokenIssuer struct {
+	issuer        string
+	secret        []byte
+	ttl           time.Duration
+	now           func() time.Time
+	authenticator *JWTAuthenticator
+}
+
+// NewGuestTokenIssuer creates a new GuestTokenIssuer signing the tokens with the given secret.
+func NewGuestTokenIssuer(issuer string, secret []byte, ttl time.Duration, opts ...JWTOption) *GuestTokenIssuer {
+	authenticator := NewJWTAuthenticator(
+		NewHMACKeySet(guestKeyID, secret),
+		append([]JWTOption{WithIssuer(issuer)}, opts...)...,
+	)
+
+	return &GuestTokenIssuer{
+		issuer:        issuer,
+		secret:        secret,
+		ttl:           ttl,
+		now:           authenticator.now,
+		authenticator: authenticator,
+	}
+}
+
+// Issue creates a token for a new guest user.
+func (g *GuestTokenIssuer) Issue(_ context.Context) (string, SessionContext, error) {
+	now := g.now()
+	expiresAt := now.Add(g.ttl)
+
+	claims := Claims{
+		"sub":      guestUserPrefix + uuid.New().String(),
+		"iss":      g.issuer,
+		"iat":      now.Unix(),
+		"exp":      expiresAt.Unix(),
+		GuestClaim: true,
+	}
+
+	token, err := signHS256(guestKeyID, g.secret, claims)
+	if err != nil {
+		return "", SessionContext{}, fmt.Errorf("sign guest token: %w", err)
+	}
+
+	session := SessionContext{
+		UserID:    claims["sub"].(string),
+		Guest:     true,
+		Claims:    claims,
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0),
+	}
+	return token, session, nil
+}
+
+// Authenticate implements Authenticator. Only the guest tokens issued by the server are accepted.
+func (g *GuestTokenIssuer) Authenticate(ctx context.Context, token string) (SessionContext, error) {
+	session, err := g.authenticator.Authenticate(ctx, token)
+	if err != nil {
+		return SessionContext{}, err
+	}
+
+	if guest, _ := session.Claims[GuestClaim].(bool); !guest {
+		return SessionContext{}, fmt.Errorf("%w: not a guest token", ErrInvalidToken)
+	}
+
+	session.Guest = true
+	return session, nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var _ Authenticator = (*IntrospectionAuthenticator)(nil)
+
+// Introspection is the state of an opaque token as reported by its authorization server.
+type Introspection struct {
+	Active    bool
+	Subject   string
+	ExpiresAt time.Time
+	Claims    Claims
+}
+
+// TokenIntrospector resolves opaque tokens against the authorization server that issued them.
+type TokenIntrospector interface {
+	Introspect(ctx context.Context, token string) (Introspection, error)
+}
+
+// IntrospectionAuthenticator authenticates opaque tokens through a TokenIntrospector.
+type IntrospectionAuthenticator struct {
+	introspector TokenIntrospector
+}
+
+// NewIntrospectionAuthenticator creates a new IntrospectionAuthenticator.
+func NewIntrospectionAuthenticator(introspector TokenIntrospector) *IntrospectionAuthenticator {
+	return &IntrospectionAuthenticator{
+		introspector: introspector,
+	}
+}
+
+// Authenticate implements Authenticator.
+func (a *IntrospectionAuthenticator) Authenticate(ctx context.Context, token string) (SessionContext, error) {
+	if token == "" {
+		return SessionContext{}, ErrMissingToken
+	}
+
+	introspection, err := a.introspector.Introspect(ctx, token)
+	if err != nil {
+		return SessionContext{}, fmt.Errorf("introspect token: %w", err)
+	}
+
+	if !introspection.Active {
+		return SessionContext{}, fmt.Errorf("%w: inactive token", ErrInvalidToken)
+	}
+
+	if introspection.Subject == "" {
+		return SessionContext{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return SessionContext{
+		UserID:    introspection.Subject,
+		Claims:    introspection.Claims,
+		ExpiresAt: introspection.ExpiresAt,
+	}, nil
+}
+
+var _ TokenIntrospector = (*HTTPTokenIntrospector)(nil)
+
+// HTTPTokenIntrospector introspects tokens through an OAuth 2.0 token introspection endpoint (RFC 7662).
+type HTTPTokenIntrospector struct {
+	endpoint     string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+}
+
+// NewHTTPTokenIntrospector creates a new HTTPTokenIntrospector authenticating
+// against the endpoint with the given client credentials, if any.
+func NewHTTPTokenIntrospector(endpoint, clientID, clientSecret string) *HTTPTokenIntrospector {
+	return &HTTPTokenIntrospector{
+		endpoint:     endpoint,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Introspect implements TokenIntrospector.
+func (i *HTTPTokenIntrospector) Introspect(ctx context.Context, token string) (Introspection, error) {
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Introspection{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if i.clientID != "" {
+		req.SetBasicAuth(i.clientID, i.clientSecret)
+	}
+
+	res, err := i.client.Do(req)
+	if err != nil {
+		return Introspection{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Introspection{}, fmt.Errorf("unexpected introspection status: %s", res.Status)
+	}
+
+	var claims Claims
+	decoder := json.NewDecoder(res.Body)
+	decoder.UseNumber()
+	if err = decoder.Decode(&claims); err != nil {
+		return Introspection{}, fmt.Errorf("decode introspection response: %w", err)
+	}
+
+	active, _ := claims["active"].(bool)
+	subject, _ := claims.String("sub")
+	introspection := Introspection{
+		Active:  active,
+		Subject: subject,
+		Claims:  claims,
+	}
+	if expiresAt, ok := numericDate(claims["exp"]); ok {
+		introspection.ExpiresAt = expiresAt
+	}
+	return introspection, nil
+}
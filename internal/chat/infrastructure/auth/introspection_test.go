@@ -0,0 +1,60 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+func TestIntrospectionAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "chat" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response := map[string]any{"active": false}
+		if r.FormValue("token") == "opaque-alice" {
+			response = map[string]any{"active": true, "sub": "alice", "exp": expiresAt, "scope": "chat"}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	authenticator := auth.Chain(
+		auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Hour),
+		auth.NewIntrospectionAuthenticator(auth.NewHTTPTokenIntrospector(server.URL, "chat", "secret")),
+	)
+
+	t.Run("should authenticate active tokens", func(t *testing.T) {
+		session, err := authenticator.Authenticate(ctx, "opaque-alice")
+		require.NoError(t, err)
+		require.Equal(t, "alice", session.UserID)
+		require.Equal(t, expiresAt, session.ExpiresAt.Unix())
+
+		scope, ok := session.Claims.String("scope")
+		require.True(t, ok)
+		require.Equal(t, "chat", scope)
+	})
+
+	t.Run("should reject inactive tokens", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, "opaque-revoked")
+		require.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("should reject empty tokens", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, "")
+		require.ErrorIs(t, err, auth.ErrMissingToken)
+	})
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+const (
+	// AlgorithmHS256 is the HMAC using SHA-256 JWS algorithm.
+	AlgorithmHS256 = "HS256"
+	// AlgorithmRS256 is the RSASSA-PKCS1-v1_5 using SHA-256 JWS algorithm.
+	AlgorithmRS256 = "RS256"
+)
+
+// JSONWebKey is a JSON Web Key (RFC 7517) used to verify tokens.
+// Only symmetric ("oct") and RSA keys are supported.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+
+	// K is the base64url encoded secret of the symmetric keys.
+	K string `json:"k,omitempty"`
+	// N and E are the base64url encoded modulus and exponent of the RSA public keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// KeySet holds the keys used to verify the signature of the tokens.
+type KeySet struct {
+	keys []verificationKey
+}
+
+type verificationKey struct {
+	id        string
+	algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+// NewHMACKeySet creates a KeySet holding a single HS256 secret.
+func NewHMACKeySet(keyID string, secret []byte) KeySet {
+	return KeySet{keys: []verificationKey{{id: keyID, algorithm: AlgorithmHS256, secret: secret}}}
+}
+
+// LoadJWKSFile reads a JSON Web Key Set from a local file.
+func LoadJWKSFile(path string) (KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return KeySet{}, fmt.Errorf("read jwks file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses a JSON Web Key Set. Keys not meant for signatures are ignored.
+func ParseJWKS(data []byte) (KeySet, error) {
+	var jwks struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return KeySet{}, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	var set KeySet
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.verificationKey()
+		if err != nil {
+			return KeySet{}, fmt.Errorf("jwk %q: %w", jwk.KeyID, err)
+		}
+		set.keys = append(set.keys, key)
+	}
+
+	if len(set.keys) == 0 {
+		return KeySet{}, errors.New("jwks has no signature keys")
+	}
+	return set, nil
+}
+
+func (jwk JSONWebKey) verificationKey() (verificationKey, error) {
+	switch jwk.KeyType {
+	case "oct":
+		if jwk.Algorithm != "" && jwk.Algorithm != AlgorithmHS256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm %q for oct keys", jwk.Algorithm)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, errors.New("invalid oct key")
+		}
+		return verificationKey{id: jwk.KeyID, algorithm: AlgorithmHS256, secret: secret}, nil
+	case "RSA":
+		if jwk.Algorithm != "" && jwk.Algorithm != AlgorithmRS256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm %q for RSA keys", jwk.Algorithm)
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(n) == 0 {
+			return verificationKey{}, errors.New("invalid RSA modulus")
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return verificationKey{}, errors.New("invalid RSA exponent")
+		}
+
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return verificationKey{id: jwk.KeyID, algorithm: AlgorithmRS256, publicKey: publicKey}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %q", jwk.KeyType)
+	}
+}
+
+// find returns the key to verify a token signed with the given algorithm.
+// Tokens without key ID are only accepted when a single key matches the algorithm.
+func (s KeySet) find(keyID, algorithm string) (verificationKey, bool) {
+	var (
+		found verificationKey
+		count int
+	)
+
+	for _, key := range s.keys {
+		if key.algorithm != algorithm {
+			continue
+		}
+
+		if keyID != "" && key.id == keyID {
+			return key, true
+		}
+
+		found = key
+		count++
+	}
+
+	return found, keyID == "" && count == 1
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// JWTAuthenticator authenticates signed JSON Web Tokens (HS256 or RS256).
+// The "sub" claim is used as the user ID.
+type JWTAuthenticator struct {
+	keys     KeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// JWTOption defines a functional option to configure the JWTAuthenticator.
+type JWTOption func(*JWTAuthenticator)
+
+// WithIssuer requires the tokens to be issued by the given issuer.
+func WithIssuer(issuer string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.issuer = issuer
+	}
+}
+
+// WithAudience requires the tokens to be issued for the given audience.
+func WithAudience(audience string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.audience = audience
+	}
+}
+
+// WithLeeway tolerates the given clock skew when validating the token times.
+func WithLeeway(leeway time.Duration) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.leeway = leeway
+	}
+}
+
+// WithClock overrides the function used to get the current time.
+func WithClock(now func() time.Time) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.now = now
+	}
+}
+
+// NewJWTAuthenticator creates a new JWTAuthenticator verifying the tokens with the given keys.
+func NewJWTAuthenticator(keys KeySet, opts ...JWTOption) *JWTAuthenticator {
+	a := &JWTAuthenticator{
+		keys:   keys,
+		leeway: 30 * time.Second,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// Authenticate implements Authenticator.
+func (a *JWTAuthenticator) Authenticate(_ context.Context, token string) (SessionContext, error) {
+	if token == "" {
+		return SessionContext{}, ErrMissingToken
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return SessionContext{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return SessionContext{}, fmt.Errorf("%w: header: %w", ErrInvalidToken, err)
+	}
+
+	key, ok := a.keys.find(header.KeyID, header.Algorithm)
+	if !ok {
+		return SessionContext{}, fmt.Errorf("%w: no key for algorithm %q", ErrInvalidToken, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return SessionContext{}, fmt.Errorf("%w: signature: %w", ErrInvalidToken, err)
+	}
+
+	if !key.verify(parts[0]+"."+parts[1], signature) {
+		return SessionContext{}, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return SessionContext{}, fmt.Errorf("%w: claims: %w", ErrInvalidToken, err)
+	}
+
+	return a.validate(claims)
+}
+
+func (a *JWTAuthenticator) validate(claims Claims) (SessionContext, error) {
+	subject, _ := claims.String("sub")
+	if subject == "" {
+		return SessionContext{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	now := a.now()
+	expiresAt, hasExpiration := numericDate(claims["exp"])
+	if hasExpiration && !now.Before(expiresAt.Add(a.leeway)) {
+		return SessionContext{}, ErrTokenExpired
+	}
+
+	if notBefore, ok := numericDate(claims["nbf"]); ok && now.Add(a.leeway).Before(notBefore) {
+		return SessionContext{}, ErrTokenExpired
+	}
+
+	if a.issuer != "" {
+		if issuer, _ := claims.String("iss"); issuer != a.issuer {
+			return SessionContext{}, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+		}
+	}
+
+	if a.audience != "" && !hasAudience(claims["aud"], a.audience) {
+		return SessionContext{}, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	session := SessionContext{
+		UserID: subject,
+		Claims: claims,
+	}
+	if hasExpiration {
+		session.ExpiresAt = expiresAt
+	}
+	return session, nil
+}
+
+func (k verificationKey) verify(signingInput string, signature []byte) bool {
+	switch k.algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signingInput))
+		return hmac.Equal(signature, mac.Sum(nil))
+	case AlgorithmRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.VerifyPKCS1v15(k.publicKey, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
+
+// signHS256 creates a HS256 signed token holding the given claims.
+func signHS256(keyID string, secret []byte, claims Claims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Algorithm: AlgorithmHS256, KeyID: keyID, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// numericDate parses a JWT NumericDate claim, the number of seconds since the epoch.
+func numericDate(value any) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+// hasAudience reports whether the "aud" claim, a string or a list of strings, contains the audience.
+func hasAudience(value any, audience string) bool {
+	switch aud := value.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, item := range aud {
+			if item == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+var b64 = base64.RawURLEncoding
+
+func signToken(t *testing.T, header, claims map[string]any, sign func(signingInput []byte) []byte) string {
+	t.Helper()
+
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := b64.EncodeToString(h) + "." + b64.EncodeToString(c)
+	return signingInput + "." + b64.EncodeToString(sign([]byte(signingInput)))
+}
+
+func hs256(secret []byte) func([]byte) []byte {
+	return func(signingInput []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(t *testing.T, key *rsa.PrivateKey) func([]byte) []byte {
+	return func(signingInput []byte) []byte {
+		digest := sha256.Sum256(signingInput)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return signature
+	}
+}
+
+func writeJWKS(t *testing.T, secret []byte, rsaKey *rsa.PublicKey) string {
+	t.Helper()
+
+	jwks := fmt.Sprintf(`{"keys":[
+		{"kty":"oct","kid":"hmac-1","alg":"HS256","k":%q},
+		{"kty":"RSA","kid":"rsa-1","alg":"RS256","use":"sig","n":%q,"e":%q}
+	]}`,
+		b64.EncodeToString(secret),
+		b64.EncodeToString(rsaKey.N.Bytes()),
+		b64.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+	)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(jwks), 0o600))
+	return path
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	secret := []byte("a-very-secret-hmac-key")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys, err := auth.LoadJWKSFile(writeJWKS(t, secret, &rsaKey.PublicKey))
+	require.NoError(t, err)
+
+	authenticator := auth.NewJWTAuthenticator(keys,
+		auth.WithIssuer("https://id.randomtalk.test"),
+		auth.WithAudience("randomtalk-chat"),
+	)
+
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"sub":  "alice",
+			"iss":  "https://id.randomtalk.test",
+			"aud":  []string{"randomtalk-chat"},
+			"exp":  now.Add(time.Hour).Unix(),
+			"role": "member",
+		}
+	}
+
+	t.Run("should authenticate HS256 tokens", func(t *testing.T) {
+		token := signToken(t, map[string]any{"alg": "HS256", "kid": "hmac-1"}, validClaims(), hs256(secret))
+
+		session, err := authenticator.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, "alice", session.UserID)
+		require.False(t, session.Guest)
+		require.Equal(t, now.Add(time.Hour).Unix(), session.ExpiresAt.Unix())
+
+		role, ok := session.Claim("role")
+		require.True(t, ok)
+		require.Equal(t, "member", role)
+	})
+
+	t.Run("should authenticate RS256 tokens", func(t *testing.T) {
+		token := signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, validClaims(), rs256(t, rsaKey))
+
+		session, err := authenticator.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, "alice", session.UserID)
+	})
+
+	t.Run("should reject invalid tokens", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		expired := validClaims()
+		expired["exp"] = now.Add(-time.Hour).Unix()
+
+		wrongAudience := validClaims()
+		wrongAudience["aud"] = "another-service"
+
+		noSubject := validClaims()
+		delete(noSubject, "sub")
+
+		cases := map[string]struct {
+			token string
+			err   error
+		}{
+			"empty": {
+				token: "",
+				err:   auth.ErrMissingToken,
+			},
+			"malformed": {
+				token: "not-a-jwt",
+				err:   auth.ErrInvalidToken,
+			},
+			"unsigned": {
+				token: signToken(t, map[string]any{"alg": "none"}, validClaims(), func([]byte) []byte { return nil }),
+				err:   auth.ErrInvalidToken,
+			},
+			"wrong secret": {
+				token: signToken(t, map[string]any{"alg": "HS256", "kid": "hmac-1"}, validClaims(), hs256([]byte("wrong"))),
+				err:   auth.ErrInvalidToken,
+			},
+			"wrong RSA key": {
+				token: signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, validClaims(), rs256(t, otherKey)),
+				err:   auth.ErrInvalidToken,
+			},
+			"algorithm confusion": {
+				token: signToken(t, map[string]any{"alg": "HS256", "kid": "rsa-1"}, validClaims(), hs256(rsaKey.N.Bytes())),
+				err:   auth.ErrInvalidToken,
+			},
+			"expired": {
+				token: signToken(t, map[string]any{"alg": "HS256", "kid": "hmac-1"}, expired, hs256(secret)),
+				err:   auth.ErrTokenExpired,
+			},
+			"wrong audience": {
+				token: signToken(t, map[string]any{"alg": "HS256", "kid": "hmac-1"}, wrongAudience, hs256(secret)),
+				err:   auth.ErrInvalidToken,
+			},
+			"no subject": {
+				token: signToken(t, map[string]any{"alg": "HS256", "kid": "hmac-1"}, noSubject, hs256(secret)),
+				err:   auth.ErrInvalidToken,
+			},
+		}
+
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := authenticator.Authenticate(ctx, tc.token)
+				require.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestGuestTokenIssuer(t *testing.T) {
+	ctx := context.Background()
+	issuer := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Hour)
+
+	t.Run("should authenticate the issued tokens", func(t *testing.T) {
+		token, issued, err := issuer.Issue(ctx)
+		require.NoError(t, err)
+		require.True(t, issued.Guest)
+
+		session, err := issuer.Authenticate(ctx, token)
+		require.NoError(t, err)
+		require.True(t, session.Guest)
+		require.Equal(t, issued.UserID, session.UserID)
+		require.Equal(t, issued.ExpiresAt, session.ExpiresAt)
+	})
+
+	t.Run("should reject tokens issued by other secrets or without guest claim", func(t *testing.T) {
+		token, _, err := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("other-secret"), time.Hour).Issue(ctx)
+		require.NoError(t, err)
+
+		_, err = issuer.Authenticate(ctx, token)
+		require.ErrorIs(t, err, auth.ErrInvalidToken)
+
+		token = signToken(t,
+			map[string]any{"alg": "HS256", "kid": "guest"},
+			map[string]any{"sub": "alice", "iss": "randomtalk-chat"},
+			hs256([]byte("guest-secret")),
+		)
+		_, err = issuer.Authenticate(ctx, token)
+		require.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("should reject expired tokens", func(t *testing.T) {
+		expiring := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Minute,
+			auth.WithClock(func() time.Time { return time.Now().Add(-time.Hour) }),
+		)
+		token, _, err := expiring.Issue(ctx)
+		require.NoError(t, err)
+
+		_, err = issuer.Authenticate(ctx, token)
+		require.ErrorIs(t, err, auth.ErrTokenExpired)
+	})
+}
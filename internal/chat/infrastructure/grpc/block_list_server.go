@@ -0,0 +1,127 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/xfrr/go-cqrsify/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatqueries "github.com/xfrr/randomtalk/internal/chat/application/queries"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+var _ chatpbv1.BlockListServiceServer = (*BlockListServer)(nil)
+
+// BlockListServer implements the BlockListService gRPC API
+// on top of the chat command and query buses.
+type BlockListServer struct {
+	chatpbv1.UnimplementedBlockListServiceServer
+
+	cmdbus chatcommands.CommandBus
+	qrybus chatqueries.QueryBus
+	logger *zerolog.Logger
+}
+
+// BlockListServerOption defines a functional option to configure the BlockListServer.
+type BlockListServerOption func(*BlockListServer)
+
+// WithBlockListLogger overrides the default zerolog.Logger.
+func WithBlockListLogger(logger *zerolog.Logger) BlockListServerOption {
+	return func(s *BlockListServer) {
+		s.logger = logger
+	}
+}
+
+// NewBlockListServer initializes a new BlockListServer.
+func NewBlockListServer(
+	cmdbus chatcommands.CommandBus,
+	qrybus chatqueries.QueryBus,
+	opts ...BlockListServerOption,
+) *BlockListServer {
+	srv := &BlockListServer{
+		cmdbus: cmdbus,
+		qrybus: qrybus,
+		logger: &zerolog.Logger{},
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
+}
+
+// ListBlockedUsers returns the users blocked by the authenticated user.
+func (s *BlockListServer) ListBlockedUsers(ctx context.Context, _ *chatpbv1.ListBlockedUsersRequest) (*chatpbv1.ListBlockedUsersResponse, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, chatcommands.ErrMissingUserIDFromContext.Error())
+	}
+
+	reply, err := messaging.DispatchQuery[chatqueries.ListBlockedUsersQuery, chatqueries.ListBlockedUsersQueryReply](
+		ctx,
+		s.qrybus,
+		chatqueries.NewListBlockedUsersQuery(userID),
+	)
+	if err != nil {
+		s.logger.Error().Err(err).
+			Str("user_id", userID).
+			Msg("failed to list blocked users")
+		return nil, toBlockListStatusError(err)
+	}
+
+	userIDs := make([]string, 0, len(reply.BlockedUserIDs))
+	for _, blockedUserID := range reply.BlockedUserIDs {
+		userIDs = append(userIDs, blockedUserID.String())
+	}
+	return &chatpbv1.ListBlockedUsersResponse{UserIds: userIDs}, nil
+}
+
+// BlockUser adds the user to the block list of the authenticated user.
+func (s *BlockListServer) BlockUser(ctx context.Context, req *chatpbv1.BlockUserRequest) (*chatpbv1.BlockUserResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	cmd := chatcommands.BlockUserCommand{
+		BaseCommand: messaging.NewBaseCommand(chatcommands.BlockUserCommandType),
+		UserID:      req.GetUserId(),
+	}
+
+	if err := messaging.DispatchCommand(ctx, s.cmdbus, cmd); err != nil {
+		return nil, toBlockListStatusError(err)
+	}
+	return &chatpbv1.BlockUserResponse{}, nil
+}
+
+// UnblockUser removes the user from the block list of the authenticated user.
+func (s *BlockListServer) UnblockUser(ctx context.Context, req *chatpbv1.UnblockUserRequest) (*chatpbv1.UnblockUserResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	cmd := chatcommands.UnblockUserCommand{
+		BaseCommand: messaging.NewBaseCommand(chatcommands.UnblockUserCommandType),
+		UserID:      req.GetUserId(),
+	}
+
+	if err := messaging.DispatchCommand(ctx, s.cmdbus, cmd); err != nil {
+		return nil, toBlockListStatusError(err)
+	}
+	return &chatpbv1.UnblockUserResponse{}, nil
+}
+
+func toBlockListStatusError(err error) error {
+	switch {
+	case errors.Is(err, chatdomain.ErrBlockedUserNotProvided),
+		errors.Is(err, chatdomain.ErrUserCannotBlockItself):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return toStatusError(err)
+	}
+}
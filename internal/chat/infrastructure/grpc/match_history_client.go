@@ -0,0 +1,88 @@
+package chatgrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+var _ chatdomain.MatchHistory = (*MatchHistoryClient)(nil)
+
+// MatchHistoryClient implements chatdomain.MatchHistory on top of the matchmaking gRPC API.
+type MatchHistoryClient struct {
+	client matchpb.MatchMakingServiceClient
+}
+
+// NewMatchHistoryClient creates a MatchHistoryClient using the given matchmaking connection.
+func NewMatchHistoryClient(conn grpc.ClientConnInterface) *MatchHistoryClient {
+	return &MatchHistoryClient{
+		client: matchpb.NewMatchMakingServiceClient(conn),
+	}
+}
+
+// ListMatchesByUser implements chatdomain.MatchHistory.
+func (c *MatchHistoryClient) ListMatchesByUser(
+	ctx context.Context,
+	userID chatdomain.ID,
+	cursor string,
+	limit int,
+) (chatdomain.MatchHistoryPage, error) {
+	res, err := c.client.ListUserMatches(ctx, &matchpb.ListUserMatchesRequest{
+		UserId: userID.String(),
+		Cursor: cursor,
+		Limit:  int32(min(max(limit, 0), math.MaxInt32)),
+	})
+	if err != nil {
+		return chatdomain.MatchHistoryPage{}, fromStatusError(err)
+	}
+
+	page := chatdomain.MatchHistoryPage{
+		Entries:    make([]chatdomain.MatchHistoryEntry, 0, len(res.GetMatches())),
+		NextCursor: res.GetNextCursor(),
+	}
+	for _, match := range res.GetMatches() {
+		page.Entries = append(page.Entries, toMatchHistoryEntry(match))
+	}
+	return page, nil
+}
+
+// GetLastMatch implements chatdomain.MatchHistory.
+func (c *MatchHistoryClient) GetLastMatch(ctx context.Context, userID chatdomain.ID) (chatdomain.MatchHistoryEntry, error) {
+	res, err := c.client.GetLastMatch(ctx, &matchpb.GetLastMatchRequest{UserId: userID.String()})
+	if err != nil {
+		return chatdomain.MatchHistoryEntry{}, fromStatusError(err)
+	}
+	return toMatchHistoryEntry(res.GetMatch()), nil
+}
+
+func toMatchHistoryEntry(match *matchpb.UserMatch) chatdomain.MatchHistoryEntry {
+	entry := chatdomain.MatchHistoryEntry{
+		MatchID:   chatdomain.ID(match.GetMatchId()),
+		PartnerID: chatdomain.ID(match.GetPartnerId()),
+		CreatedAt: match.GetCreatedAt().AsTime(),
+	}
+	for _, memberID := range match.GetMemberIds() {
+		entry.MemberIDs = append(entry.MemberIDs, chatdomain.ID(memberID))
+	}
+	return entry
+}
+
+// fromStatusError maps the errors of the matchmaking API to the chat domain errors.
+func fromStatusError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return chatdomain.ErrMatchNotFound
+	case codes.InvalidArgument:
+		// the user is checked before calling the API, only the cursor can be invalid
+		return fmt.Errorf("%w: %s", chatdomain.ErrInvalidMatchHistoryCursor, status.Convert(err).Message())
+	default:
+		return fmt.Errorf("matchmaking api: %w", err)
+	}
+}
@@ -0,0 +1,110 @@
+package chatgrpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+// UserIDMetadataKey is the gRPC metadata key, and HTTP header of the gateway,
+// carrying the ID of the user calling the API.
+const UserIDMetadataKey = "x-randomtalk-user-id"
+
+// NewGRPCServer creates a gRPC server with the MessageStreamService and BlockListService registered.
+func NewGRPCServer(
+	srv chatpbv1.MessageStreamServiceServer,
+	blockListSrv chatpbv1.BlockListServiceServer,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(userIDUnaryInterceptor),
+		grpc.ChainStreamInterceptor(userIDStreamInterceptor),
+	)
+
+	grpcServer := grpc.NewServer(opts...)
+	chatpbv1.RegisterMessageStreamServiceServer(grpcServer, srv)
+	chatpbv1.RegisterBlockListServiceServer(grpcServer, blockListSrv)
+	return grpcServer
+}
+
+// NewGatewayServer creates an HTTP server exposing the MessageStreamService and BlockListService
+// through their grpc-gateway bindings, proxying the requests to the gRPC server
+// listening on grpcAddr so that streaming calls are supported as well.
+func NewGatewayServer(ctx context.Context, addr, grpcAddr string) (*http.Server, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.EqualFold(key, UserIDMetadataKey) {
+				return UserIDMetadataKey, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+	)
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	if err := chatpbv1.RegisterMessageStreamServiceHandlerFromEndpoint(ctx, mux, grpcAddr, dialOpts); err != nil {
+		return nil, err
+	}
+
+	if err := chatpbv1.RegisterBlockListServiceHandlerFromEndpoint(ctx, mux, grpcAddr, dialOpts); err != nil {
+		return nil, err
+	}
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
+
+func userIDUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(contextWithUserID(ctx), req)
+}
+
+func userIDStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &userIDServerStream{ServerStream: ss, ctx: contextWithUserID(ss.Context())})
+}
+
+// contextWithUserID stores the user ID found in the incoming metadata in the auth session.
+func contextWithUserID(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	values := md.Get(UserIDMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx
+	}
+	return auth.ContextWithUserID(ctx, values[0])
+}
+
+type userIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userIDServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,177 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/xfrr/go-cqrsify/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+const receiveMessagesBufferSize = 64
+
+var _ chatpbv1.MessageStreamServiceServer = (*MessageStreamServer)(nil)
+
+// MessageSubscriber subscribes to the messages sent to a user.
+type MessageSubscriber interface {
+	SubscribeUser(ctx context.Context, userID string, handler func(ctx context.Context, msg *chatpbv1.UserMessage)) error
+}
+
+// MessageStreamServer implements the MessageStreamService gRPC API
+// sharing the rooms and message pipeline used by the WebSocket hub.
+type MessageStreamServer struct {
+	chatpbv1.UnimplementedMessageStreamServiceServer
+
+	cmdbus            chatcommands.CommandBus
+	roomRepository    chatdomain.RoomRepository
+	messageSubscriber MessageSubscriber
+	logger            *zerolog.Logger
+}
+
+// ServerOption defines a functional option to configure the MessageStreamServer.
+type ServerOption func(*MessageStreamServer)
+
+// WithLogger overrides the default zerolog.Logger.
+func WithLogger(logger *zerolog.Logger) ServerOption {
+	return func(s *MessageStreamServer) {
+		s.logger = logger
+	}
+}
+
+// NewMessageStreamServer initializes a new MessageStreamServer.
+func NewMessageStreamServer(
+	cmdbus chatcommands.CommandBus,
+	roomRepository chatdomain.RoomRepository,
+	messageSubscriber MessageSubscriber,
+	opts ...ServerOption,
+) *MessageStreamServer {
+	srv := &MessageStreamServer{
+		cmdbus:            cmdbus,
+		roomRepository:    roomRepository,
+		messageSubscriber: messageSubscriber,
+		logger:            &zerolog.Logger{},
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
+}
+
+// ReceiveMessages streams the messages sent to the room until the client goes away.
+// Only the participants of the room can receive its messages.
+func (s *MessageStreamServer) ReceiveMessages(
+	req *chatpbv1.ReceiveMessagesRequest,
+	stream chatpbv1.MessageStreamService_ReceiveMessagesServer,
+) error {
+	ctx := stream.Context()
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, chatcommands.ErrMissingUserIDFromContext.Error())
+	}
+
+	if req.GetRoomId() == "" {
+		return status.Error(codes.InvalidArgument, "room_id is required")
+	}
+
+	room, err := s.roomRepository.FindByID(ctx, chatdomain.ID(req.GetRoomId()))
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	if !room.HasParticipant(chatdomain.ID(userID)) {
+		return toStatusError(chatdomain.ErrUserNotInRoom)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages := make(chan *chatpbv1.UserMessage, receiveMessagesBufferSize)
+	subErr := make(chan error, 1)
+	go func() {
+		subErr <- s.messageSubscriber.SubscribeUser(ctx, userID, func(_ context.Context, msg *chatpbv1.UserMessage) {
+			if msg.GetRoomId() != req.GetRoomId() {
+				return
+			}
+
+			select {
+			case messages <- msg:
+			default:
+				s.logger.Warn().
+					Str("user_id", userID).
+					Str("room_id", req.GetRoomId()).
+					Msg("message stream is full, dropping message")
+			}
+		})
+	}()
+
+	for {
+		select {
+		case msg := <-messages:
+			if err := stream.Send(&chatpbv1.ReceiveMessagesResponse{Message: msg}); err != nil {
+				return err
+			}
+		case err := <-subErr:
+			if err != nil {
+				return toStatusError(err)
+			}
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// SendMessage sends a text message to the room on behalf of the authenticated user.
+func (s *MessageStreamServer) SendMessage(ctx context.Context, req *chatpbv1.SendMessageRequest) (*chatpbv1.SendMessageResponse, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, chatcommands.ErrMissingUserIDFromContext.Error())
+	}
+
+	if req.GetRoomId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "room_id is required")
+	}
+
+	cmd := chatcommands.SendMessageCommand{
+		BaseCommand: messaging.NewBaseCommand(chatcommands.SendMessageCommandType),
+		RoomID:      req.GetRoomId(),
+		Text:        req.GetMessage().GetPayload().GetText(),
+	}
+
+	if err := messaging.DispatchCommand(ctx, s.cmdbus, cmd); err != nil {
+		s.logger.Error().Err(err).
+			Str("user_id", userID).
+			Str("room_id", req.GetRoomId()).
+			Msg("failed to send message")
+		return nil, toStatusError(err)
+	}
+
+	return &chatpbv1.SendMessageResponse{}, nil
+}
+
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, chatdomain.ErrRoomNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, chatdomain.ErrUserNotInRoom):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, chatdomain.ErrEmptyMessage),
+		errors.Is(err, chatdomain.ErrMessageTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, chatcommands.ErrMissingUserIDFromContext):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
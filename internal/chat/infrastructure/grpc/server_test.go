@@ -0,0 +1,152 @@
+package chatgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/xfrr/go-cqrsify/messaging"
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatqueries "github.com/xfrr/randomtalk/internal/chat/application/queries"
+	chatgrpc "github.com/xfrr/randomtalk/internal/chat/infrastructure/grpc"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+func newTestClient(t *testing.T) chatpbv1.MessageStreamServiceClient {
+	t.Helper()
+	ctx := context.Background()
+
+	roomRepo := chatinmemory.NewRoomRepository()
+	blockListRepo := chatinmemory.NewBlockListRepository()
+	broker := chatinmemory.NewMessageBroker()
+
+	cmdbus, closer, err := chatcommands.InitCommandBus(
+		ctx,
+		chatinmemory.NewChatSessionRepository(),
+		roomRepo,
+		blockListRepo,
+		nil,
+		broker,
+		nil,
+		zerolog.Nop(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+
+	qrybus, qryCloser, err := chatqueries.InitQueryBus(ctx, blockListRepo, nil, zerolog.Nop())
+	require.NoError(t, err)
+	t.Cleanup(qryCloser)
+
+	require.NoError(t, messaging.DispatchCommand(ctx, cmdbus, chatcommands.NewCreateRoomCommand("room-1", "alice", "bob")))
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := chatgrpc.NewGRPCServer(
+		chatgrpc.NewMessageStreamServer(cmdbus, roomRepo, broker),
+		chatgrpc.NewBlockListServer(cmdbus, qrybus),
+	)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return chatpbv1.NewMessageStreamServiceClient(conn)
+}
+
+func asUser(ctx context.Context, userID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, chatgrpc.UserIDMetadataKey, userID)
+}
+
+func textMessage(text string) *chatpbv1.UserMessage {
+	return &chatpbv1.UserMessage{
+		MessageType: chatpbv1.UserMessage_TYPE_TEXT,
+		Payload: &chatpbv1.UserMessage_Payload{
+			Content: &chatpbv1.UserMessage_Payload_Text{Text: text},
+		},
+	}
+}
+
+func TestMessageStreamServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("send message requires an authenticated user", func(t *testing.T) {
+		client := newTestClient(t)
+
+		_, err := client.SendMessage(ctx, &chatpbv1.SendMessageRequest{RoomId: "room-1", Message: textMessage("hi")})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("send message rejects users outside the room", func(t *testing.T) {
+		client := newTestClient(t)
+
+		_, err := client.SendMessage(asUser(ctx, "carol"), &chatpbv1.SendMessageRequest{RoomId: "room-1", Message: textMessage("hi")})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("send message rejects empty messages", func(t *testing.T) {
+		client := newTestClient(t)
+
+		_, err := client.SendMessage(asUser(ctx, "alice"), &chatpbv1.SendMessageRequest{RoomId: "room-1", Message: textMessage(" ")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("receive messages returns not found for unknown rooms", func(t *testing.T) {
+		client := newTestClient(t)
+
+		stream, err := client.ReceiveMessages(asUser(ctx, "alice"), &chatpbv1.ReceiveMessagesRequest{RoomId: "unknown"})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("receive messages streams the messages sent to the room", func(t *testing.T) {
+		client := newTestClient(t)
+
+		streamCtx, cancel := context.WithTimeout(asUser(ctx, "bob"), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.ReceiveMessages(streamCtx, &chatpbv1.ReceiveMessagesRequest{RoomId: "room-1"})
+		require.NoError(t, err)
+
+		// the subscription is registered asynchronously, keep sending until it is received
+		received := make(chan *chatpbv1.ReceiveMessagesResponse, 1)
+		go func() {
+			res, recvErr := stream.Recv()
+			if recvErr == nil {
+				received <- res
+			}
+		}()
+
+		require.Eventually(t, func() bool {
+			_, sendErr := client.SendMessage(asUser(ctx, "alice"), &chatpbv1.SendMessageRequest{
+				RoomId:  "room-1",
+				Message: textMessage("hello bob"),
+			})
+			require.NoError(t, sendErr)
+			return len(received) > 0
+		}, 2*time.Second, 20*time.Millisecond)
+
+		res := <-received
+		assert.Equal(t, "alice", res.GetMessage().GetUserId())
+		assert.Equal(t, "room-1", res.GetMessage().GetRoomId())
+		assert.Equal(t, "hello bob", res.GetMessage().GetPayload().GetText())
+		assert.NotEmpty(t, res.GetMessage().GetMessageId())
+	})
+}
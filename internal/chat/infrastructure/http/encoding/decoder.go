@@ -14,7 +14,34 @@ import (
 var (
 	decoders = map[string]map[string]Decoder[any]{
 		chatcommands.CreateChatSessionCommandType: {
-			ApplicationJSON: AnyDecoder(NewJSONDecoder[chatcommands.CreateChatSessionCommand]()),
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.CreateChatSessionCommand](chatcommands.CreateChatSessionCommandType)),
+		},
+		chatcommands.SendMessageCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.SendMessageCommand](chatcommands.SendMessageCommandType)),
+		},
+		chatcommands.LeaveChatSessionCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.LeaveChatSessionCommand](chatcommands.LeaveChatSessionCommandType)),
+		},
+		chatcommands.CancelMatchRequestCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.CancelMatchRequestCommand](chatcommands.CancelMatchRequestCommandType)),
+		},
+		chatcommands.SkipPartnerCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.SkipPartnerCommand](chatcommands.SkipPartnerCommandType)),
+		},
+		chatcommands.AcceptMatchCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.AcceptMatchCommand](chatcommands.AcceptMatchCommandType)),
+		},
+		chatcommands.DeclineMatchCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.DeclineMatchCommand](chatcommands.DeclineMatchCommandType)),
+		},
+		chatcommands.BlockUserCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.BlockUserCommand](chatcommands.BlockUserCommandType)),
+		},
+		chatcommands.UnblockUserCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.UnblockUserCommand](chatcommands.UnblockUserCommandType)),
+		},
+		chatcommands.RatePartnerCommandType: {
+			ApplicationJSON: AnyDecoder(NewJSONCommandDecoder[chatcommands.RatePartnerCommand](chatcommands.RatePartnerCommandType)),
 		},
 	}
 )
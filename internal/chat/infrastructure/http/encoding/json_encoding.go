@@ -2,7 +2,11 @@ package httpencoding
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"reflect"
+
+	"github.com/xfrr/go-cqrsify/messaging"
 )
 
 // ApplicationJSON is the application/json MIME type.
@@ -35,3 +39,30 @@ func (jd *JSONDecoder[T]) Decode(r io.Reader) (T, error) {
 	err := json.NewDecoder(r).Decode(&result)
 	return result, err
 }
+
+// JSONCommandDecoder is a JSON Decoder for commands of type T.
+// It initializes the embedded messaging.BaseCommand with the command type,
+// so the decoded command can be routed by the command bus.
+type JSONCommandDecoder[T messaging.Command] struct {
+	commandType string
+}
+
+// NewJSONCommandDecoder instantiates a new JSONCommandDecoder for the given command type.
+func NewJSONCommandDecoder[T messaging.Command](commandType string) *JSONCommandDecoder[T] {
+	return &JSONCommandDecoder[T]{commandType: commandType}
+}
+
+// Decode implements the Decoder[T] interface using JSON.
+func (jd *JSONCommandDecoder[T]) Decode(r io.Reader) (T, error) {
+	var result T
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return result, err
+	}
+
+	baseCommand := reflect.ValueOf(&result).Elem().FieldByName("BaseCommand")
+	if !baseCommand.IsValid() || !baseCommand.CanSet() {
+		return result, fmt.Errorf("command %T does not embed messaging.BaseCommand", result)
+	}
+	baseCommand.Set(reflect.ValueOf(messaging.NewBaseCommand(jd.commandType)))
+	return result, nil
+}
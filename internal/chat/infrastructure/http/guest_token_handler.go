@@ -0,0 +1,46 @@
+package chathttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+)
+
+type guestTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	UserID      string `json:"user_id"`
+}
+
+// NewGuestTokenHandler returns the handler issuing guest tokens to anonymous users.
+// The token is returned in the response body, so the client chooses how to send it when connecting.
+func NewGuestTokenHandler(issuer *auth.GuestTokenIssuer, logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, session, err := issuer.Issue(r.Context())
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to issue guest token")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(guestTokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(time.Until(session.ExpiresAt).Seconds()),
+			UserID:      session.UserID,
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/xfrr/go-cqrsify/messaging"
 	chatconfig "github.com/xfrr/randomtalk/internal/chat/config"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
 	chatpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,9 +16,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func ServeHTTP(cfg chatconfig.HubWebsocketServer, handler http.HandlerFunc) error {
-	http.Handle(cfg.Path, handler)
-
+func ServeHTTP(cfg chatconfig.HubWebsocketServer, handler http.Handler) error {
 	lis, err := net.Listen("tcp", cfg.Address)
 	if err != nil {
 		return err
@@ -28,7 +27,7 @@ func ServeHTTP(cfg chatconfig.HubWebsocketServer, handler http.HandlerFunc) erro
 		ReadTimeout:    time.Duration(cfg.ReadTimeoutSeconds) * time.Second,
 		WriteTimeout:   time.Duration(cfg.WriteTimeoutSeconds) * time.Second,
 		IdleTimeout:    time.Duration(cfg.IdleTimeoutSeconds) * time.Second,
-		MaxHeaderBytes: 1 << 10, // 1 KB
+		MaxHeaderBytes: 1 << 13, // 8 KB, access tokens are sent in the headers
 	}
 
 	return server.Serve(lis)
@@ -61,6 +60,15 @@ func formatErrorResponse(err error) (uint32, string) {
 	case errors.Is(err, messaging.ErrHandlerNotFound):
 		code = http.StatusNotFound
 		message = "Command not found: " + err.Error()
+	case errors.Is(err, chatdomain.ErrRoomNotFound):
+		code = http.StatusNotFound
+		message = err.Error()
+	case errors.Is(err, chatdomain.ErrUserNotInRoom):
+		code = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, chatdomain.ErrEmptyMessage), errors.Is(err, chatdomain.ErrMessageTooLong):
+		code = http.StatusBadRequest
+		message = err.Error()
 	default:
 		code = http.StatusInternalServerError
 		message = "Internal server error: " + err.Error()
@@ -2,33 +2,58 @@ package chathttp
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/xfrr/go-cqrsify/messaging"
 	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 	"github.com/xfrr/randomtalk/internal/shared/semantic"
 
 	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
 	chatqueries "github.com/xfrr/randomtalk/internal/chat/application/queries"
 	chatconfig "github.com/xfrr/randomtalk/internal/chat/config"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
 	httpencoding "github.com/xfrr/randomtalk/internal/chat/infrastructure/http/encoding"
 	imsg "github.com/xfrr/randomtalk/internal/shared/messaging"
 	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
 )
 
+// The types of the matchmaking notifications handled besides the created matches.
+// The queue statuses come from their own consumer, see WithQueueStatusConsumer.
+const (
+	// noMatchFoundNotificationType is sent when a user wait deadline passes without a match.
+	noMatchFoundNotificationType = "no_match_found"
+	// matchAcceptedNotificationType is sent when an user accepts a proposed match.
+	matchAcceptedNotificationType = "match_accepted"
+	// matchConfirmedNotificationType is sent when both users accepted a proposed match.
+	matchConfirmedNotificationType = "match_confirmed"
+	// matchCancelledNotificationType is sent when a proposed match is declined or expires.
+	matchCancelledNotificationType = "match_cancelled"
+	// queueStatusNotificationType is sent periodically to the users waiting for a match.
+	queueStatusNotificationType = "queue_status"
+)
+
 // NotificationConsumer is a component that consumes notifications.
 type NotificationConsumer interface {
 	Consume(ctx context.Context, notificationHandler func(ctx context.Context, notification *imsg.Event)) error
 }
 
+// MessageSubscriber is a component that receives the messages and notifications sent to the users.
+type MessageSubscriber interface {
+	Subscribe(ctx context.Context, handler func(ctx context.Context, recipientID string, msg *chatpbv1.UserMessage)) error
+	SubscribeNotifications(ctx context.Context, handler func(ctx context.Context, recipientID string, notification *chatpbv1.NotificationMessage)) error
+}
+
 // WebSocket Upgrader with proper settings
 var upgrader = &websocket.Upgrader{
 	CheckOrigin:     func(r *http.Request) bool { return true }, // Allow all origins; customize for security
@@ -38,16 +63,34 @@ var upgrader = &websocket.Upgrader{
 
 // Client represents a WebSocket connection
 type Client struct {
-	id   string
-	conn *websocket.Conn
-	send chan []byte // Buffered channel for outbound messages
-	hub  *Hub
+	id       string
+	session  auth.SessionContext
+	delivery *clientSession
+	conn     *websocket.Conn
+	send     chan []byte // Buffered channel for outbound messages
+	hub      *Hub
+}
+
+// trySend queues the message without blocking. A client that does not keep up is disconnected,
+// it can resume its session to get the messages it missed.
+func (c *Client) trySend(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+		c.disconnect()
+	}
+}
+
+// disconnect closes the connection, so the read pump unregisters the client.
+func (c *Client) disconnect() {
+	_ = c.conn.Close()
 }
 
 // Hub maintains active connections
 type Hub struct {
 	cfg                   *chatconfig.HubWebsocketServer
 	clients               map[*Client]bool
+	sessions              map[string]*clientSession
 	broadcast             chan []byte
 	register              chan *Client
 	unregister            chan *Client
@@ -55,6 +98,9 @@ type Hub struct {
 	cmdBus                chatcommands.CommandBus
 	queryBus              chatqueries.QueryBus
 	notificationsConsumer NotificationConsumer
+	queueStatusConsumer   NotificationConsumer
+	messageSubscriber     MessageSubscriber
+	authenticator         auth.Authenticator
 	logger                zerolog.Logger
 }
 
@@ -67,6 +113,29 @@ func WithLogger(logger zerolog.Logger) HubOption {
 	}
 }
 
+// WithMessageSubscriber sets the subscriber used to deliver user messages to the connected clients.
+func WithMessageSubscriber(subscriber MessageSubscriber) HubOption {
+	return func(h *Hub) {
+		h.messageSubscriber = subscriber
+	}
+}
+
+// WithQueueStatusConsumer sets the consumer of the queue statuses sent to the waiting users.
+// Without it the users are not told their progress while waiting.
+func WithQueueStatusConsumer(consumer NotificationConsumer) HubOption {
+	return func(h *Hub) {
+		h.queueStatusConsumer = consumer
+	}
+}
+
+// WithAuthenticator sets the authenticator used to verify the access token of the connections.
+// Without authenticator every connection is rejected.
+func WithAuthenticator(authenticator auth.Authenticator) HubOption {
+	return func(h *Hub) {
+		h.authenticator = authenticator
+	}
+}
+
 // WithConfig sets the configuration for the hub instance.
 func WithConfig(cfg *chatconfig.HubWebsocketServer) HubOption {
 	return func(h *Hub) {
@@ -81,6 +150,7 @@ func NewHub(cmdBus chatcommands.CommandBus, queryBus chatqueries.QueryBus, notif
 		register:              make(chan *Client),
 		unregister:            make(chan *Client),
 		clients:               make(map[*Client]bool),
+		sessions:              make(map[string]*clientSession),
 		cmdBus:                cmdBus,
 		queryBus:              queryBus,
 		logger:                zerolog.Nop(), // Avoid nil logger
@@ -112,6 +182,12 @@ func NewHub(cmdBus chatcommands.CommandBus, queryBus chatqueries.QueryBus, notif
 			// deployments, 1000 might be too low. Depending on your hardware and load tests,
 			// you might allow 10k+ or handle scaling horizontally.
 			MaxConnections: 10000,
+			// Mobile clients drop connections constantly, give them some time to come back.
+			ResumeGracePeriodSeconds: 30,
+			ResumeBufferSize:         256,
+			TokenQueryParam:          "access_token",
+			TokenCookieName:          "randomtalk_access_token",
+			GuestTokenPath:           "/guest-tokens",
 		},
 	}
 
@@ -127,6 +203,13 @@ func NewHub(cmdBus chatcommands.CommandBus, queryBus chatqueries.QueryBus, notif
 // Run starts the hub to manage clients
 func (h *Hub) Run(ctx context.Context) {
 	go h.startNotificationsConsumer(ctx)
+	if h.queueStatusConsumer != nil {
+		go h.startQueueStatusConsumer(ctx)
+	}
+	if h.messageSubscriber != nil {
+		go h.startMessageSubscriber(ctx)
+		go h.startUserNotificationSubscriber(ctx)
+	}
 
 	for {
 		select {
@@ -152,12 +235,24 @@ func (h *Hub) addClient(client *Client) {
 		Msg("client registered")
 }
 
+func (h *Hub) clientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
 func (h *Hub) removeClient(client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	if _, exists := h.clients[client]; exists {
 		delete(h.clients, client)
+		if client.delivery != nil {
+			session := client.delivery
+			session.detach(client, time.Duration(h.cfg.ResumeGracePeriodSeconds)*time.Second, func() {
+				h.expireSession(session)
+			})
+		}
 		close(client.send)
 		h.logger.Debug().
 			Str("client.address", client.conn.RemoteAddr().String()).
@@ -166,19 +261,74 @@ func (h *Hub) removeClient(client *Client) {
 	}
 }
 
-// TODO: create an index for userID to client
-// getClientByUserID retrieves a client by user ID
-func (h *Hub) getClientByUserID(userID string) *Client {
+// openSession attaches the client to the session of its user. The session is resumed
+// when the resume token matches, otherwise a new one replaces it.
+func (h *Hub) openSession(client *Client, resumeToken string, lastSequence uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	session, exists := h.sessions[client.id]
+	resumed := exists && session.canResume(resumeToken)
+	if !resumed {
+		if exists {
+			session.close()
+		}
+		session = newClientSession(client.id, h.cfg.ResumeBufferSize)
+		h.sessions[client.id] = session
+	}
+
+	client.delivery = session
+	session.attach(client, resumed, lastSequence)
+
+	h.logger.Debug().
+		Str("client.id", client.id).
+		Bool("resumed", resumed).
+		Msg("client session opened")
+}
+
+// expireSession removes the session when its user did not come back in time,
+// stops looking for a match for the user and expires its chat session.
+// Sessions resumed or replaced within the grace period are left untouched.
+func (h *Hub) expireSession(session *clientSession) {
+	h.mu.Lock()
+	expired := h.sessions[session.userID] == session && session.isDetached()
+	if expired {
+		delete(h.sessions, session.userID)
+	}
+	h.mu.Unlock()
+
+	if !expired {
+		return
+	}
+
+	h.logger.Debug().Str("client.id", session.userID).Msg("client session expired")
+
+	ctx := auth.ContextWithSession(context.Background(), session.authSession())
+	if err := messaging.DispatchCommand(ctx, h.cmdBus, chatcommands.NewCancelMatchRequestCommand()); err != nil {
+		h.logger.Error().Err(err).Str("client.id", session.userID).Msg("failed to cancel match request")
+	}
+	if err := messaging.DispatchCommand(ctx, h.cmdBus, chatcommands.NewExpireChatSessionCommand()); err != nil {
+		h.logger.Error().Err(err).Str("client.id", session.userID).Msg("failed to expire chat session")
+	}
+}
+
+// getSession retrieves the session of a user connected to this instance, or waiting to reconnect.
+func (h *Hub) getSession(userID string) *clientSession {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
+	return h.sessions[userID]
+}
 
-	for client := range h.clients {
-		if client.id == userID {
-			return client
-		}
+// deliver sends the message to the user, or keeps it until the user reconnects.
+// It reports whether the user has a session in this instance.
+func (h *Hub) deliver(userID string, msg *chatpbv1.ServerMessage) bool {
+	session := h.getSession(userID)
+	if session == nil {
+		return false
 	}
 
-	return nil
+	session.deliver(msg)
+	return true
 }
 
 func (h *Hub) broadcastMessage(msg []byte) {
@@ -196,97 +346,330 @@ func (h *Hub) broadcastMessage(msg []byte) {
 
 func (h *Hub) startNotificationsConsumer(ctx context.Context) {
 	err := h.notificationsConsumer.Consume(ctx, func(ctx context.Context, notification *imsg.Event) {
-		h.logger.Debug().Msg("received notification, sending to clients")
-
-		var dataMap map[string]any
-		if err := notification.DataAs(&dataMap); err != nil {
-			h.logger.Error().Err(err).Msg("failed to decode notification data")
-			notification.Reject()
-			return
+		h.logger.Debug().Str("type", notification.Type()).Msg("received notification, sending to clients")
+
+		switch notification.Type() {
+		case noMatchFoundNotificationType:
+			h.handleNoMatchFoundNotification(ctx, notification)
+		case matchAcceptedNotificationType:
+			// the users are only told when the match is confirmed or cancelled
+			notification.Ack()
+		case matchCancelledNotificationType:
+			h.handleMatchCancelledNotification(ctx, notification)
+		case matchConfirmedNotificationType:
+			h.handleMatchCreatedNotification(ctx, notification)
+		default:
+			h.handleMatchCreatedNotification(ctx, notification)
 		}
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to start notifications consumer")
+	}
+}
 
-		requesterUserID, ok := dataMap["match_user_requester_id"].(string)
-		if !ok {
-			h.logger.Error().Msg("failed to get requester user ID from notification")
-			notification.Reject()
+func (h *Hub) startQueueStatusConsumer(ctx context.Context) {
+	err := h.queueStatusConsumer.Consume(ctx, func(ctx context.Context, notification *imsg.Event) {
+		if notification.Type() != queueStatusNotificationType {
+			notification.Ack()
 			return
 		}
+		h.handleQueueStatusNotification(ctx, notification)
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to start queue status consumer")
+	}
+}
 
-		matchedUserID, ok := dataMap["match_user_matched_id"].(string)
-		if !ok {
-			h.logger.Error().Msg("failed to get matched user ID from notification")
-			notification.Reject()
-			return
-		}
+// handleNoMatchFoundNotification ends the chat session of a user the matchmaker
+// could not match before its wait deadline.
+func (h *Hub) handleNoMatchFoundNotification(ctx context.Context, notification *imsg.Event) {
+	var data struct {
+		UserID        string `json:"user_id"`
+		WaitedSeconds int64  `json:"waited_seconds"`
+	}
+	if err := notification.DataAs(&data); err != nil || data.UserID == "" {
+		h.logger.Error().Err(err).Msg("failed to decode no match found notification")
+		notification.Reject()
+		return
+	}
 
-		// send notification to requester
-		firstUser := h.getClientByUserID(requesterUserID)
-		if firstUser == nil {
-			h.logger.Error().
-				Str("requester_user_id", requesterUserID).
-				Str("matched_user_id", matchedUserID).
-				Msg("requester user not found")
-			notification.Reject()
-			return
-		}
+	cmd := chatcommands.NewExpireMatchRequestCommand(time.Duration(data.WaitedSeconds) * time.Second)
+	if err := messaging.DispatchCommand(auth.ContextWithUserID(ctx, data.UserID), h.cmdBus, cmd); err != nil {
+		h.logger.Error().Err(err).Str("user_id", data.UserID).Msg("failed to expire match request")
+		notification.Nack()
+		return
+	}
+	notification.Ack()
+}
+
+// handleQueueStatusNotification tells a waiting user how many compatible users are waiting,
+// its position in the queue and its estimated wait.
+func (h *Hub) handleQueueStatusNotification(ctx context.Context, notification *imsg.Event) {
+	var data struct {
+		UserID               string `json:"user_id"`
+		CompatibleUsers      int    `json:"compatible_users"`
+		Position             int    `json:"position"`
+		EstimatedWaitSeconds int64  `json:"estimated_wait_seconds"`
+	}
+	if err := notification.DataAs(&data); err != nil || data.UserID == "" {
+		h.logger.Error().Err(err).Msg("failed to decode queue status notification")
+		notification.Reject()
+		return
+	}
+
+	cmd := chatcommands.NewUpdateQueueStatusCommand(chatdomain.QueueStatus{
+		CompatibleUsers: data.CompatibleUsers,
+		Position:        data.Position,
+		EstimatedWait:   time.Duration(data.EstimatedWaitSeconds) * time.Second,
+	})
+	if err := messaging.DispatchCommand(auth.ContextWithUserID(ctx, data.UserID), h.cmdBus, cmd); err != nil {
+		// a newer status follows shortly, there is no point in retrying
+		h.logger.Error().Err(err).Str("user_id", data.UserID).Msg("failed to update queue status")
+	}
+	notification.Ack()
+}
+
+// handleMatchCancelledNotification tells both users of a proposed match that it was cancelled.
+func (h *Hub) handleMatchCancelledNotification(ctx context.Context, notification *imsg.Event) {
+	var data struct {
+		MatchID         string   `json:"match_id"`
+		RequesterUserID string   `json:"match_user_requester_id"`
+		MatchedUserID   string   `json:"match_user_matched_id"`
+		Reason          string   `json:"reason"`
+		RequeuedUserIDs []string `json:"requeued_user_ids"`
+	}
+	if err := notification.DataAs(&data); err != nil || data.MatchID == "" {
+		h.logger.Error().Err(err).Msg("failed to decode match cancelled notification")
+		notification.Reject()
+		return
+	}
 
-		// send notification to matched user
-		secondUser := h.getClientByUserID(matchedUserID)
-		if secondUser == nil {
-			h.logger.Error().
-				Str("requester_user_id", requesterUserID).
-				Str("matched_user_id", matchedUserID).
-				Msg("matched user not found")
-			notification.Reject()
+	for _, userID := range []string{data.RequesterUserID, data.MatchedUserID} {
+		cmd := chatcommands.NewCancelMatchProposalCommand(data.MatchID, data.Reason, slices.Contains(data.RequeuedUserIDs, userID))
+		if err := messaging.DispatchCommand(auth.ContextWithUserID(ctx, userID), h.cmdBus, cmd); err != nil {
+			h.logger.Error().Err(err).Str("user_id", userID).Str("match_id", data.MatchID).Msg("failed to cancel match proposal")
+			notification.Nack()
 			return
 		}
+	}
+	notification.Ack()
+}
 
-		// Create a new notification payload
-		notificationDataMap := map[string]any{
-			"user_requester_id": requesterUserID,
-			"user_matched_id":   matchedUserID,
-		}
+// proposedMatchUser is a user of a proposed match, as sent by the matchmaker.
+type proposedMatchUser struct {
+	ID          string
+	Age         int32
+	Gender      gender.Gender
+	Preferences matchmaking.Preferences
+	Location    *location.Location
+	Languages   []string
+}
 
-		nprotoPayload, err := structpb.NewStruct(notificationDataMap)
-		if err != nil {
-			h.logger.Error().Err(err).Msg("failed to create struct from notification data")
-			notification.Reject()
+// partner returns the public profile of the user, as seen by the other user of the match.
+// The exact location of the user is never shared.
+func (u proposedMatchUser) partner() chatdomain.MatchPartner {
+	partner := chatdomain.MatchPartner{
+		UserID:    chatdomain.ID(u.ID),
+		Age:       u.Age,
+		Gender:    u.Gender,
+		Interests: u.Preferences.Interests,
+		Languages: u.Languages,
+	}
+	if u.Location != nil {
+		partner.CountryCode = u.Location.CountryCode
+		partner.CityCode = u.Location.CityCode
+	}
+	return partner
+}
+
+// handleMatchProposedNotification sends the proposed match to both users, along with
+// the public profile of their partner, so they can accept or decline it.
+func (h *Hub) handleMatchProposedNotification(ctx context.Context, notification *imsg.Event) {
+	var data struct {
+		MatchID   string    `json:"match_id"`
+		ExpiresAt time.Time `json:"proposal_expires_at"`
+
+		RequesterID          string                  `json:"match_user_requester_id"`
+		RequesterAge         int32                   `json:"match_user_requester_age"`
+		RequesterGender      gender.Gender           `json:"match_user_requester_gender"`
+		RequesterPreferences matchmaking.Preferences `json:"match_user_requester_preferences"`
+		RequesterLocation    *location.Location      `json:"match_user_requester_location"`
+		RequesterLanguages   []string                `json:"match_user_requester_languages"`
+
+		MatchedID          string                  `json:"match_user_matched_id"`
+		MatchedAge         int32                   `json:"match_user_matched_age"`
+		MatchedGender      gender.Gender           `json:"match_user_matched_gender"`
+		MatchedPreferences matchmaking.Preferences `json:"match_user_matched_preferences"`
+		MatchedLocation    *location.Location      `json:"match_user_matched_location"`
+		MatchedLanguages   []string                `json:"match_user_matched_languages"`
+	}
+	if err := notification.DataAs(&data); err != nil || data.MatchID == "" || data.RequesterID == "" || data.MatchedID == "" {
+		h.logger.Error().Err(err).Msg("failed to decode proposed match notification")
+		notification.Reject()
+		return
+	}
+
+	requester := proposedMatchUser{
+		ID:          data.RequesterID,
+		Age:         data.RequesterAge,
+		Gender:      data.RequesterGender,
+		Preferences: data.RequesterPreferences,
+		Location:    data.RequesterLocation,
+		Languages:   data.RequesterLanguages,
+	}
+	matched := proposedMatchUser{
+		ID:          data.MatchedID,
+		Age:         data.MatchedAge,
+		Gender:      data.MatchedGender,
+		Preferences: data.MatchedPreferences,
+		Location:    data.MatchedLocation,
+		Languages:   data.MatchedLanguages,
+	}
+
+	// each user is told from the instance it is connected to, which may not be this one
+	for _, pair := range [][2]proposedMatchUser{{requester, matched}, {matched, requester}} {
+		user, partner := pair[0], pair[1]
+		cmd := chatcommands.NewProposeMatchCommand(chatdomain.MatchProposal{
+			MatchID:   chatdomain.ID(data.MatchID),
+			ExpiresAt: data.ExpiresAt,
+			Partner:   partner.partner(),
+		})
+		if err := messaging.DispatchCommand(auth.ContextWithUserID(ctx, user.ID), h.cmdBus, cmd); err != nil {
+			h.logger.Error().Err(err).Str("user_id", user.ID).Str("match_id", data.MatchID).Msg("failed to propose match")
+			notification.Nack()
 			return
 		}
+	}
+	notification.Ack()
+}
 
-		nproto := &chatpbv1.ServerMessage{
-			Kind: chatpbv1.Kind_KIND_SYSTEM,
-			Data: &chatpbv1.ServerMessage_Notification{
-				Notification: &chatpbv1.NotificationMessage{
-					Type:    chatpbv1.NotificationMessage_TYPE_NEW_MATCH,
-					Payload: nprotoPayload,
-				},
-			},
+// handleMatchCreatedNotification opens the room of a new match.
+// Proposed matches are only opened once confirmed.
+func (h *Hub) handleMatchCreatedNotification(ctx context.Context, notification *imsg.Event) {
+	var dataMap map[string]any
+	if err := notification.DataAs(&dataMap); err != nil {
+		h.logger.Error().Err(err).Msg("failed to decode notification data")
+		notification.Reject()
+		return
+	}
+
+	if _, proposed := dataMap["proposal_expires_at"]; proposed {
+		h.handleMatchProposedNotification(ctx, notification)
+		return
+	}
+
+	requesterUserID, ok := dataMap["match_user_requester_id"].(string)
+	if !ok {
+		h.logger.Error().Msg("failed to get requester user ID from notification")
+		notification.Reject()
+		return
+	}
+
+	matchedUserID, ok := dataMap["match_user_matched_id"].(string)
+	if !ok {
+		h.logger.Error().Msg("failed to get matched user ID from notification")
+		notification.Reject()
+		return
+	}
+
+	matchID, ok := dataMap["match_id"].(string)
+	if !ok {
+		h.logger.Error().Msg("failed to get match ID from notification")
+		notification.Reject()
+		return
+	}
+
+	participantIDs, ok := groupParticipantIDs(dataMap, requesterUserID, matchedUserID)
+	if !ok {
+		h.logger.Error().Str("match_id", matchID).Msg("failed to get group members from notification")
+		notification.Reject()
+		return
+	}
+
+	// open the room where the users will talk, the users are told through the user notifier
+	// so every instance delivers the notification to the users connected to it
+	createRoomCmd := chatcommands.NewCreateRoomCommand(matchID, participantIDs...)
+	if err := messaging.DispatchCommand(ctx, h.cmdBus, createRoomCmd); err != nil {
+		h.logger.Error().Err(err).Str("match_id", matchID).Msg("failed to create room")
+		notification.Nack()
+		return
+	}
+
+	h.logger.Debug().Str("match_id", matchID).Msg("room created for match")
+	notification.Ack()
+}
+
+// groupParticipantIDs returns the IDs of the users of the match, adding the members
+// of a group room to the requester and the matched user.
+func groupParticipantIDs(dataMap map[string]any, requesterUserID, matchedUserID string) ([]string, bool) {
+	participantIDs := []string{requesterUserID, matchedUserID}
+
+	members, _ := dataMap["match_group_members"].([]any)
+	for _, member := range members {
+		fields, ok := member.(map[string]any)
+		if !ok {
+			return nil, false
 		}
 
-		data, err := protojson.Marshal(nproto)
-		if err != nil {
-			h.logger.Error().Err(err).Msg("failed to marshal notification")
-			notification.Reject()
-			return
+		userID, ok := fields["id"].(string)
+		if !ok || userID == "" {
+			return nil, false
 		}
+		participantIDs = append(participantIDs, userID)
+	}
+	return participantIDs, true
+}
 
-		// send notification to both users
-		firstUser.send <- data
-		secondUser.send <- data
+func (h *Hub) startMessageSubscriber(ctx context.Context) {
+	err := h.messageSubscriber.Subscribe(ctx, func(_ context.Context, recipientID string, msg *chatpbv1.UserMessage) {
+		// the recipient may be connected to another instance
+		h.deliver(recipientID, &chatpbv1.ServerMessage{
+			Kind: chatpbv1.Kind_KIND_USER,
+			Data: &chatpbv1.ServerMessage_Message{
+				Message: msg,
+			},
+		})
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to start message subscriber")
+	}
+}
 
-		h.logger.Debug().Msg("notification sent to users")
-		// Acknowledge the notification
-		notification.Ack()
+func (h *Hub) startUserNotificationSubscriber(ctx context.Context) {
+	err := h.messageSubscriber.SubscribeNotifications(ctx, func(_ context.Context, recipientID string, notification *chatpbv1.NotificationMessage) {
+		// the recipient may be connected to another instance
+		h.deliver(recipientID, &chatpbv1.ServerMessage{
+			Kind: chatpbv1.Kind_KIND_SYSTEM,
+			Data: &chatpbv1.ServerMessage_Notification{
+				Notification: notification,
+			},
+		})
 	})
 	if err != nil {
-		h.logger.Error().Err(err).Msg("failed to start notifications consumer")
+		h.logger.Error().Err(err).Msg("failed to start user notification subscriber")
 	}
 }
 
 // Handle manages incoming WebSocket connections.
+// Connections must carry an access token accepted by the hub authenticator.
 func (h *Hub) Handle(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	token, protocol := tokenFromRequest(r, h.cfg)
+	session, err := h.authenticate(r.Context(), token)
+	if err != nil {
+		h.logger.Debug().Err(err).
+			Str(semantic.ClientAddressKey, r.RemoteAddr).
+			Msg("connection rejected")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var responseHeader http.Header
+	if protocol != "" {
+		// browsers drop the connection unless one of the requested subprotocols is selected
+		responseHeader = http.Header{"Sec-WebSocket-Protocol": {protocol}}
+	}
+
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
 		h.logger.Error().Err(err).Msg("failed to upgrade connection")
 		return
@@ -298,7 +681,7 @@ func (h *Hub) Handle(w http.ResponseWriter, r *http.Request) {
 		contentType = "application/json"
 	}
 
-	if len(h.clients) >= h.cfg.MaxConnections {
+	if h.clientCount() >= h.cfg.MaxConnections {
 		_ = conn.WriteMessage(
 			websocket.CloseMessage,
 			websocket.FormatCloseMessage(websocket.CloseMessageTooBig, "server is full"))
@@ -307,20 +690,32 @@ func (h *Hub) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := &Client{
-		id:   uuid.New().String(),
-		conn: conn,
-		send: make(chan []byte, 256),
-		hub:  h,
+		id:      session.UserID,
+		session: session,
+		conn:    conn,
+		send:    make(chan []byte, 256),
+		hub:     h,
 	}
 
 	h.register <- client
 
+	lastSequence, _ := strconv.ParseUint(r.URL.Query().Get(LastSequenceParam), 10, 64)
+	h.openSession(client, r.URL.Query().Get(ResumeTokenParam), lastSequence)
+
 	// Start client read and write pumps
 	go client.readPump(contentType)
 	go client.writePump()
 }
 
-// readPump reads messages from a client
+func (h *Hub) authenticate(ctx context.Context, token string) (auth.SessionContext, error) {
+	if h.authenticator == nil {
+		return auth.SessionContext{}, errors.New("no authenticator configured")
+	}
+	return h.authenticator.Authenticate(ctx, token)
+}
+
+// readPump reads messages from a client.
+// Once the connection closes, the user keeps waiting for a match during the resume grace period.
 func (c *Client) readPump(contentType string) {
 	defer func() {
 		c.hub.unregister <- c
@@ -365,7 +760,7 @@ func (c *Client) readPump(contentType string) {
 			continue
 		}
 
-		ctx = auth.ContextWithUserID(ctx, c.id)
+		ctx = auth.ContextWithSession(ctx, c.session)
 		dispatchErr := messaging.DispatchCommand(ctx, c.hub.cmdBus, cmd)
 		if dispatchErr != nil {
 			logger.Error().Err(dispatchErr).Msg("failed to dispatch command")
@@ -412,14 +807,18 @@ func (c *Client) writePump() {
 			}
 
 			// Send queued messages in a single frame
+		queued:
 			for {
 				select {
-				case msg := <-c.send:
+				case msg, ok := <-c.send:
+					if !ok {
+						break queued
+					}
 					_, _ = w.Write([]byte("\n"))
 					_, _ = w.Write(msg)
 				default:
 					// Exit the loop when the channel is empty
-					break
+					break queued
 				}
 			}
 
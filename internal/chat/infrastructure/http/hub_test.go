@@ -0,0 +1,363 @@
+package chathttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/messaging"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
+	chatconfig "github.com/xfrr/randomtalk/internal/chat/config"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chathttp "github.com/xfrr/randomtalk/internal/chat/infrastructure/http"
+	imsg "github.com/xfrr/randomtalk/internal/shared/messaging"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+type idleNotificationConsumer struct{}
+
+func (idleNotificationConsumer) Consume(ctx context.Context, _ func(context.Context, *imsg.Event)) error {
+	<-ctx.Done()
+	return nil
+}
+
+// sessionRecorder records the session of the users sending a leave_chat_session command.
+type sessionRecorder chan auth.SessionContext
+
+func (r sessionRecorder) Handle(ctx context.Context, _ chatcommands.LeaveChatSessionCommand) error {
+	session, _ := auth.SessionFromContext(ctx)
+	r <- session
+	return nil
+}
+
+func TestHubAuthentication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sessions := make(sessionRecorder, 1)
+	cmdbus := messaging.NewInMemoryCommandBus()
+	_, err := messaging.SubscribeCommand(ctx, cmdbus, chatcommands.LeaveChatSessionCommandType, sessions)
+	require.NoError(t, err)
+
+	issuer := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Hour)
+	hub := chathttp.NewHub(cmdbus, nil, idleNotificationConsumer{}, chathttp.WithAuthenticator(issuer))
+	go hub.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", hub.Handle)
+	mux.Handle("/guest-tokens", chathttp.NewGuestTokenHandler(issuer, zerolog.Nop()))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	issueGuestToken := func(t *testing.T) (token, userID string) {
+		t.Helper()
+
+		res, err := http.Post(server.URL+"/guest-tokens", "application/json", nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var body struct {
+			AccessToken string `json:"access_token"`
+			UserID      string `json:"user_id"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		return body.AccessToken, body.UserID
+	}
+
+	requireSessionOf := func(t *testing.T, conn *websocket.Conn, userID string) {
+		t.Helper()
+
+		cmd := `{"kind":"command","data":{"type":"` + chatcommands.LeaveChatSessionCommandType + `","payload":{}}}`
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(cmd)))
+
+		select {
+		case session := <-sessions:
+			require.Equal(t, userID, session.UserID)
+			require.True(t, session.Guest)
+		case <-time.After(2 * time.Second):
+			t.Fatal("command not dispatched")
+		}
+	}
+
+	t.Run("should reject connections without a valid token", func(t *testing.T) {
+		for _, url := range []string{wsURL, wsURL + "?access_token=invalid"} {
+			_, res, err := websocket.DefaultDialer.Dial(url, nil)
+			require.Error(t, err)
+			require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	t.Run("should accept the token in the websocket protocol", func(t *testing.T) {
+		token, userID := issueGuestToken(t)
+
+		dialer := websocket.Dialer{Subprotocols: []string{chathttp.AccessTokenProtocol, token}}
+		conn, _, err := dialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		require.Equal(t, chathttp.AccessTokenProtocol, conn.Subprotocol())
+		requireSessionOf(t, conn, userID)
+	})
+
+	t.Run("should accept the token in the query", func(t *testing.T) {
+		token, userID := issueGuestToken(t)
+
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?access_token="+token, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		requireSessionOf(t, conn, userID)
+	})
+
+	t.Run("should accept the token in a cookie", func(t *testing.T) {
+		token, userID := issueGuestToken(t)
+
+		header := http.Header{"Cookie": {"randomtalk_access_token=" + token}}
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		requireSessionOf(t, conn, userID)
+	})
+}
+
+// messageFeed lets the tests push messages to the users through the hub message subscriber.
+type messageFeed chan func(ctx context.Context, recipientID string, msg *chatpbv1.UserMessage)
+
+func (f messageFeed) Subscribe(ctx context.Context, handler func(ctx context.Context, recipientID string, msg *chatpbv1.UserMessage)) error {
+	f <- handler
+	<-ctx.Done()
+	return nil
+}
+
+func (f messageFeed) SubscribeNotifications(ctx context.Context, _ func(context.Context, string, *chatpbv1.NotificationMessage)) error {
+	<-ctx.Done()
+	return nil
+}
+
+// expirationRecorder records the users whose chat session expired.
+type expirationRecorder chan string
+
+func (r expirationRecorder) Handle(ctx context.Context, _ chatcommands.ExpireChatSessionCommand) error {
+	userID, _ := auth.UserIDFromContext(ctx)
+	r <- userID
+	return nil
+}
+
+// newResumableHubConfig returns a hub configuration with a short resume grace period.
+func newResumableHubConfig() *chatconfig.HubWebsocketServer {
+	return &chatconfig.HubWebsocketServer{
+		ReadBufferSize:           1024,
+		WriteBufferSize:          1024,
+		ReadTimeoutSeconds:       10,
+		WriteTimeoutSeconds:      10,
+		PongWaitSeconds:          10,
+		PingPeriodSeconds:        9,
+		MaxMessageSizeBytes:      1024,
+		MaxConnections:           10,
+		ResumeGracePeriodSeconds: 1,
+		ResumeBufferSize:         8,
+		TokenQueryParam:          "access_token",
+	}
+}
+
+func TestHubSessionResumption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	expirations := make(expirationRecorder, 1)
+	cmdbus := messaging.NewInMemoryCommandBus()
+	_, err := messaging.SubscribeCommand(ctx, cmdbus, chatcommands.ExpireChatSessionCommandType, expirations)
+	require.NoError(t, err)
+
+	feed := make(messageFeed, 1)
+	issuer := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Hour)
+	hub := chathttp.NewHub(cmdbus, nil, idleNotificationConsumer{},
+		chathttp.WithAuthenticator(issuer),
+		chathttp.WithMessageSubscriber(feed),
+		chathttp.WithConfig(newResumableHubConfig()),
+	)
+	go hub.Run(ctx)
+	publish := <-feed
+
+	server := httptest.NewServer(http.HandlerFunc(hub.Handle))
+	t.Cleanup(server.Close)
+
+	token, session, err := issuer.Issue(ctx)
+	require.NoError(t, err)
+	userID := session.UserID
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?access_token=" + token
+
+	// the hub writes the queued messages in a single frame, one per line
+	pending := make(map[*websocket.Conn][]string)
+	readServerMessage := func(t *testing.T, conn *websocket.Conn) *chatpbv1.ServerMessage {
+		t.Helper()
+
+		if len(pending[conn]) == 0 {
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+			_, data, err := conn.ReadMessage()
+			require.NoError(t, err)
+			pending[conn] = strings.Split(string(data), "\n")
+		}
+
+		msg := &chatpbv1.ServerMessage{}
+		require.NoError(t, protojson.Unmarshal([]byte(pending[conn][0]), msg))
+		pending[conn] = pending[conn][1:]
+		return msg
+	}
+
+	sendText := func(text string) {
+		publish(ctx, userID, &chatpbv1.UserMessage{
+			UserId:  "partner",
+			Payload: &chatpbv1.UserMessage_Payload{Content: &chatpbv1.UserMessage_Payload_Text{Text: text}},
+		})
+	}
+
+	var resumeToken string
+
+	t.Run("should send the resume token on connect", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+
+		info := readServerMessage(t, conn).GetInfo()
+		require.Equal(t, chatpbv1.InfoMessage_TYPE_CONNECTION, info.GetType())
+		require.Equal(t, "connected", info.GetPayload().GetStatus())
+		require.Equal(t, userID, info.GetPayload().GetUserId())
+		require.NotEmpty(t, info.GetPayload().GetResumeToken())
+		resumeToken = info.GetPayload().GetResumeToken()
+
+		sendText("first")
+		msg := readServerMessage(t, conn)
+		require.Equal(t, uint64(1), msg.GetSequence())
+		require.Equal(t, "first", msg.GetMessage().GetPayload().GetText())
+
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("should replay the messages sent while disconnected", func(t *testing.T) {
+		sendText("second")
+		sendText("third")
+
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"&resume_token="+resumeToken+"&last_sequence=1", nil)
+		require.NoError(t, err)
+
+		info := readServerMessage(t, conn).GetInfo()
+		require.Equal(t, "resumed", info.GetPayload().GetStatus())
+		require.Equal(t, resumeToken, info.GetPayload().GetResumeToken())
+		require.Equal(t, uint64(3), info.GetPayload().GetLastSequence())
+
+		for i, text := range []string{"second", "third"} {
+			msg := readServerMessage(t, conn)
+			require.Equal(t, uint64(i+2), msg.GetSequence())
+			require.Equal(t, text, msg.GetMessage().GetPayload().GetText())
+		}
+
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("should expire the chat session after the grace period", func(t *testing.T) {
+		select {
+		case expiredUserID := <-expirations:
+			require.Equal(t, userID, expiredUserID)
+		case <-time.After(3 * time.Second):
+			t.Fatal("chat session not expired")
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"&resume_token="+resumeToken+"&last_sequence=3", nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		info := readServerMessage(t, conn).GetInfo()
+		require.Equal(t, "connected", info.GetPayload().GetStatus())
+		require.NotEqual(t, resumeToken, info.GetPayload().GetResumeToken())
+		require.Zero(t, info.GetPayload().GetLastSequence())
+	})
+}
+
+// cancellationRecorder records the users whose match request is cancelled.
+type cancellationRecorder chan string
+
+func (r cancellationRecorder) Handle(ctx context.Context, _ chatcommands.CancelMatchRequestCommand) error {
+	userID, _ := auth.UserIDFromContext(ctx)
+	r <- userID
+	return nil
+}
+
+func TestHubMatchRequestCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cancellations := make(cancellationRecorder, 1)
+	cmdbus := messaging.NewInMemoryCommandBus()
+	_, err := messaging.SubscribeCommand(ctx, cmdbus, chatcommands.CancelMatchRequestCommandType, cancellations)
+	require.NoError(t, err)
+	_, err = messaging.SubscribeCommand(ctx, cmdbus, chatcommands.ExpireChatSessionCommandType, make(expirationRecorder, 1))
+	require.NoError(t, err)
+
+	issuer := auth.NewGuestTokenIssuer("randomtalk-chat", []byte("guest-secret"), time.Hour)
+	hub := chathttp.NewHub(cmdbus, nil, idleNotificationConsumer{},
+		chathttp.WithAuthenticator(issuer),
+		chathttp.WithConfig(newResumableHubConfig()),
+	)
+	go hub.Run(ctx)
+
+	server := httptest.NewServer(http.HandlerFunc(hub.Handle))
+	t.Cleanup(server.Close)
+
+	token, session, err := issuer.Issue(ctx)
+	require.NoError(t, err)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?access_token=" + token
+
+	readResumeToken := func(t *testing.T, conn *websocket.Conn) string {
+		t.Helper()
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		_, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+
+		msg := &chatpbv1.ServerMessage{}
+		require.NoError(t, protojson.Unmarshal(data, msg))
+		return msg.GetInfo().GetPayload().GetResumeToken()
+	}
+
+	t.Run("should keep the user queued when it resumes within the grace period", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		resumeToken := readResumeToken(t, conn)
+		require.NoError(t, conn.Close())
+
+		conn, _, err = websocket.DefaultDialer.Dial(wsURL+"&resume_token="+resumeToken, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		require.Equal(t, resumeToken, readResumeToken(t, conn))
+
+		select {
+		case userID := <-cancellations:
+			t.Fatalf("match request of %s cancelled", userID)
+		case <-time.After(2 * time.Second):
+		}
+
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("should cancel the match request once the grace period expires", func(t *testing.T) {
+		select {
+		case userID := <-cancellations:
+			require.Equal(t, session.UserID, userID)
+		case <-time.After(3 * time.Second):
+			t.Fatal("match request not cancelled")
+		}
+	})
+}
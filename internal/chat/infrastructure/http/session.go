@@ -0,0 +1,186 @@
+package chathttp
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+const (
+	// ResumeTokenParam is the query parameter a reconnecting client sends its resume token in.
+	ResumeTokenParam = "resume_token"
+	// LastSequenceParam is the query parameter a reconnecting client sends
+	// the sequence of the last message it received in.
+	LastSequenceParam = "last_sequence"
+)
+
+// clientSession keeps the messages sent to a user across its connections,
+// so a client reconnecting within the grace period gets the messages it missed.
+// Sessions live in the memory of the instance the user is connected to, so resuming
+// needs the load balancer to route the user back to the same instance, e.g. by hashing
+// the user or a sticky cookie. A client reaching another instance starts a new session.
+type clientSession struct {
+	mu          sync.Mutex
+	userID      string
+	resumeToken string
+	sequence    uint64
+	// buffer holds the last messages sent, ordered by sequence.
+	buffer     [][]byte
+	bufferSeqs []uint64
+	bufferSize int
+	client     *Client
+	graceTimer *time.Timer
+	// auth is the session of the last client attached, used to act on behalf
+	// of the user once the grace period expires.
+	auth auth.SessionContext
+}
+
+func newClientSession(userID string, bufferSize int) *clientSession {
+	return &clientSession{
+		userID:      userID,
+		resumeToken: newResumeToken(),
+		bufferSize:  bufferSize,
+	}
+}
+
+// deliver assigns the next sequence to the message, keeps it for replay
+// and sends it to the attached client, if any.
+func (s *clientSession) deliver(msg *chatpbv1.ServerMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+	msg = proto.Clone(msg).(*chatpbv1.ServerMessage)
+	msg.Sequence = s.sequence
+
+	raw, err := protojson.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	s.buffer = append(s.buffer, raw)
+	s.bufferSeqs = append(s.bufferSeqs, s.sequence)
+	if len(s.buffer) > s.bufferSize {
+		s.buffer = s.buffer[1:]
+		s.bufferSeqs = s.bufferSeqs[1:]
+	}
+
+	if s.client != nil {
+		s.client.trySend(raw)
+	}
+}
+
+// attach binds the client to the session, sends it the connection info and
+// replays the messages after lastSequence. The client previously attached, if any, is disconnected.
+func (s *clientSession) attach(client *Client, resumed bool, lastSequence uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.graceTimer != nil {
+		s.graceTimer.Stop()
+		s.graceTimer = nil
+	}
+
+	if s.client != nil && s.client != client {
+		s.client.disconnect()
+	}
+	s.client = client
+	s.auth = client.session
+
+	status := "connected"
+	if resumed {
+		status = "resumed"
+	}
+
+	info, _ := protojson.Marshal(&chatpbv1.ServerMessage{
+		Kind: chatpbv1.Kind_KIND_SYSTEM,
+		Data: &chatpbv1.ServerMessage_Info{
+			Info: &chatpbv1.InfoMessage{
+				Type: chatpbv1.InfoMessage_TYPE_CONNECTION,
+				Payload: &chatpbv1.InfoMessage_Payload{
+					UserId:       s.userID,
+					Status:       status,
+					ResumeToken:  s.resumeToken,
+					LastSequence: s.sequence,
+				},
+				Timestamp: timestamppb.Now(),
+			},
+		},
+	})
+	client.trySend(info)
+
+	if !resumed {
+		return
+	}
+
+	for i, seq := range s.bufferSeqs {
+		if seq > lastSequence {
+			client.trySend(s.buffer[i])
+		}
+	}
+}
+
+// detach unbinds the client from the session and calls onExpire when no client
+// attaches within the grace period. It reports whether the client was attached.
+func (s *clientSession) detach(client *Client, grace time.Duration, onExpire func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != client {
+		return false
+	}
+
+	s.client = nil
+	s.graceTimer = time.AfterFunc(grace, onExpire)
+	return true
+}
+
+// close stops the grace period and disconnects the attached client, if any.
+func (s *clientSession) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.graceTimer != nil {
+		s.graceTimer.Stop()
+		s.graceTimer = nil
+	}
+
+	if s.client != nil {
+		s.client.disconnect()
+		s.client = nil
+	}
+}
+
+// isDetached reports whether no client is attached to the session.
+func (s *clientSession) isDetached() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client == nil
+}
+
+// authSession returns the session of the last client attached.
+func (s *clientSession) authSession() auth.SessionContext {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.auth
+}
+
+// canResume reports whether the given token resumes the session.
+func (s *clientSession) canResume(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.resumeToken)) == 1
+}
+
+func newResumeToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
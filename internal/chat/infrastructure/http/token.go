@@ -0,0 +1,51 @@
+package chathttp
+
+import (
+	"net/http"
+	"strings"
+
+	chatconfig "github.com/xfrr/randomtalk/internal/chat/config"
+)
+
+// AccessTokenProtocol is the WebSocket subprotocol announcing that the next
+// requested subprotocol is the access token, e.g. new WebSocket(url, ["access_token", token]).
+// Browsers cannot set the Authorization header on WebSocket connections.
+const AccessTokenProtocol = "access_token"
+
+// tokenFromRequest extracts the access token from the Sec-WebSocket-Protocol header,
+// the query string or a cookie, in that order.
+// It returns the subprotocol that must be echoed back to the client, if any.
+func tokenFromRequest(r *http.Request, cfg *chatconfig.HubWebsocketServer) (token, protocol string) {
+	protocols := websocketProtocols(r)
+	for i, p := range protocols {
+		if p == AccessTokenProtocol && i+1 < len(protocols) {
+			return protocols[i+1], AccessTokenProtocol
+		}
+	}
+
+	if cfg.TokenQueryParam != "" {
+		if token = r.URL.Query().Get(cfg.TokenQueryParam); token != "" {
+			return token, ""
+		}
+	}
+
+	if cfg.TokenCookieName != "" {
+		if cookie, err := r.Cookie(cfg.TokenCookieName); err == nil && cookie.Value != "" {
+			return cookie.Value, ""
+		}
+	}
+
+	return "", ""
+}
+
+func websocketProtocols(r *http.Request) []string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, p := range strings.Split(header, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				protocols = append(protocols, p)
+			}
+		}
+	}
+	return protocols
+}
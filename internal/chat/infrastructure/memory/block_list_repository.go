@@ -0,0 +1,73 @@
+package chatinmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+var _ chatdomain.BlockListRepository = (*BlockListRepository)(nil)
+
+// BlockListRepository implements chatdomain.BlockListRepository using an in-memory concurrent implementation.
+type BlockListRepository struct {
+	mu      sync.RWMutex
+	blocked map[chatdomain.ID]map[chatdomain.ID]struct{}
+}
+
+// NewBlockListRepository initializes an in-memory block list repository.
+func NewBlockListRepository() *BlockListRepository {
+	return &BlockListRepository{
+		blocked: make(map[chatdomain.ID]map[chatdomain.ID]struct{}),
+	}
+}
+
+// Block adds the blocked user to the block list of the given user.
+func (r *BlockListRepository) Block(_ context.Context, userID, blockedUserID chatdomain.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.blocked[userID]; !ok {
+		r.blocked[userID] = make(map[chatdomain.ID]struct{})
+	}
+	r.blocked[userID][blockedUserID] = struct{}{}
+	return nil
+}
+
+// Unblock removes the blocked user from the block list of the given user.
+func (r *BlockListRepository) Unblock(_ context.Context, userID, blockedUserID chatdomain.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.blocked[userID], blockedUserID)
+	return nil
+}
+
+// FindBlockedUsers retrieves the users blocked by the given user.
+func (r *BlockListRepository) FindBlockedUsers(_ context.Context, userID chatdomain.ID) ([]chatdomain.ID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	blocked := make([]chatdomain.ID, 0, len(r.blocked[userID]))
+	for blockedUserID := range r.blocked[userID] {
+		blocked = append(blocked, blockedUserID)
+	}
+	slices.Sort(blocked)
+	return blocked, nil
+}
+
+// FindBlockingUsers retrieves the users that blocked the given user.
+func (r *BlockListRepository) FindBlockingUsers(_ context.Context, userID chatdomain.ID) ([]chatdomain.ID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var blocking []chatdomain.ID
+	for blockerID, blocked := range r.blocked {
+		if _, ok := blocked[userID]; ok {
+			blocking = append(blocking, blockerID)
+		}
+	}
+	slices.Sort(blocking)
+	return blocking, nil
+}
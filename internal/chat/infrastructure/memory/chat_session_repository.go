@@ -0,0 +1,87 @@
+package chatinmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/xfrr/go-cqrsify/domain"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+var _ chatdomain.ChatSessionRepository = (*ChatSessionRepository)(nil)
+
+// ChatSessionRepository implements chatdomain.ChatSessionRepository storing the events
+// of the current conversation of every ChatSession in memory and replaying them on load.
+type ChatSessionRepository struct {
+	mu        sync.RWMutex
+	events    map[string][]domain.Event
+	sequences map[string]uint64
+}
+
+// NewChatSessionRepository initializes an in-memory chat session repository.
+func NewChatSessionRepository() *ChatSessionRepository {
+	return &ChatSessionRepository{
+		events:    make(map[string][]domain.Event),
+		sequences: make(map[string]uint64),
+	}
+}
+
+// Save appends the uncommitted events of the ChatSession with optimistic concurrency checks.
+func (r *ChatSessionRepository) Save(_ context.Context, cs *chatdomain.ChatSession) error {
+	events := cs.AggregateEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sequences[cs.AggregateID()] != cs.Sequence() {
+		if cs.AggregateVersion() == 0 {
+			return chatdomain.ErrChatSessionAlreadyExists
+		}
+		return chatdomain.ErrChatSessionVersionMismatch
+	}
+
+	stored := r.events[cs.AggregateID()]
+	if cs.AggregateVersion() == 0 {
+		// a restarted session replaces the closed one
+		stored = nil
+	}
+
+	sequence := cs.Sequence() + uint64(len(events))
+	r.events[cs.AggregateID()] = append(slices.Clip(stored), events...)
+	r.sequences[cs.AggregateID()] = sequence
+	cs.SetSequence(sequence)
+	cs.CommitEvents()
+	return nil
+}
+
+// FindByID restores a ChatSession from its stored events.
+func (r *ChatSessionRepository) FindByID(_ context.Context, id string) (*chatdomain.ChatSession, error) {
+	r.mu.RLock()
+	events := slices.Clone(r.events[id])
+	sequence := r.sequences[id]
+	r.mu.RUnlock()
+
+	if len(events) == 0 {
+		return nil, chatdomain.ErrChatSessionNotFound
+	}
+
+	cs, err := chatdomain.NewChatSessionFromEvents(chatdomain.ID(id), events)
+	if err != nil {
+		return nil, err
+	}
+	cs.SetSequence(sequence)
+	return cs, nil
+}
+
+// Exists checks if a ChatSession with the given ID exists.
+func (r *ChatSessionRepository) Exists(_ context.Context, id string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.events[id]) > 0, nil
+}
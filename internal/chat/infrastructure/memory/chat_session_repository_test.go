@@ -0,0 +1,66 @@
+package chatinmemory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestChatSessionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	newChatSession := func(t *testing.T) *chatdomain.ChatSession {
+		t.Helper()
+
+		user, err := chatdomain.NewUser("alice", "nick", 25, gender.Female, matchmaking.DefaultPreferences())
+		require.NoError(t, err)
+		cs, err := chatdomain.NewChatSession("alice", user)
+		require.NoError(t, err)
+		return cs
+	}
+
+	t.Run("should reject changes of a stale session", func(t *testing.T) {
+		repo := chatinmemory.NewChatSessionRepository()
+		require.NoError(t, repo.Save(ctx, newChatSession(t)))
+		require.ErrorIs(t, repo.Save(ctx, newChatSession(t)), chatdomain.ErrChatSessionAlreadyExists)
+
+		matched, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		expired, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+
+		require.NoError(t, matched.Match("bob", "match-1"))
+		require.NoError(t, repo.Save(ctx, matched))
+
+		require.NoError(t, expired.Expire())
+		require.ErrorIs(t, repo.Save(ctx, expired), chatdomain.ErrChatSessionVersionMismatch)
+	})
+
+	t.Run("should replace the previous conversation when restarted", func(t *testing.T) {
+		repo := chatinmemory.NewChatSessionRepository()
+		cs := newChatSession(t)
+		require.NoError(t, repo.Save(ctx, cs))
+		require.NoError(t, cs.Leave())
+		require.NoError(t, repo.Save(ctx, cs))
+
+		restarted, err := cs.Restart(*cs.User())
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, restarted))
+
+		// the closed session cannot be restarted twice
+		again, err := cs.Restart(*cs.User())
+		require.NoError(t, err)
+		require.ErrorIs(t, repo.Save(ctx, again), chatdomain.ErrChatSessionAlreadyExists)
+
+		found, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, found.Status())
+		require.EqualValues(t, 1, found.AggregateVersion())
+	})
+}
@@ -0,0 +1,84 @@
+package chatinmemory
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+var _ chatdomain.MessagePublisher = (*MessageBroker)(nil)
+
+type messageHandler func(ctx context.Context, msg *chatpbv1.UserMessage)
+
+// MessageBroker delivers the published messages to the in-process subscribers of each recipient.
+// Handlers are called synchronously from Publish.
+type MessageBroker struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[string]map[int]messageHandler
+}
+
+// NewMessageBroker initializes an in-memory message broker.
+func NewMessageBroker() *MessageBroker {
+	return &MessageBroker{
+		subscribers: make(map[string]map[int]messageHandler),
+	}
+}
+
+// Publish delivers the message to the subscribers of every recipient.
+func (b *MessageBroker) Publish(ctx context.Context, msg chatdomain.Message, recipients ...chatdomain.ID) error {
+	protoMsg := &chatpbv1.UserMessage{
+		MessageId:   msg.ID().String(),
+		MessageType: chatpbv1.UserMessage_TYPE_TEXT,
+		UserId:      msg.SenderID().String(),
+		RoomId:      msg.RoomID().String(),
+		Payload: &chatpbv1.UserMessage_Payload{
+			Content: &chatpbv1.UserMessage_Payload_Text{Text: msg.Text()},
+		},
+		Timestamp: timestamppb.New(msg.SentAt()),
+	}
+
+	for _, recipient := range recipients {
+		b.mu.RLock()
+		handlers := make([]messageHandler, 0, len(b.subscribers[recipient.String()]))
+		for _, handler := range b.subscribers[recipient.String()] {
+			handlers = append(handlers, handler)
+		}
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			handler(ctx, protoMsg)
+		}
+	}
+	return nil
+}
+
+// SubscribeUser calls the handler for every message sent to the given user until the context is done.
+func (b *MessageBroker) SubscribeUser(
+	ctx context.Context,
+	userID string,
+	handler func(ctx context.Context, msg *chatpbv1.UserMessage),
+) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	if _, ok := b.subscribers[userID]; !ok {
+		b.subscribers[userID] = make(map[int]messageHandler)
+	}
+	b.subscribers[userID][id] = handler
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.subscribers[userID], id)
+	if len(b.subscribers[userID]) == 0 {
+		delete(b.subscribers, userID)
+	}
+	b.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,105 @@
+package chatinmemory
+
+import (
+	"context"
+	"sync"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+var _ chatdomain.RoomRepository = (*RoomRepository)(nil)
+
+// RoomRepository implements chatdomain.RoomRepository using an in-memory concurrent implementation.
+// Every room found is a copy, updated only while it is still the stored version.
+type RoomRepository struct {
+	mu        sync.RWMutex
+	rooms     map[chatdomain.ID][]byte
+	versions  map[chatdomain.ID]uint64
+	userRooms map[chatdomain.ID]chatdomain.ID
+}
+
+// NewRoomRepository initializes an in-memory room repository.
+func NewRoomRepository() *RoomRepository {
+	return &RoomRepository{
+		rooms:     make(map[chatdomain.ID][]byte),
+		versions:  make(map[chatdomain.ID]uint64),
+		userRooms: make(map[chatdomain.ID]chatdomain.ID),
+	}
+}
+
+// Save stores a new room and indexes it by its participants.
+func (r *RoomRepository) Save(_ context.Context, room *chatdomain.Room) error {
+	body, err := room.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rooms[room.ID()]; ok {
+		return chatdomain.ErrRoomAlreadyExists
+	}
+
+	r.rooms[room.ID()] = body
+	r.versions[room.ID()] = 1
+	for _, participant := range room.Participants() {
+		r.userRooms[participant] = room.ID()
+	}
+	room.SetVersion(1)
+	return nil
+}
+
+// Update replaces an existing room, as long as nobody updated it since it was found.
+func (r *RoomRepository) Update(_ context.Context, room *chatdomain.Room) error {
+	body, err := room.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, ok := r.versions[room.ID()]
+	if !ok {
+		return chatdomain.ErrRoomNotFound
+	}
+	if version != room.Version() {
+		return chatdomain.ErrRoomVersionMismatch
+	}
+
+	r.rooms[room.ID()] = body
+	r.versions[room.ID()] = version + 1
+	room.SetVersion(version + 1)
+	return nil
+}
+
+// FindByID retrieves a room by its ID.
+func (r *RoomRepository) FindByID(_ context.Context, id chatdomain.ID) (*chatdomain.Room, error) {
+	r.mu.RLock()
+	body, ok := r.rooms[id]
+	version := r.versions[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, chatdomain.ErrRoomNotFound
+	}
+
+	room := &chatdomain.Room{}
+	if err := room.UnmarshalJSON(body); err != nil {
+		return nil, err
+	}
+	room.SetVersion(version)
+	return room, nil
+}
+
+// FindByUserID retrieves the last room the given user joined.
+func (r *RoomRepository) FindByUserID(ctx context.Context, userID chatdomain.ID) (*chatdomain.Room, error) {
+	r.mu.RLock()
+	roomID, ok := r.userRooms[userID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, chatdomain.ErrRoomNotFound
+	}
+	return r.FindByID(ctx, roomID)
+}
@@ -0,0 +1,83 @@
+package chatinmemory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatinmemory "github.com/xfrr/randomtalk/internal/chat/infrastructure/memory"
+)
+
+func TestRoomRepositoryConcurrentLeaves(t *testing.T) {
+	ctx := context.Background()
+	members := []chatdomain.ID{"alice", "bob", "carol", "dave", "erin"}
+
+	newRepository := func(t *testing.T) *chatinmemory.RoomRepository {
+		t.Helper()
+
+		repo := chatinmemory.NewRoomRepository()
+		room, err := chatdomain.NewRoom("room-id", members...)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, room))
+		return repo
+	}
+
+	t.Run("should reject updates of a stale room", func(t *testing.T) {
+		repo := newRepository(t)
+
+		first, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+
+		require.NoError(t, first.Leave("alice"))
+		require.NoError(t, repo.Update(ctx, first))
+
+		require.NoError(t, second.Leave("bob"))
+		require.ErrorIs(t, repo.Update(ctx, second), chatdomain.ErrRoomVersionMismatch)
+	})
+
+	t.Run("should keep every leave when the members leave at once", func(t *testing.T) {
+		repo := newRepository(t)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, len(members)-1)
+		for _, member := range members[1:] {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- leaveRoom(ctx, repo, member)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		room, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+		require.Equal(t, members[:1], room.Participants())
+	})
+}
+
+// leaveRoom runs the read-leave-update cycle, retrying when another member updated the room first.
+func leaveRoom(ctx context.Context, repo chatdomain.RoomRepository, userID chatdomain.ID) error {
+	for {
+		room, err := repo.FindByID(ctx, "room-id")
+		if err != nil {
+			return err
+		}
+		if err = room.Leave(userID); err != nil {
+			return err
+		}
+		if err = repo.Update(ctx, room); !errors.Is(err, chatdomain.ErrRoomVersionMismatch) {
+			return err
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package chatnats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const blockListsBucketName = "randomtalk_chat_block_lists"
+
+var _ chatdomain.BlockListRepository = (*BlockListRepository)(nil)
+
+// BlockListRepository implements chatdomain.BlockListRepository using a NATS JetStream KeyValue bucket.
+// Each block is stored under "users.<user_id>.blocked.<blocked_user_id>" with the time it was created.
+type BlockListRepository struct {
+	kv jetstream.KeyValue
+}
+
+// NewBlockListRepository creates a new BlockListRepository.
+// The blocks never expire, they are kept until the user removes them.
+func NewBlockListRepository(ctx context.Context, js jetstream.JetStream) (*BlockListRepository, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  blockListsBucketName,
+		History: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockListRepository{
+		kv: kvstore,
+	}, nil
+}
+
+// Block implements chatdomain.BlockListRepository.
+func (r *BlockListRepository) Block(ctx context.Context, userID, blockedUserID chatdomain.ID) error {
+	_, err := r.kv.PutString(ctx, blockKey(userID.String(), blockedUserID.String()), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("put block: %w", err)
+	}
+	return nil
+}
+
+// Unblock implements chatdomain.BlockListRepository.
+func (r *BlockListRepository) Unblock(ctx context.Context, userID, blockedUserID chatdomain.ID) error {
+	if err := r.kv.Delete(ctx, blockKey(userID.String(), blockedUserID.String())); err != nil {
+		return fmt.Errorf("delete block: %w", err)
+	}
+	return nil
+}
+
+// FindBlockedUsers implements chatdomain.BlockListRepository.
+func (r *BlockListRepository) FindBlockedUsers(ctx context.Context, userID chatdomain.ID) ([]chatdomain.ID, error) {
+	return r.listKeys(ctx, blockKey(userID.String(), "*"), func(key string) string {
+		return key[strings.LastIndex(key, ".")+1:]
+	})
+}
+
+// FindBlockingUsers implements chatdomain.BlockListRepository.
+func (r *BlockListRepository) FindBlockingUsers(ctx context.Context, userID chatdomain.ID) ([]chatdomain.ID, error) {
+	return r.listKeys(ctx, blockKey("*", userID.String()), func(key string) string {
+		return strings.Split(key, ".")[1]
+	})
+}
+
+// listKeys returns the user IDs extracted from the keys matching the filter.
+func (r *BlockListRepository) listKeys(ctx context.Context, filter string, userIDFromKey func(key string) string) ([]chatdomain.ID, error) {
+	lister, err := r.kv.ListKeysFiltered(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list block keys: %w", err)
+	}
+
+	var userIDs []chatdomain.ID
+	for key := range lister.Keys() {
+		userIDs = append(userIDs, chatdomain.ID(userIDFromKey(key)))
+	}
+
+	// the lister stops early when the context is done
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	slices.Sort(userIDs)
+	return userIDs, nil
+}
+
+func blockKey(userID, blockedUserID string) string {
+	return "users." + userID + ".blocked." + blockedUserID
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -23,13 +24,15 @@ const (
 	chatSessionsStreamSuffix = "sessions"
 )
 
-// ensure MatchRepository implements chatdom.MatchRepository
 var _ chatdom.ChatSessionRepository = (*ChatSessionRepository)(nil)
 
-// MatchRepository implements chatdom.MatchRepository using NATS JetStream.
+// ChatSessionRepository implements chatdom.ChatSessionRepository using NATS JetStream.
+// The events of every ChatSession are appended to "randomtalk.chat.sessions.<session_id>",
+// expecting the sequence of the last event the ChatSession was loaded with. A restarted
+// ChatSession purges the events of the previous conversation once appended.
 type ChatSessionRepository struct {
 	sourceName string
-	stream     eventstore.Stream
+	stream     *xnats.Stream
 }
 
 func NewChatSessionRepository(ctx context.Context, js jetstream.JetStream, streamConfig xnats.StreamConfig) (*ChatSessionRepository, error) {
@@ -44,37 +47,65 @@ func NewChatSessionRepository(ctx context.Context, js jetstream.JetStream, strea
 	}, nil
 }
 
-// Save appends new events for a Match to the event store with optimistic concurrency checks.
-func (r ChatSessionRepository) Save(ctx context.Context, match *chatdom.ChatSession) error {
-	events, err := r.toStoreEvents(match.AggregateEvents())
+// Save appends new events for a ChatSession to the event store with optimistic concurrency checks.
+func (r ChatSessionRepository) Save(ctx context.Context, cs *chatdom.ChatSession) error {
+	if len(cs.AggregateEvents()) == 0 {
+		return nil
+	}
+
+	events, err := r.toStoreEvents(cs.AggregateEvents())
 	if err != nil {
 		return fmt.Errorf("convert to cloud events: %w", err)
 	}
 
-	_, appendErr := r.stream.Append(ctx, events)
+	subject := r.subject(cs.AggregateID())
+	res, appendErr := r.stream.AppendToSubject(ctx, subject, cs.Sequence(), events)
 	if appendErr != nil {
 		if errors.Is(appendErr, eventstore.ErrSequenceMismatch) {
-			return chatdom.ErrChatSessionAlreadyExists
+			return r.versionError(cs)
 		}
 		return fmt.Errorf("append chat session events: %w", appendErr)
 	}
 
+	if cs.AggregateVersion() == 0 && cs.Sequence() > 0 {
+		// the session was restarted, the previous conversation is skipped on load anyway
+		_ = r.stream.PurgeSubject(ctx, subject, res.FirstSequence)
+	}
+
+	cs.SetSequence(res.LastSequence)
+	cs.CommitEvents()
 	return nil
 }
 
+// FindByID restores the current conversation of the ChatSession, which starts
+// at its last creation event. Once the creation expired from the stream,
+// the events left are purged and the ChatSession is not found.
 func (r ChatSessionRepository) FindByID(ctx context.Context, id string) (*chatdom.ChatSession, error) {
-	batchSize := 5
-	filter := eventstore.FetchSubject(createEventFilterKey(r.sourceName, id, ">"))
-
-	cloudEvents, err := r.stream.Pull(ctx, batchSize, filter)
+	subject := r.subject(id)
+	stored, err := r.stream.PullSubject(ctx, subject)
 	if err != nil {
-		if errors.Is(err, eventstore.ErrNoEventsFound) {
-			return nil, chatdom.ErrChatSessionNotFound
-		}
 		return nil, fmt.Errorf("pull from stream: %w", err)
 	}
+	if len(stored) == 0 {
+		return nil, chatdom.ErrChatSessionNotFound
+	}
 
-	aggEvents, err := eventsFromCloudEvents(cloudEvents)
+	lastSequence := stored[len(stored)-1].Sequence
+	created := -1
+	for i, e := range slices.Backward(stored) {
+		if e.Type() == (chatdomaineventsv1.ChatSessionCreated{}).EventName() {
+			created = i
+			break
+		}
+	}
+	if created < 0 {
+		if err = r.stream.PurgeSubject(ctx, subject, lastSequence+1); err != nil {
+			return nil, fmt.Errorf("purge expired chat session: %w", err)
+		}
+		return nil, chatdom.ErrChatSessionNotFound
+	}
+
+	aggEvents, err := eventsFromStoredEvents(stored[created:])
 	if err != nil {
 		return nil, fmt.Errorf("convert from cloud events: %w", err)
 	}
@@ -83,20 +114,30 @@ func (r ChatSessionRepository) FindByID(ctx context.Context, id string) (*chatdo
 	if restoreErr != nil {
 		return nil, fmt.Errorf("restore chat session from events: %w", restoreErr)
 	}
+	sess.SetSequence(lastSequence)
 	return sess, nil
 }
 
 func (r ChatSessionRepository) Exists(ctx context.Context, id string) (bool, error) {
-	filter := eventstore.FetchSubject(createEventFilterKey(r.sourceName, chatdom.AggregateName, id, ">"))
-	cloudEvents, err := r.stream.Pull(ctx, 1, filter)
+	_, err := r.FindByID(ctx, id)
+	if errors.Is(err, chatdom.ErrChatSessionNotFound) {
+		return false, nil
+	}
 	if err != nil {
-		if errors.Is(err, eventstore.ErrNoEventsFound) {
-			return false, nil
-		}
-		return false, fmt.Errorf("pull from stream (Exists): %w", err)
+		return false, err
 	}
+	return true, nil
+}
+
+func (r ChatSessionRepository) subject(id string) string {
+	return createEventFilterKey(r.sourceName, id)
+}
 
-	return len(cloudEvents) > 0, nil
+func (r ChatSessionRepository) versionError(cs *chatdom.ChatSession) error {
+	if cs.AggregateVersion() == 0 {
+		return chatdom.ErrChatSessionAlreadyExists
+	}
+	return chatdom.ErrChatSessionVersionMismatch
 }
 
 func (r ChatSessionRepository) toStoreEvents(events []domain.Event) ([]eventstore.Event, error) {
@@ -131,10 +172,10 @@ func buildStreamSourceName(sourceName, streamSuffix string) string {
 	return sourceName + "." + streamSuffix
 }
 
-func eventsFromCloudEvents(cloudEvents []eventstore.Event) ([]domain.Event, error) {
-	aggEvents := make([]domain.Event, len(cloudEvents))
-	for i, ce := range cloudEvents {
-		evt, err := eventFromCloudEvent(ce)
+func eventsFromStoredEvents(stored []xnats.StoredEvent) ([]domain.Event, error) {
+	aggEvents := make([]domain.Event, len(stored))
+	for i, se := range stored {
+		evt, err := eventFromCloudEvent(se.Event)
 		if err != nil {
 			return nil, fmt.Errorf("convert single cloud event: %w", err)
 		}
@@ -149,36 +190,83 @@ func eventFromCloudEvent(ce eventstore.Event) (domain.Event, error) {
 		return nil, fmt.Errorf("invalid event aggregate version: %w", err)
 	}
 
+	subjectSplit := strings.Split(ce.Subject(), ".")
+	if len(subjectSplit) < 2 {
+		return nil, errors.New("invalid subject format")
+	}
+	subjectID := subjectSplit[1]
+
+	baseEvent := domain.NewEvent(
+		ce.Type(),
+		domain.NewEventAggregateReference(subjectID, chatdom.AggregateName, domain.AggregateVersion(aggVersion)),
+		domain.WithEventTimestamp(ce.Time()),
+	)
+
 	switch ce.Type() {
 	case chatdomaineventsv1.ChatSessionCreated{}.EventName():
-		payload := &chatdomaineventsv1.ChatSessionCreated{}
-		if unmarshalErr := json.Unmarshal(ce.DataEncoded, payload); unmarshalErr != nil {
-			return nil, fmt.Errorf("json unmarshal: %w", unmarshalErr)
+		payload := chatdomaineventsv1.ChatSessionCreated{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
 		}
 
-		subjectSplit := strings.Split(ce.Subject(), ".")
-		if len(subjectSplit) < 2 {
-			return nil, errors.New("invalid subject format")
-		}
-
-		subjectID := subjectSplit[1]
 		if payload.UserID != subjectID {
 			return nil, errors.New("subject ID and event payload ID mismatch")
 		}
 
-		baseEvent := domain.NewEvent(
-			ce.Type(),
-			domain.NewEventAggregateReference(subjectID, chatdom.AggregateName, domain.AggregateVersion(aggVersion)),
-			domain.WithEventTimestamp(ce.Time()),
-		)
+		payload.BaseEvent = baseEvent
+		return payload, nil
+	case chatdomaineventsv1.ChatSessionMatched{}.EventName():
+		payload := chatdomaineventsv1.ChatSessionMatched{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
+		}
+
+		payload.BaseEvent = baseEvent
+		return payload, nil
+	case chatdomaineventsv1.ChatSessionPartnerSkipped{}.EventName():
+		payload := chatdomaineventsv1.ChatSessionPartnerSkipped{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
+		}
+
+		payload.BaseEvent = baseEvent
+		return payload, nil
+	case chatdomaineventsv1.ChatSessionLeft{}.EventName():
+		payload := chatdomaineventsv1.ChatSessionLeft{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
+		}
+
+		payload.BaseEvent = baseEvent
+		return payload, nil
+	case chatdomaineventsv1.ChatSessionEnded{}.EventName():
+		payload := chatdomaineventsv1.ChatSessionEnded{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
+		}
+
+		payload.BaseEvent = baseEvent
+		return payload, nil
+	case chatdomaineventsv1.ChatSessionExpired{}.EventName():
+		payload := chatdomaineventsv1.ChatSessionExpired{}
+		if err := unmarshalEventData(ce, &payload); err != nil {
+			return nil, err
+		}
 
 		payload.BaseEvent = baseEvent
-		return *payload, nil
+		return payload, nil
 	default:
 		return nil, fmt.Errorf("unexpected event type: %s", ce.Type())
 	}
 }
 
+func unmarshalEventData(ce eventstore.Event, payload any) error {
+	if err := json.Unmarshal(ce.DataEncoded, payload); err != nil {
+		return fmt.Errorf("json unmarshal: %w", err)
+	}
+	return nil
+}
+
 func createEventFilterKey(sourceName string, parts ...string) string {
 	return strings.Join(append([]string{sourceName}, parts...), ".")
 }
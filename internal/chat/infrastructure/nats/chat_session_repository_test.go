@@ -0,0 +1,100 @@
+//go:build integration
+// +build integration
+
+package chatnats_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatnats "github.com/xfrr/randomtalk/internal/chat/infrastructure/nats"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+	xnats "github.com/xfrr/randomtalk/internal/shared/nats"
+)
+
+const testChatSessionStreamName = "randomtalk_chat_sessions_test"
+
+func TestChatSessionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	newRepository := func(t *testing.T) (*chatnats.ChatSessionRepository, jetstream.Stream) {
+		t.Helper()
+
+		js := setupJetStream(t)
+		repo, err := chatnats.NewChatSessionRepository(ctx, js,
+			xnats.NewStreamConfig(testChatSessionStreamName, "randomtalk.chat.sessions.>"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = js.DeleteStream(context.Background(), testChatSessionStreamName) })
+
+		stream, err := js.Stream(ctx, testChatSessionStreamName)
+		require.NoError(t, err)
+		return repo, stream
+	}
+
+	newChatSession := func(t *testing.T) *chatdomain.ChatSession {
+		t.Helper()
+
+		user, err := chatdomain.NewUser("alice", "nick", 25, gender.Female, matchmaking.DefaultPreferences())
+		require.NoError(t, err)
+		cs, err := chatdomain.NewChatSession("alice", user)
+		require.NoError(t, err)
+		return cs
+	}
+
+	t.Run("should reject concurrent changes of different types", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		require.NoError(t, repo.Save(ctx, newChatSession(t)))
+
+		matched, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		expired, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+
+		require.NoError(t, matched.Match("bob", "match-1"))
+		require.NoError(t, repo.Save(ctx, matched))
+
+		require.NoError(t, expired.Expire())
+		require.ErrorIs(t, repo.Save(ctx, expired), chatdomain.ErrChatSessionVersionMismatch)
+	})
+
+	t.Run("should replace the previous conversation when restarted", func(t *testing.T) {
+		repo, stream := newRepository(t)
+		cs := newChatSession(t)
+		require.NoError(t, repo.Save(ctx, cs))
+		require.NoError(t, cs.Leave())
+		require.NoError(t, repo.Save(ctx, cs))
+
+		restarted, err := cs.Restart(*cs.User())
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, restarted))
+
+		found, err := repo.FindByID(ctx, "alice")
+		require.NoError(t, err)
+		require.Equal(t, chatdomain.ChatSessionWaiting, found.Status())
+		require.EqualValues(t, 1, found.AggregateVersion())
+
+		info, err := stream.Info(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, info.State.Msgs)
+	})
+
+	t.Run("should not find a session whose creation expired", func(t *testing.T) {
+		repo, stream := newRepository(t)
+		cs := newChatSession(t)
+		require.NoError(t, repo.Save(ctx, cs))
+		require.NoError(t, cs.Match("bob", "match-1"))
+		require.NoError(t, repo.Save(ctx, cs))
+
+		// drop the creation, as the stream max age does
+		require.NoError(t, stream.Purge(ctx, jetstream.WithPurgeKeep(1)))
+
+		_, err := repo.FindByID(ctx, "alice")
+		require.ErrorIs(t, err, chatdomain.ErrChatSessionNotFound)
+		require.NoError(t, repo.Save(ctx, newChatSession(t)))
+	})
+}
@@ -3,6 +3,7 @@ package chatnats
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,12 +14,21 @@ import (
 	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
 	"github.com/xfrr/randomtalk/internal/shared/eventstore"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	geo "github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	// EventTypeUserMatchRequested is the CloudEvent type for user match request events.
 	EventTypeUserMatchRequested = "com.randomtalk.chat.notifications.user_match_requested"
+	// EventTypeMatchResponded is the CloudEvent type for the responses to the proposed matches.
+	EventTypeMatchResponded = "com.randomtalk.chat.notifications.match_responded"
+	// EventTypeMatchRequestCancelled is the CloudEvent type for the cancelled match requests.
+	EventTypeMatchRequestCancelled = "com.randomtalk.chat.notifications.match_request_cancelled"
+	// EventTypePartnerRated is the CloudEvent type for the ratings of the partners.
+	EventTypePartnerRated = "com.randomtalk.chat.notifications.partner_rated"
 	// EventSource identifies the source of the event.
 	EventSource = "/chat"
 	// maxRetries defines the number of retry attempts for publishing.
@@ -32,13 +42,19 @@ var _ chatdomain.MatchRequester = (*MatchRequester)(nil)
 // MatchRequester is responsible for publishing user match request events via NATS JetStream.
 // It uses CloudEvents to ensure interoperability and standardization.
 type MatchRequester struct {
-	streamName string
-	js         jetstream.JetStream
+	streamName    string
+	js            jetstream.JetStream
+	blockListRepo chatdomain.BlockListRepository
 }
 
 // It creates a CloudEvent based on the provided ChatSession, marshals it to JSON,
 // and publishes it to the NATS JetStream stream.
 func (m *MatchRequester) RequestMatch(ctx context.Context, cs *chatdomain.ChatSession) error {
+	blockedUserIDs, err := m.blockedUserIDs(ctx, cs.User().ID())
+	if err != nil {
+		return fmt.Errorf("find blocked users: %w", err)
+	}
+
 	eventID := uuid.New().String()
 
 	ce := eventstore.NewEvent()
@@ -53,28 +69,167 @@ func (m *MatchRequester) RequestMatch(ctx context.Context, cs *chatdomain.ChatSe
 		NotificationId: eventID,
 		ChatSessionId:  cs.AggregateID(),
 		UserAttributes: &chatpbv1.UserAttributes{
-			Id:     cs.User().ID().String(),
-			Age:    cs.User().Age(),
-			Gender: toProtoGender(cs.User().Gender()),
+			Id:        cs.User().ID().String(),
+			Age:       cs.User().Age(),
+			Gender:    toProtoGender(cs.User().Gender()),
+			Location:  toProtoUserLocation(cs.User().Location()),
+			Languages: cs.User().Languages(),
 		},
 		UserPreferences: &chatpbv1.UserPreferences{
-			MinAge:    cs.User().MatchPreferences().MinAge,
-			MaxAge:    cs.User().MatchPreferences().MaxAge,
-			Gender:    toProtoGender(cs.User().MatchPreferences().Gender),
-			Interests: cs.User().MatchPreferences().Interests,
+			MinAge:             cs.User().MatchPreferences().MinAge,
+			MaxAge:             cs.User().MatchPreferences().MaxAge,
+			Gender:             toProtoGender(cs.User().MatchPreferences().Gender),
+			Interests:          cs.User().MatchPreferences().Interests,
+			MaxDistanceKm:      cs.User().MatchPreferences().MaxDistanceKm,
+			LocationScope:      toProtoLocationScope(cs.User().MatchPreferences().LocationScope),
+			MaxWaitTimeSeconds: cs.User().MatchPreferences().MaxWaitTimeSeconds,
+
+			RequireSharedLanguage: cs.User().MatchPreferences().RequireSharedLanguage,
+			GroupSize:             cs.User().MatchPreferences().GroupSize,
 		},
+		SkippedUserId:  cs.LastPartnerID().String(),
+		BlockedUserIds: blockedUserIDs,
+	}
+
+	if dataErr := ce.SetData(string(eventstore.ContentTypeApplicationJSON), notif); dataErr != nil {
+		return fmt.Errorf("set event data: %w", dataErr)
+	}
+
+	if err = m.publish(ctx, cs, "user_match_requested", ce); err != nil {
+		return fmt.Errorf("publish user match request event: %w", err)
+	}
+	return nil
+}
+
+// blockedUserIDs returns the users blocked by the user and the users that blocked it,
+// which the matchmaker must never pair with the user.
+func (m *MatchRequester) blockedUserIDs(ctx context.Context, userID chatdomain.ID) ([]string, error) {
+	blocked, err := m.blockListRepo.FindBlockedUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking, err := m.blockListRepo.FindBlockingUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(blocked)+len(blocking))
+	for _, id := range slices.Concat(blocked, blocking) {
+		if !slices.Contains(userIDs, id.String()) {
+			userIDs = append(userIDs, id.String())
+		}
+	}
+	return userIDs, nil
+}
+
+// RespondToMatch publishes whether the ChatSession user accepts the proposed match.
+func (m *MatchRequester) RespondToMatch(ctx context.Context, cs *chatdomain.ChatSession, matchID chatdomain.ID, accepted bool) error {
+	eventID := uuid.New().String()
+	now := time.Now().UTC()
+
+	ce := eventstore.NewEvent()
+	ce.SetID(eventID)
+	ce.SetType(EventTypeMatchResponded)
+	ce.SetSource(chatdomain.EventSourceName)
+	ce.SetSubject(strings.Join([]string{chatSessionsStreamSuffix, cs.AggregateID()}, "."))
+	ce.SetTime(now)
+	ce.SetDataSchema("schemas.randomtalk.com/chat/notifications/match_responded/1.0")
+
+	notif := &chatpbv1.MatchResponseNotification{
+		NotificationId: eventID,
+		ChatSessionId:  cs.AggregateID(),
+		MatchId:        matchID.String(),
+		UserId:         cs.User().ID().String(),
+		Accepted:       accepted,
+		OccurredAt:     timestamppb.New(now),
+	}
+
+	if dataErr := ce.SetData(string(eventstore.ContentTypeApplicationJSON), notif); dataErr != nil {
+		return fmt.Errorf("set event data: %w", dataErr)
+	}
+
+	if err := m.publish(ctx, cs, "match_responded", ce); err != nil {
+		return fmt.Errorf("publish match responded event: %w", err)
+	}
+	return nil
+}
+
+// CancelMatchRequest publishes that the ChatSession user no longer waits for a match.
+func (m *MatchRequester) CancelMatchRequest(ctx context.Context, cs *chatdomain.ChatSession) error {
+	eventID := uuid.New().String()
+	now := time.Now().UTC()
+
+	ce := eventstore.NewEvent()
+	ce.SetID(eventID)
+	ce.SetType(EventTypeMatchRequestCancelled)
+	ce.SetSource(chatdomain.EventSourceName)
+	ce.SetSubject(strings.Join([]string{chatSessionsStreamSuffix, cs.AggregateID()}, "."))
+	ce.SetTime(now)
+	ce.SetDataSchema("schemas.randomtalk.com/chat/notifications/match_request_cancelled/1.0")
+
+	notif := &chatpbv1.MatchRequestCancelledNotification{
+		NotificationId: eventID,
+		ChatSessionId:  cs.AggregateID(),
+		UserId:         cs.User().ID().String(),
+		OccurredAt:     timestamppb.New(now),
 	}
 
 	if dataErr := ce.SetData(string(eventstore.ContentTypeApplicationJSON), notif); dataErr != nil {
 		return fmt.Errorf("set event data: %w", dataErr)
 	}
 
+	if err := m.publish(ctx, cs, "match_request_cancelled", ce); err != nil {
+		return fmt.Errorf("publish match request cancelled event: %w", err)
+	}
+	return nil
+}
+
+func (m *MatchRequester) RatePartner(ctx context.Context, cs *chatdomain.ChatSession, rating chatdomain.Rating) error {
+	eventID := uuid.New().String()
+	now := time.Now().UTC()
+
+	ce := eventstore.NewEvent()
+	ce.SetID(eventID)
+	ce.SetType(EventTypePartnerRated)
+	ce.SetSource(chatdomain.EventSourceName)
+	ce.SetSubject(strings.Join([]string{chatSessionsStreamSuffix, cs.AggregateID()}, "."))
+	ce.SetTime(now)
+	ce.SetDataSchema("schemas.randomtalk.com/chat/notifications/partner_rated/1.0")
+
+	tags := make([]string, 0, len(rating.Tags))
+	for _, tag := range rating.Tags {
+		tags = append(tags, tag.String())
+	}
+
+	notif := &chatpbv1.PartnerRatedNotification{
+		NotificationId: eventID,
+		ChatSessionId:  cs.AggregateID(),
+		RaterUserId:    cs.User().ID().String(),
+		RatedUserId:    rating.RatedUserID.String(),
+		MatchId:        rating.MatchID.String(),
+		Liked:          rating.Liked,
+		Tags:           tags,
+		OccurredAt:     timestamppb.New(now),
+	}
+
+	if dataErr := ce.SetData(string(eventstore.ContentTypeApplicationJSON), notif); dataErr != nil {
+		return fmt.Errorf("set event data: %w", dataErr)
+	}
+
+	if err := m.publish(ctx, cs, "partner_rated", ce); err != nil {
+		return fmt.Errorf("publish partner rated event: %w", err)
+	}
+	return nil
+}
+
+func (m *MatchRequester) publish(ctx context.Context, cs *chatdomain.ChatSession, name string, ce eventstore.Event) error {
 	body, err := ce.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("marshal user match request cloudevent notification: %w", err)
+		return fmt.Errorf("marshal cloudevent notification: %w", err)
 	}
 
-	subject := strings.Join([]string{chatdomain.EventSourceName, "notifications", cs.AggregateID(), "user_match_requested"}, ".")
+	subject := strings.Join([]string{chatdomain.EventSourceName, "notifications", cs.AggregateID(), name}, ".")
 
 	msg := nats.Msg{
 		Subject: subject,
@@ -83,21 +238,18 @@ func (m *MatchRequester) RequestMatch(ctx context.Context, cs *chatdomain.ChatSe
 
 	_, err = m.js.PublishMsg(ctx, &msg,
 		jetstream.WithExpectStream(m.streamName),
-		jetstream.WithMsgID(eventID),
+		jetstream.WithMsgID(ce.ID()),
 		jetstream.WithRetryAttempts(maxRetries),
 		jetstream.WithRetryWait(retryDelay),
 	)
-	if err != nil {
-		return fmt.Errorf("publish user match request event: %w", err)
-	}
-
-	return nil
+	return err
 }
 
-func NewMatchRequester(streamName string, js jetstream.JetStream) *MatchRequester {
+func NewMatchRequester(streamName string, js jetstream.JetStream, blockListRepo chatdomain.BlockListRepository) *MatchRequester {
 	return &MatchRequester{
-		streamName: streamName,
-		js:         js,
+		streamName:    streamName,
+		js:            js,
+		blockListRepo: blockListRepo,
 	}
 }
 
@@ -111,3 +263,26 @@ func toProtoGender(g gender.Gender) chatpbv1.Gender {
 		return chatpbv1.Gender_GENDER_UNSPECIFIED
 	}
 }
+
+func toProtoUserLocation(location *geo.Location) *chatpbv1.UserLocation {
+	if location == nil {
+		return nil
+	}
+	return &chatpbv1.UserLocation{
+		Latitude:    location.Coordinates.Latitude,
+		Longitude:   location.Coordinates.Longitude,
+		CountryCode: location.CountryCode,
+		CityCode:    location.CityCode,
+	}
+}
+
+func toProtoLocationScope(scope matchmaking.LocationScope) chatpbv1.LocationScope {
+	switch scope {
+	case matchmaking.LocationScopeCountry:
+		return chatpbv1.LocationScope_LOCATION_SCOPE_COUNTRY
+	case matchmaking.LocationScopeCity:
+		return chatpbv1.LocationScope_LOCATION_SCOPE_CITY
+	default:
+		return chatpbv1.LocationScope_LOCATION_SCOPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,65 @@
+package chatnats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+var _ chatdomain.MessagePublisher = (*MessagePublisher)(nil)
+
+// MessagePublisher delivers room messages to the users connected to any chat instance.
+// Messages are published to core NATS, they are not persisted.
+type MessagePublisher struct {
+	nc *nats.Conn
+}
+
+// NewMessagePublisher creates a new MessagePublisher.
+func NewMessagePublisher(nc *nats.Conn) *MessagePublisher {
+	return &MessagePublisher{
+		nc: nc,
+	}
+}
+
+// Publish implements chatdomain.MessagePublisher.
+func (p *MessagePublisher) Publish(ctx context.Context, msg chatdomain.Message, recipients ...chatdomain.ID) error {
+	body, err := protojson.Marshal(toProtoUserMessage(msg))
+	if err != nil {
+		return fmt.Errorf("marshal user message: %w", err)
+	}
+
+	for _, recipient := range recipients {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := p.nc.Publish(userMessagesSubject(recipient.String()), body); err != nil {
+			return fmt.Errorf("publish user message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toProtoUserMessage(msg chatdomain.Message) *chatpbv1.UserMessage {
+	return &chatpbv1.UserMessage{
+		MessageId:   msg.ID().String(),
+		MessageType: chatpbv1.UserMessage_TYPE_TEXT,
+		UserId:      msg.SenderID().String(),
+		RoomId:      msg.RoomID().String(),
+		Payload: &chatpbv1.UserMessage_Payload{
+			Content: &chatpbv1.UserMessage_Payload_Text{Text: msg.Text()},
+		},
+		Timestamp: timestamppb.New(msg.SentAt()),
+	}
+}
+
+func userMessagesSubject(userID string) string {
+	return "randomtalk.chat.users." + userID + ".messages"
+}
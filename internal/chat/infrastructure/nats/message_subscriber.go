@@ -0,0 +1,113 @@
+package chatnats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+// MessageSubscriber receives the room messages published by MessagePublisher and the
+// notifications published by UserNotifier for any user, so each chat instance can
+// deliver them to its connected clients.
+type MessageSubscriber struct {
+	nc     *nats.Conn
+	logger zerolog.Logger
+}
+
+// NewMessageSubscriber creates a new MessageSubscriber.
+func NewMessageSubscriber(nc *nats.Conn, logger zerolog.Logger) *MessageSubscriber {
+	return &MessageSubscriber{
+		nc:     nc,
+		logger: logger,
+	}
+}
+
+// Subscribe calls the handler for every message sent to a user until the context is done.
+func (s *MessageSubscriber) Subscribe(
+	ctx context.Context,
+	handler func(ctx context.Context, recipientID string, msg *chatpbv1.UserMessage),
+) error {
+	return s.subscribe(ctx, userMessagesSubject("*"), handler)
+}
+
+func (s *MessageSubscriber) subscribe(
+	ctx context.Context,
+	subject string,
+	handler func(ctx context.Context, recipientID string, msg *chatpbv1.UserMessage),
+) error {
+	sub, err := s.nc.Subscribe(subject, func(natsMsg *nats.Msg) {
+		recipientID, ok := recipientFromSubject(natsMsg.Subject)
+		if !ok {
+			s.logger.Error().Str("subject", natsMsg.Subject).Msg("invalid user message subject")
+			return
+		}
+
+		msg := &chatpbv1.UserMessage{}
+		if err := protojson.Unmarshal(natsMsg.Data, msg); err != nil {
+			s.logger.Error().Err(err).Msg("failed to unmarshal user message")
+			return
+		}
+
+		handler(ctx, recipientID, msg)
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe to user messages: %w", err)
+	}
+
+	<-ctx.Done()
+	return sub.Unsubscribe()
+}
+
+// SubscribeUser calls the handler for every message sent to the given user until the context is done.
+func (s *MessageSubscriber) SubscribeUser(
+	ctx context.Context,
+	userID string,
+	handler func(ctx context.Context, msg *chatpbv1.UserMessage),
+) error {
+	return s.subscribe(ctx, userMessagesSubject(userID), func(ctx context.Context, _ string, msg *chatpbv1.UserMessage) {
+		handler(ctx, msg)
+	})
+}
+
+// SubscribeNotifications calls the handler for every notification sent to a user until the context is done.
+func (s *MessageSubscriber) SubscribeNotifications(
+	ctx context.Context,
+	handler func(ctx context.Context, recipientID string, notification *chatpbv1.NotificationMessage),
+) error {
+	sub, err := s.nc.Subscribe(userNotificationsSubject("*"), func(natsMsg *nats.Msg) {
+		recipientID, ok := recipientFromSubject(natsMsg.Subject)
+		if !ok {
+			s.logger.Error().Str("subject", natsMsg.Subject).Msg("invalid user notification subject")
+			return
+		}
+
+		notification := &chatpbv1.NotificationMessage{}
+		if err := protojson.Unmarshal(natsMsg.Data, notification); err != nil {
+			s.logger.Error().Err(err).Msg("failed to unmarshal user notification")
+			return
+		}
+
+		handler(ctx, recipientID, notification)
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe to user notifications: %w", err)
+	}
+
+	<-ctx.Done()
+	return sub.Unsubscribe()
+}
+
+// recipientFromSubject extracts the user ID from "randomtalk.chat.users.<user_id>.<messages|notifications>".
+func recipientFromSubject(subject string) (string, bool) {
+	parts := strings.Split(subject, ".")
+	if len(parts) != 5 {
+		return "", false
+	}
+	return parts[3], true
+}
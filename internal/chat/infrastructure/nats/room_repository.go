@@ -0,0 +1,130 @@
+package chatnats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+)
+
+const (
+	roomsBucketName = "randomtalk_chat_rooms"
+	roomsTTL        = 24 * time.Hour
+)
+
+var _ chatdomain.RoomRepository = (*RoomRepository)(nil)
+
+// RoomRepository implements chatdomain.RoomRepository using a NATS JetStream KeyValue bucket.
+// Rooms are stored under "rooms.<room_id>" and indexed by participant under "users.<user_id>".
+type RoomRepository struct {
+	kv jetstream.KeyValue
+}
+
+// NewRoomRepository creates a new RoomRepository.
+func NewRoomRepository(ctx context.Context, js jetstream.JetStream) (*RoomRepository, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  roomsBucketName,
+		History: 1,
+		TTL:     roomsTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoomRepository{
+		kv: kvstore,
+	}, nil
+}
+
+// Save implements chatdomain.RoomRepository.
+func (r *RoomRepository) Save(ctx context.Context, room *chatdomain.Room) error {
+	body, err := room.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshal room: %w", err)
+	}
+
+	revision, err := r.kv.Create(ctx, roomKey(room.ID()), body)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return chatdomain.ErrRoomAlreadyExists
+		}
+		return fmt.Errorf("create room: %w", err)
+	}
+	room.SetVersion(revision)
+
+	for _, participant := range room.Participants() {
+		_, err = r.kv.PutString(ctx, userRoomKey(participant), room.ID().String())
+		if err != nil {
+			return fmt.Errorf("index room participant: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update implements chatdomain.RoomRepository.
+// The room is only updated at the revision it was found with.
+func (r *RoomRepository) Update(ctx context.Context, room *chatdomain.Room) error {
+	body, err := room.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshal room: %w", err)
+	}
+
+	revision, err := r.kv.Update(ctx, roomKey(room.ID()), body, room.Version())
+	if err != nil {
+		var apiErr *jetstream.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence {
+			if _, getErr := r.kv.Get(ctx, roomKey(room.ID())); errors.Is(getErr, jetstream.ErrKeyNotFound) {
+				return chatdomain.ErrRoomNotFound
+			}
+			return chatdomain.ErrRoomVersionMismatch
+		}
+		return fmt.Errorf("update room: %w", err)
+	}
+
+	room.SetVersion(revision)
+	return nil
+}
+
+// FindByID implements chatdomain.RoomRepository.
+func (r *RoomRepository) FindByID(ctx context.Context, id chatdomain.ID) (*chatdomain.Room, error) {
+	entry, err := r.kv.Get(ctx, roomKey(id))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil, chatdomain.ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("get room: %w", err)
+	}
+
+	var room chatdomain.Room
+	if err := room.UnmarshalJSON(entry.Value()); err != nil {
+		return nil, fmt.Errorf("unmarshal room: %w", err)
+	}
+	room.SetVersion(entry.Revision())
+	return &room, nil
+}
+
+// FindByUserID implements chatdomain.RoomRepository.
+func (r *RoomRepository) FindByUserID(ctx context.Context, userID chatdomain.ID) (*chatdomain.Room, error) {
+	entry, err := r.kv.Get(ctx, userRoomKey(userID))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil, chatdomain.ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("get user room: %w", err)
+	}
+
+	return r.FindByID(ctx, chatdomain.ID(entry.Value()))
+}
+
+func roomKey(id chatdomain.ID) string {
+	return "rooms." + id.String()
+}
+
+func userRoomKey(userID chatdomain.ID) string {
+	return "users." + userID.String()
+}
@@ -0,0 +1,114 @@
+//go:build integration
+// +build integration
+
+package chatnats_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatnats "github.com/xfrr/randomtalk/internal/chat/infrastructure/nats"
+)
+
+func TestRoomRepositoryConcurrentLeaves(t *testing.T) {
+	ctx := context.Background()
+	members := []chatdomain.ID{"alice", "bob", "carol", "dave", "erin"}
+
+	newRepository := func(t *testing.T) *chatnats.RoomRepository {
+		t.Helper()
+
+		repo, err := chatnats.NewRoomRepository(ctx, setupJetStream(t))
+		require.NoError(t, err)
+
+		room, err := chatdomain.NewRoom("room-id", members...)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, room))
+		return repo
+	}
+
+	t.Run("should reject updates of a stale room", func(t *testing.T) {
+		repo := newRepository(t)
+
+		first, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+
+		require.NoError(t, first.Leave("alice"))
+		require.NoError(t, repo.Update(ctx, first))
+
+		require.NoError(t, second.Leave("bob"))
+		require.ErrorIs(t, repo.Update(ctx, second), chatdomain.ErrRoomVersionMismatch)
+	})
+
+	t.Run("should keep every leave when the members leave at once", func(t *testing.T) {
+		repo := newRepository(t)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, len(members)-1)
+		for _, member := range members[1:] {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- leaveRoom(ctx, repo, member)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		room, err := repo.FindByID(ctx, "room-id")
+		require.NoError(t, err)
+		require.Equal(t, members[:1], room.Participants())
+	})
+}
+
+// leaveRoom runs the read-leave-update cycle, retrying when another member updated the room first.
+func leaveRoom(ctx context.Context, repo chatdomain.RoomRepository, userID chatdomain.ID) error {
+	for {
+		room, err := repo.FindByID(ctx, "room-id")
+		if err != nil {
+			return err
+		}
+		if err = room.Leave(userID); err != nil {
+			return err
+		}
+		if err = repo.Update(ctx, room); !errors.Is(err, chatdomain.ErrRoomVersionMismatch) {
+			return err
+		}
+	}
+}
+
+func setupJetStream(t *testing.T) jetstream.JetStream {
+	t.Helper()
+
+	nc, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := js.DeleteKeyValue(ctx, "randomtalk_chat_rooms")
+		if !errors.Is(err, jetstream.ErrBucketNotFound) {
+			require.NoError(t, err)
+		}
+		nc.Close()
+	})
+
+	return js
+}
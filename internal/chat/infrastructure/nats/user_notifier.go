@@ -0,0 +1,197 @@
+package chatnats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+)
+
+var _ chatdomain.UserNotifier = (*UserNotifier)(nil)
+
+// UserNotifier delivers system notifications to the users connected to any chat instance.
+// Notifications are published to core NATS, they are not persisted.
+type UserNotifier struct {
+	nc *nats.Conn
+}
+
+// NewUserNotifier creates a new UserNotifier.
+func NewUserNotifier(nc *nats.Conn) *UserNotifier {
+	return &UserNotifier{
+		nc: nc,
+	}
+}
+
+// NotifyUserLeft implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyUserLeft(ctx context.Context, recipientID, userID, roomID chatdomain.ID) error {
+	payload, err := structpb.NewStruct(map[string]any{
+		"user_id": userID.String(),
+		"room_id": roomID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("create user left payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_USER_LEFT,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+// NotifyNewMatch implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyNewMatch(ctx context.Context, recipientID, roomID chatdomain.ID, participantIDs []chatdomain.ID) error {
+	if len(participantIDs) < chatdomain.MinRoomParticipants {
+		return chatdomain.ErrInvalidRoomParticipants
+	}
+
+	data := map[string]any{
+		"user_requester_id": participantIDs[0].String(),
+		"user_matched_id":   participantIDs[1].String(),
+		"room_id":           roomID.String(),
+	}
+	if len(participantIDs) > chatdomain.MinRoomParticipants {
+		members := make([]any, 0, len(participantIDs))
+		for _, participantID := range participantIDs {
+			members = append(members, participantID.String())
+		}
+		data["participant_ids"] = members
+	}
+
+	payload, err := structpb.NewStruct(data)
+	if err != nil {
+		return fmt.Errorf("create new match payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_NEW_MATCH,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+// NotifyNoMatchFound implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyNoMatchFound(ctx context.Context, recipientID chatdomain.ID, waited time.Duration) error {
+	payload, err := structpb.NewStruct(map[string]any{
+		"user_id":        recipientID.String(),
+		"waited_seconds": int64(waited.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("create no match found payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_NO_MATCH_FOUND,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+// NotifyMatchProposed implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyMatchProposed(ctx context.Context, recipientID chatdomain.ID, proposal chatdomain.MatchProposal) error {
+	partner := map[string]any{
+		"user_id": proposal.Partner.UserID.String(),
+		"age":     proposal.Partner.Age,
+		"gender":  proposal.Partner.Gender.String(),
+	}
+	if len(proposal.Partner.Interests) > 0 {
+		partner["interests"] = toAnySlice(proposal.Partner.Interests)
+	}
+	if len(proposal.Partner.Languages) > 0 {
+		partner["languages"] = toAnySlice(proposal.Partner.Languages)
+	}
+	if proposal.Partner.CountryCode != "" {
+		partner["country_code"] = proposal.Partner.CountryCode
+	}
+	if proposal.Partner.CityCode != "" {
+		partner["city_code"] = proposal.Partner.CityCode
+	}
+
+	payload, err := structpb.NewStruct(map[string]any{
+		"match_id":   proposal.MatchID.String(),
+		"expires_at": proposal.ExpiresAt.UTC().Format(time.RFC3339),
+		"partner":    partner,
+	})
+	if err != nil {
+		return fmt.Errorf("create match proposed payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_MATCH_PROPOSED,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+// NotifyMatchCancelled implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyMatchCancelled(ctx context.Context, recipientID, matchID chatdomain.ID, reason string, requeued bool) error {
+	payload, err := structpb.NewStruct(map[string]any{
+		"user_id":  recipientID.String(),
+		"match_id": matchID.String(),
+		"reason":   reason,
+		"requeued": requeued,
+	})
+	if err != nil {
+		return fmt.Errorf("create match cancelled payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_MATCH_CANCELLED,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+// NotifyQueueStatus implements chatdomain.UserNotifier.
+func (n *UserNotifier) NotifyQueueStatus(ctx context.Context, recipientID chatdomain.ID, status chatdomain.QueueStatus) error {
+	payload, err := structpb.NewStruct(map[string]any{
+		"user_id":                recipientID.String(),
+		"compatible_users":       status.CompatibleUsers,
+		"position":               status.Position,
+		"estimated_wait_seconds": int64(status.EstimatedWait.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("create queue status payload: %w", err)
+	}
+
+	return n.publish(ctx, recipientID, &chatpbv1.NotificationMessage{
+		Type:      chatpbv1.NotificationMessage_TYPE_QUEUE_STATUS,
+		Payload:   payload,
+		Timestamp: timestamppb.New(time.Now().UTC()),
+	})
+}
+
+func (n *UserNotifier) publish(ctx context.Context, recipientID chatdomain.ID, notification *chatpbv1.NotificationMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := protojson.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal user notification: %w", err)
+	}
+
+	if err := n.nc.Publish(userNotificationsSubject(recipientID.String()), body); err != nil {
+		return fmt.Errorf("publish user notification: %w", err)
+	}
+	return nil
+}
+
+func toAnySlice(values []string) []any {
+	res := make([]any, 0, len(values))
+	for _, v := range values {
+		res = append(res, v)
+	}
+	return res
+}
+
+func userNotificationsSubject(userID string) string {
+	return "randomtalk.chat.users." + userID + ".notifications"
+}
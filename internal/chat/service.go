@@ -2,8 +2,11 @@ package chatcontext
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"time"
 
 	"github.com/nats-io/nats.go"
@@ -12,10 +15,15 @@ import (
 	"github.com/xfrr/randomtalk/internal/shared/env"
 	"github.com/xfrr/randomtalk/internal/shared/logging"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	chatcommands "github.com/xfrr/randomtalk/internal/chat/application/commands"
 	chatqueries "github.com/xfrr/randomtalk/internal/chat/application/queries"
 	chatconfig "github.com/xfrr/randomtalk/internal/chat/config"
+	chatdomain "github.com/xfrr/randomtalk/internal/chat/domain"
+	"github.com/xfrr/randomtalk/internal/chat/infrastructure/auth"
+	chatgrpc "github.com/xfrr/randomtalk/internal/chat/infrastructure/grpc"
 	chathttp "github.com/xfrr/randomtalk/internal/chat/infrastructure/http"
 	chatnats "github.com/xfrr/randomtalk/internal/chat/infrastructure/nats"
 	xnats "github.com/xfrr/randomtalk/internal/shared/nats"
@@ -37,7 +45,10 @@ type Service struct {
 	cmdbus                     chatcommands.CommandBus
 	querybus                   chatqueries.QueryBus
 	matchNotificationsConsumer *xnats.MessagingEventConsumer
+	roomRepository             chatdomain.RoomRepository
+	messageSubscriber          *chatnats.MessageSubscriber
 	httpWebsocketHub           *chathttp.Hub
+	guestTokenIssuer           *auth.GuestTokenIssuer
 	closers                    []func()
 }
 
@@ -51,16 +62,129 @@ func (s *Service) Start(ctx context.Context) {
 			Str("path", s.config.HubWebsocketServer.Path).
 			Str("url", fmt.Sprintf("ws://%s%s", s.config.HubWebsocketServer.Address, s.config.HubWebsocketServer.Path)).
 			Msg("starting websocket server")
-		err := chathttp.ServeHTTP(s.config.HubWebsocketServer, s.httpWebsocketHub.Handle)
+		err := chathttp.ServeHTTP(s.config.HubWebsocketServer, s.httpHandler())
 		if err != nil {
 			s.logger.Error().Err(err).Msg("failed to start http server")
 		}
 	}()
 
+	s.startGrpcAPIServer(ctx)
+
 	<-ctx.Done()
 	s.shutdown()
 }
 
+func (s *Service) startGrpcAPIServer(ctx context.Context) {
+	apiServer := chatgrpc.NewMessageStreamServer(
+		s.cmdbus,
+		s.roomRepository,
+		s.messageSubscriber,
+		chatgrpc.WithLogger(s.logger),
+	)
+
+	lis, err := net.Listen("tcp", s.config.GrpcAPIServer.Addr)
+	if err != nil {
+		s.logger.Fatal().Err(err).Str("address", s.config.GrpcAPIServer.Addr).Msg("failed to listen for gRPC API server")
+		return
+	}
+
+	blockListServer := chatgrpc.NewBlockListServer(
+		s.cmdbus,
+		s.querybus,
+		chatgrpc.WithBlockListLogger(s.logger),
+	)
+
+	grpcServer := chatgrpc.NewGRPCServer(apiServer, blockListServer)
+	s.registerCloser(grpcServer.GracefulStop)
+
+	go func() {
+		s.logger.Info().Str("address", s.config.GrpcAPIServer.Addr).Msg("starting gRPC API server")
+		if serveErr := grpcServer.Serve(lis); serveErr != nil {
+			s.logger.Error().Err(serveErr).Msg("gRPC API server stopped")
+		}
+	}()
+
+	gatewayServer, err := chatgrpc.NewGatewayServer(
+		ctx,
+		s.config.GrpcAPIServer.GatewayAddr,
+		s.config.GrpcAPIServer.Addr,
+	)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("failed to initialize gRPC gateway server")
+		return
+	}
+
+	s.registerCloser(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = gatewayServer.Shutdown(shutdownCtx)
+	})
+
+	go func() {
+		s.logger.Info().Str("address", s.config.GrpcAPIServer.GatewayAddr).Msg("starting gRPC gateway server")
+		if serveErr := gatewayServer.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			s.logger.Error().Err(serveErr).Msg("gRPC gateway server stopped")
+		}
+	}()
+}
+
+// httpHandler routes the websocket connections and, when guest access is enabled, the guest token requests.
+func (s *Service) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.config.HubWebsocketServer.Path, s.httpWebsocketHub.Handle)
+	if s.guestTokenIssuer != nil {
+		mux.Handle(s.config.HubWebsocketServer.GuestTokenPath, chathttp.NewGuestTokenHandler(s.guestTokenIssuer, *s.logger))
+	}
+	return mux
+}
+
+// initAuthenticator combines every authentication method enabled in the config.
+func (s *Service) initAuthenticator() (auth.Authenticator, error) {
+	cfg := s.config.Auth
+
+	var authenticators []auth.Authenticator
+	if cfg.JWKSFile != "" {
+		keys, err := auth.LoadJWKSFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []auth.JWTOption
+		if cfg.JWTIssuer != "" {
+			opts = append(opts, auth.WithIssuer(cfg.JWTIssuer))
+		}
+		if cfg.JWTAudience != "" {
+			opts = append(opts, auth.WithAudience(cfg.JWTAudience))
+		}
+		authenticators = append(authenticators, auth.NewJWTAuthenticator(keys, opts...))
+	}
+
+	if cfg.IntrospectionURL != "" {
+		authenticators = append(authenticators, auth.NewIntrospectionAuthenticator(
+			auth.NewHTTPTokenIntrospector(cfg.IntrospectionURL, cfg.IntrospectionClientID, cfg.IntrospectionClientSecret),
+		))
+	}
+
+	if cfg.GuestEnabled {
+		secret := []byte(cfg.GuestSecret)
+		if len(secret) == 0 {
+			s.logger.Warn().Msg("no guest secret configured, guest tokens will only be valid on this instance until it restarts")
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return nil, err
+			}
+		}
+
+		s.guestTokenIssuer = auth.NewGuestTokenIssuer(s.config.ServiceName, secret, cfg.GuestTokenTTL)
+		authenticators = append(authenticators, s.guestTokenIssuer)
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("no authentication method enabled")
+	}
+	return auth.Chain(authenticators...), nil
+}
+
 func (s *Service) shutdown() {
 	s.logger.Info().Msg("shutting down chat service...")
 	for _, closer := range s.closers {
@@ -156,30 +280,85 @@ func NewService(opts ...InitOption) (*Service, error) {
 		return nil, err
 	}
 
-	matchRequester := chatnats.NewMatchRequester(svc.config.NotificationStreamConfig.Name, js)
+	blockListRepo, err := chatnats.NewBlockListRepository(ctx, js)
+	if err != nil {
+		return nil, err
+	}
+
+	matchRequester := chatnats.NewMatchRequester(svc.config.NotificationStreamConfig.Name, js, blockListRepo)
+
+	roomRepo, err := chatnats.NewRoomRepository(ctx, js)
+	if err != nil {
+		return nil, err
+	}
+	svc.roomRepository = roomRepo
+
+	messagePublisher := chatnats.NewMessagePublisher(svc.natsConnection)
+	userNotifier := chatnats.NewUserNotifier(svc.natsConnection)
 
 	var cmdCloser func()
-	svc.cmdbus, cmdCloser, err = chatcommands.InitCommandBus(ctx, chatSessionRepo, matchRequester, *svc.logger)
+	svc.cmdbus, cmdCloser, err = chatcommands.InitCommandBus(
+		ctx,
+		chatSessionRepo,
+		roomRepo,
+		blockListRepo,
+		matchRequester,
+		messagePublisher,
+		userNotifier,
+		*svc.logger,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize command bus: %w", err)
 	}
 	svc.registerCloser(cmdCloser)
 
-	svc.querybus, _, err = chatqueries.InitQueryBus(ctx)
+	matchmakingConn, err := grpc.NewClient(
+		svc.config.MatchmakingGrpcAPI.Addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create matchmaking gRPC client: %w", err)
+	}
+	svc.registerCloser(func() { _ = matchmakingConn.Close() })
+
+	var qryCloser func()
+	svc.querybus, qryCloser, err = chatqueries.InitQueryBus(
+		ctx,
+		blockListRepo,
+		chatgrpc.NewMatchHistoryClient(matchmakingConn),
+		*svc.logger,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize query bus: %w", err)
 	}
+	svc.registerCloser(qryCloser)
 
 	matchNotificationsConsumer, err := svc.initMatchNotificationsConsumer(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize match notifications consumer: %w", err)
 	}
 
+	queueStatusConsumer, err := svc.initQueueStatusConsumer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize queue status consumer: %w", err)
+	}
+
+	svc.messageSubscriber = chatnats.NewMessageSubscriber(svc.natsConnection, *svc.logger)
+
+	authenticator, err := svc.initAuthenticator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize authenticator: %w", err)
+	}
+
 	svc.httpWebsocketHub = chathttp.NewHub(
 		svc.cmdbus,
 		svc.querybus,
 		matchNotificationsConsumer,
 		chathttp.WithLogger(*svc.logger),
+		chathttp.WithMessageSubscriber(svc.messageSubscriber),
+		chathttp.WithQueueStatusConsumer(queueStatusConsumer),
+		chathttp.WithAuthenticator(authenticator),
+		chathttp.WithConfig(&svc.config.HubWebsocketServer),
 	)
 
 	return svc, nil
@@ -206,7 +385,6 @@ func (s *Service) setupNatsConnection(config chatconfig.Config) error {
 		nats.PingInterval(10*time.Second),
 		nats.MaxPingsOutstanding(3),
 		nats.Timeout(5*time.Second),
-		nats.NoEcho(),
 		nats.ReconnectJitter(50*time.Millisecond, 1*time.Second),
 		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
 			s.logger.Error().Err(err).Msg("NATS error")
@@ -264,6 +442,26 @@ func (s *Service) initMatchNotificationsConsumer(ctx context.Context) (*xnats.Me
 	return chatNotificationConsumer, nil
 }
 
+// initQueueStatusConsumer consumes the queue statuses, which are sent again shortly,
+// so they are delivered once and never retried.
+func (s *Service) initQueueStatusConsumer(ctx context.Context) (*xnats.MessagingEventConsumer, error) {
+	return xnats.CreateMessagingEventConsumer(
+		ctx,
+		s.natsConnection,
+		s.logger,
+		s.config.QueueStatusConsumerConfig.StreamName,
+		jetstream.ConsumerConfig{
+			Name:          s.config.QueueStatusConsumerConfig.Name,
+			Durable:       s.config.QueueStatusConsumerConfig.Name,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			DeliverPolicy: jetstream.DeliverNewPolicy,
+			AckWait:       5 * time.Second,
+			MaxDeliver:    1,
+			MaxAckPending: 50,
+		},
+	)
+}
+
 func (s *Service) registerCloser(closer func()) {
 	if s.closers == nil {
 		s.closers = make([]func(), 0)
@@ -3,6 +3,7 @@ package matchcommands
 import (
 	"github.com/xfrr/go-cqrsify/messaging"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -12,9 +13,29 @@ type MatchUserWithPreferencesCommand struct {
 	UserID          string                  `json:"user_id"`
 	UserAge         int32                   `json:"user_age"`
 	UserGender      gender.Gender           `json:"user_gender"`
+	UserLocation    *location.Location      `json:"user_location,omitempty"`
 	UserPreferences matchmaking.Preferences `json:"user_match_preferences"`
 }
 
+// NewMatchUserWithPreferencesCommand creates a new MatchUserWithPreferencesCommand
+// routed to the MatchUserWithPreferencesCommandType handler.
+func NewMatchUserWithPreferencesCommand(
+	userID string,
+	userAge int32,
+	userGender gender.Gender,
+	userLocation *location.Location,
+	userPreferences matchmaking.Preferences,
+) MatchUserWithPreferencesCommand {
+	return MatchUserWithPreferencesCommand{
+		BaseCommand:     messaging.NewBaseCommand(MatchUserWithPreferencesCommandType),
+		UserID:          userID,
+		UserAge:         userAge,
+		UserGender:      userGender,
+		UserLocation:    userLocation,
+		UserPreferences: userPreferences,
+	}
+}
+
 type MatchUserWithPreferencesResponse struct {
 	MatchID string `json:"match_id"`
 }
@@ -40,6 +40,7 @@ func (h *MatchmakingCommandHandler) Handle(ctx context.Context, cmd MatchUserWit
 		cmd.UserAge,
 		cmd.UserGender,
 		cmd.UserPreferences,
+		matchdomain.WithLocation(cmd.UserLocation),
 	)
 
 	err := h.matchmakingService.ProcessMatchRequest(ctx, *requesterUser)
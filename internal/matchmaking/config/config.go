@@ -13,6 +13,10 @@ type Config struct {
 	LoggingConfig                   `envPrefix:"LOGGING_"`
 	NatsConfig                      `envPrefix:"NATS_"`
 	ChatNotificationsConsumerConfig `envPrefix:"CHAT_NOTIFICATIONS_CONSUMER_"`
+	GrpcAPIServer                   `envPrefix:"GRPC_API_SERVER_"`
+	Matchmaker                      `envPrefix:"MATCHMAKER_"`
+	MatchHistory                    `envPrefix:"MATCH_HISTORY_"`
+	Reputation                      `envPrefix:"REPUTATION_"`
 }
 
 func MustLoadFromEnv() Config {
@@ -0,0 +1,11 @@
+package matchmakingconfig
+
+// GrpcAPIServer holds the configuration for the matchmaking gRPC API and its HTTP gateway.
+type GrpcAPIServer struct {
+	// Addr is the address the gRPC server will listen on.
+	Addr string `env:"ADDR" default:"0.0.0.0:50000"`
+	// GatewayAddr is the address the grpc-gateway HTTP server will listen on.
+	GatewayAddr string `env:"GATEWAY_ADDR" default:"0.0.0.0:50001"`
+	// FindMatchTimeoutSeconds is the maximum time FindMatch waits for a match before returning.
+	FindMatchTimeoutSeconds int `env:"FIND_MATCH_TIMEOUT_SECONDS" default:"30"`
+}
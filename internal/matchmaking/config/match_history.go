@@ -0,0 +1,9 @@
+package matchmakingconfig
+
+// MatchHistory holds the configuration of the per-user match history projection.
+type MatchHistory struct {
+	// ConsumerName is the durable consumer projecting the created matches into the history.
+	ConsumerName string `env:"CONSUMER_NAME" default:"randomtalk_matchmaking_match_history_projector"`
+	// MaxEntries is the number of the most recent matches kept per user. Zero keeps every match.
+	MaxEntries int `env:"MAX_ENTRIES" default:"100"`
+}
@@ -0,0 +1,156 @@
+package matchmakingconfig
+
+import (
+	"time"
+
+	"github.com/caarlos0/env/v11"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+// The algorithms used to find the matches among the waiting users.
+const (
+	// GaleShapleyAlgorithm matches two disjoint sets of users.
+	GaleShapleyAlgorithm = "gale_shapley"
+	// StableRoommatesAlgorithm matches the users of a single pool with each other.
+	StableRoommatesAlgorithm = "stable_roommates"
+	// MaxCardinalityAlgorithm matches as many users as possible, regardless of stability.
+	MaxCardinalityAlgorithm = "max_cardinality"
+	// MaxWeightAlgorithm maximizes the total score of the matches, regardless of stability.
+	// It is O(n³) in the waiting users, so larger pools are matched in buckets of 500 users.
+	MaxWeightAlgorithm = "max_weight"
+)
+
+// Matchmaker holds the configuration of the matchmaking rules.
+type Matchmaker struct {
+	// Algorithm is the matching algorithm: "gale_shapley", "stable_roommates",
+	// "max_cardinality" or "max_weight".
+	// "max_weight" runs the Hungarian algorithm in O(n³) over the waiting pool: a round of
+	// 1k users takes ~90ms. Above that, the total score is only maximized within buckets of
+	// 500 users of each half, and a round of 10k users takes ~1s.
+	Algorithm string `env:"ALGORITHM" default:"gale_shapley"`
+	// Mode is when the waiting users are matched: "immediate" on every request,
+	// or "batch" in periodic rounds over the whole waiting pool.
+	Mode string `env:"MODE" default:"immediate"`
+	// BatchInterval is how often the batch matching rounds run.
+	BatchInterval time.Duration `env:"BATCH_INTERVAL" default:"500ms"`
+	// BatchPoolSize runs a batch matching round as soon as the waiting pool reaches it. Zero disables it.
+	BatchPoolSize int `env:"BATCH_POOL_SIZE" default:"50"`
+
+	// SkipCooldown is how long two users are not matched again after one of them skips the other.
+	SkipCooldown time.Duration `env:"SKIP_COOLDOWN" default:"5m"`
+
+	// ProposalTimeout is how long both users have to accept a match before it is cancelled.
+	// Expired proposals are cancelled when the waiting users are processed.
+	// Zero disables the proposals, the users start talking as soon as they are matched.
+	ProposalTimeout time.Duration `env:"PROPOSAL_TIMEOUT" default:"0s"`
+
+	// MaxWaitTime is how long the users wait for a match when they do not set their own,
+	// and the cap of the ones they set. Zero lets the users wait indefinitely.
+	MaxWaitTime time.Duration `env:"MAX_WAIT_TIME" default:"2m"`
+	// WaitingUsersInterval is how often the waiting users are released or matched again.
+	WaitingUsersInterval time.Duration `env:"WAITING_USERS_INTERVAL" default:"5s"`
+
+	// QueueStatusInterval is how often the waiting users are told their queue status. Zero disables it.
+	QueueStatusInterval time.Duration `env:"QUEUE_STATUS_INTERVAL" default:"10s"`
+	// MatchRateWindow is how far back the matches are counted to estimate the wait of the users.
+	MatchRateWindow time.Duration `env:"MATCH_RATE_WINDOW" default:"10m"`
+
+	// RelaxationInterval is the waiting time between two relaxation steps. Zero disables the relaxation.
+	RelaxationInterval time.Duration `env:"RELAXATION_INTERVAL" default:"30s"`
+	// RelaxationMaxSteps caps the number of relaxation steps. Zero means no cap.
+	RelaxationMaxSteps int `env:"RELAXATION_MAX_STEPS" default:"5"`
+	// RelaxationAgeRangeStep widens the accepted age range on both ends at every step.
+	RelaxationAgeRangeStep int32 `env:"RELAXATION_AGE_RANGE_STEP" default:"2"`
+	// RelaxationDistanceStepFactor multiplies the max distance at every step.
+	RelaxationDistanceStepFactor float64 `env:"RELAXATION_DISTANCE_STEP_FACTOR" default:"1.5"`
+	// RelaxationDropInterestsAfterSteps ignores the interests from the given step on. Zero keeps them.
+	RelaxationDropInterestsAfterSteps int `env:"RELAXATION_DROP_INTERESTS_AFTER_STEPS" default:"2"`
+
+	// ScoreInterestsWeight is the weight of the interests overlap when ranking candidates.
+	ScoreInterestsWeight float64 `env:"SCORE_INTERESTS_WEIGHT" default:"1"`
+	// ScoreAgeWeight is the weight of the age closeness when ranking candidates.
+	ScoreAgeWeight float64 `env:"SCORE_AGE_WEIGHT" default:"1"`
+	// ScoreDistanceWeight is the weight of the distance when ranking candidates.
+	ScoreDistanceWeight float64 `env:"SCORE_DISTANCE_WEIGHT" default:"1"`
+	// ScoreWaitTimeWeight is the weight of the time the candidates have been waiting.
+	ScoreWaitTimeWeight float64 `env:"SCORE_WAIT_TIME_WEIGHT" default:"0.5"`
+	// ScoreReputationWeight is the weight of the reputation closeness when ranking candidates.
+	ScoreReputationWeight float64 `env:"SCORE_REPUTATION_WEIGHT" default:"1"`
+}
+
+// DefaultMatchmaker returns the Matchmaker configuration with the default values,
+// regardless of the environment.
+func DefaultMatchmaker() Matchmaker {
+	m, err := env.ParseAsWithOptions[Matchmaker](env.Options{
+		Environment:         map[string]string{},
+		TagName:             "env",
+		DefaultValueTagName: "default",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MatchingMode returns the configured matching mode.
+func (m Matchmaker) MatchingMode() matchdomain.MatchingMode {
+	return matchdomain.ParseMatchingMode(m.Mode)
+}
+
+// WaitingUsersProcessingInterval returns how often the waiting users are processed.
+// In batch mode, it is the interval between the matching rounds.
+func (m Matchmaker) WaitingUsersProcessingInterval() time.Duration {
+	if m.MatchingMode() == matchdomain.BatchMatching {
+		return m.BatchInterval
+	}
+	return m.WaitingUsersInterval
+}
+
+// StableMatcher returns the configured matching algorithm, defaulting to Gale-Shapley.
+func (m Matchmaker) StableMatcher(scorer matchdomain.MatchScorer) matchdomain.StableMatchFinder {
+	switch m.Algorithm {
+	case StableRoommatesAlgorithm:
+		return matchdomain.NewStableRoommatesMatcher(matchdomain.WithRoommatesScorer(scorer))
+	case MaxCardinalityAlgorithm:
+		return matchdomain.NewMaximumMatcher(matchdomain.WithMaximumMatchingScorer(scorer))
+	case MaxWeightAlgorithm:
+		return matchdomain.NewMaximumMatcher(
+			matchdomain.WithMaximumMatchingScorer(scorer),
+			matchdomain.WithObjective(matchdomain.MaximizeWeight),
+		)
+	default:
+		return matchdomain.NewGaleShapleyStableMatcher(matchdomain.WithScorer(scorer))
+	}
+}
+
+// ScoreWeights returns the weights used to rank the candidates.
+func (m Matchmaker) ScoreWeights() matchdomain.ScoreWeights {
+	return matchdomain.ScoreWeights{
+		Interests:  m.ScoreInterestsWeight,
+		Age:        m.ScoreAgeWeight,
+		Distance:   m.ScoreDistanceWeight,
+		WaitTime:   m.ScoreWaitTimeWeight,
+		Reputation: m.ScoreReputationWeight,
+	}
+}
+
+// RelaxationPolicy returns the relaxation policy of the waiting users preferences.
+func (m Matchmaker) RelaxationPolicy() matchdomain.RelaxationPolicy {
+	return matchdomain.RelaxationPolicy{
+		Interval:                m.RelaxationInterval,
+		MaxSteps:                m.RelaxationMaxSteps,
+		AgeRangeStep:            m.RelaxationAgeRangeStep,
+		DistanceStepFactor:      m.RelaxationDistanceStepFactor,
+		DropInterestsAfterSteps: m.RelaxationDropInterestsAfterSteps,
+	}
+}
+
+// UserStoreTTL returns how long the waiting users are kept in the user store.
+// It outlives the max wait time, so the users are released by the matchmaker instead.
+func (m Matchmaker) UserStoreTTL() time.Duration {
+	if m.MaxWaitTime <= 0 {
+		return 0
+	}
+	return m.MaxWaitTime + 10*m.WaitingUsersInterval
+}
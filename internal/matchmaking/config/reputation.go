@@ -0,0 +1,20 @@
+package matchmakingconfig
+
+import matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+
+// Reputation holds the configuration of the users reputation built from their partners ratings.
+type Reputation struct {
+	// QuarantineScore is the reputation score below which the users are only matched
+	// with each other. Zero disables the quarantine.
+	QuarantineScore float64 `env:"QUARANTINE_SCORE" default:"0.2"`
+	// QuarantineMinRatings is the number of ratings a user needs before being quarantined.
+	QuarantineMinRatings int `env:"QUARANTINE_MIN_RATINGS" default:"10"`
+}
+
+// Policy returns the policy deciding which users are quarantined.
+func (r Reputation) Policy() matchdomain.ReputationPolicy {
+	return matchdomain.ReputationPolicy{
+		QuarantineScore: r.QuarantineScore,
+		MinRatings:      r.QuarantineMinRatings,
+	}
+}
@@ -0,0 +1,9 @@
+package matchdomain
+
+import "time"
+
+// StartWaiting exposes startWaiting to the external tests, which need users
+// that have been waiting for a given time.
+func (u *User) StartWaiting(at time.Time) {
+	u.startWaiting(at)
+}
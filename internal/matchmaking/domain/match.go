@@ -2,20 +2,64 @@ package matchdomain
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/xfrr/go-cqrsify/domain"
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
-// Match is a domain entity representing a successful pairing of two users.
+var (
+	// ErrMatchNotProposed is returned when responding to a match that is not waiting for the users to accept it.
+	ErrMatchNotProposed = domainerror.New("match is not waiting for a response")
+
+	// ErrMatchProposalExpired is returned when responding to a proposed match after its expiration time.
+	ErrMatchProposalExpired = domainerror.New("match proposal expired")
+
+	// ErrUserNotInMatch is returned when an user responds to a match it is not part of.
+	ErrUserNotInMatch = domainerror.New("user is not part of the match")
+)
+
+// MatchStatus represents the status of a match.
+type MatchStatus int
+
+const (
+	// MatchConfirmed is the status of the matches whose users can start talking.
+	MatchConfirmed MatchStatus = iota
+	// MatchProposed is the status of the matches waiting for both users to accept them.
+	MatchProposed
+	// MatchCancelled is the status of the proposed matches that were declined or expired.
+	MatchCancelled
+)
+
+func (s MatchStatus) String() string {
+	switch s {
+	case MatchProposed:
+		return "proposed"
+	case MatchCancelled:
+		return "cancelled"
+	}
+	return "confirmed"
+}
+
+// Match is a domain entity representing a successful pairing of two users,
+// or the group room of several users.
 type Match struct {
 	*domain.BaseAggregate[string]
 
 	requester *User
 	match     *User
+	// members are the participants of a group room other than the requester and the matched user.
+	members   []*User
 	createdAt time.Time
+
+	status     MatchStatus
+	expiresAt  time.Time
+	accepted   map[string]bool
+	declinedBy string
+	requeued   []string
 }
 
 func (m *Match) ID() string {
@@ -46,9 +90,154 @@ func (m *Match) Candidate() *User {
 	return m.match
 }
 
+// Participants returns every user of the match, the requester first.
+func (m *Match) Participants() []*User {
+	participants := make([]*User, 0, 2+len(m.members))
+	participants = append(participants, m.requester, m.match)
+	return append(participants, m.members...)
+}
+
+// IsGroup reports whether the match is a group room.
+func (m *Match) IsGroup() bool {
+	return len(m.members) > 0
+}
+
+// HasParticipant reports whether the user is part of the match.
+func (m *Match) HasParticipant(userID string) bool {
+	return slices.ContainsFunc(m.Participants(), func(u *User) bool { return u.ID() == userID })
+}
+
+// Status returns the current status of the match.
+func (m *Match) Status() MatchStatus {
+	return m.status
+}
+
+// ProposalExpiresAt returns when the proposed match is cancelled unless both users accept it.
+func (m *Match) ProposalExpiresAt() time.Time {
+	return m.expiresAt
+}
+
+// Accepted reports whether the user accepted the proposed match.
+func (m *Match) Accepted(userID string) bool {
+	return m.accepted[userID]
+}
+
+// DeclinedBy returns the user that declined the match, if any.
+func (m *Match) DeclinedBy() string {
+	return m.declinedBy
+}
+
+// RequeuedUsers returns the users that go back to the waiting pool after the match was cancelled.
+func (m *Match) RequeuedUsers() []*User {
+	users := make([]*User, 0, len(m.requeued))
+	for _, user := range m.Participants() {
+		if slices.Contains(m.requeued, user.ID()) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// Partners returns the other users of the match, every other member in a group room.
+func (m *Match) Partners(userID string) ([]*User, error) {
+	if !m.HasParticipant(userID) {
+		return nil, ErrUserNotInMatch
+	}
+
+	participants := m.Participants()
+	partners := make([]*User, 0, len(participants)-1)
+	for _, user := range participants {
+		if user.ID() != userID {
+			partners = append(partners, user)
+		}
+	}
+	return partners, nil
+}
+
+// Accept records that the user accepts the proposed match.
+// The match is confirmed once every user accepted it.
+func (m *Match) Accept(userID string, at time.Time) error {
+	if err := m.ensureProposalOpen(userID, at); err != nil {
+		return err
+	}
+
+	if m.accepted[userID] {
+		return nil
+	}
+
+	if err := domain.NextEvent(m, NewMatchAcceptedEvent(m, userID)); err != nil {
+		return err
+	}
+
+	for _, user := range m.Participants() {
+		if !m.accepted[user.ID()] {
+			return nil
+		}
+	}
+	return domain.NextEvent(m, NewMatchConfirmedEvent(m))
+}
+
+// Decline cancels the proposed match. Every user but the one that declined it goes back to the waiting pool.
+func (m *Match) Decline(userID string, at time.Time) error {
+	if err := m.ensureProposalOpen(userID, at); err != nil {
+		return err
+	}
+
+	requeued := make([]string, 0, 1+len(m.members))
+	for _, user := range m.Participants() {
+		if user.ID() != userID {
+			requeued = append(requeued, user.ID())
+		}
+	}
+	return domain.NextEvent(m, NewMatchCancelledEvent(m, MatchDeclinedReason, userID, requeued))
+}
+
+// Expire cancels the proposed match when it was not accepted in time.
+// Only the users that accepted it go back to the waiting pool.
+func (m *Match) Expire(at time.Time) error {
+	if m.status != MatchProposed {
+		return ErrMatchNotProposed
+	}
+
+	if at.Before(m.expiresAt) {
+		return nil
+	}
+
+	var requeued []string
+	for _, user := range m.Participants() {
+		if m.accepted[user.ID()] {
+			requeued = append(requeued, user.ID())
+		}
+	}
+	return domain.NextEvent(m, NewMatchCancelledEvent(m, MatchExpiredReason, "", requeued))
+}
+
+func (m *Match) ensureProposalOpen(userID string, at time.Time) error {
+	if m.status != MatchProposed {
+		return ErrMatchNotProposed
+	}
+
+	if !m.HasParticipant(userID) {
+		return ErrUserNotInMatch
+	}
+
+	if !at.Before(m.expiresAt) {
+		return ErrMatchProposalExpired
+	}
+	return nil
+}
+
 func (m *Match) registerEventHandlers() {
-	var matchCreatedEvent MatchCreatedEvent
+	var (
+		matchCreatedEvent   MatchCreatedEvent
+		matchAcceptedEvent  MatchAcceptedEvent
+		matchConfirmedEvent MatchConfirmedEvent
+		matchCancelledEvent MatchCancelledEvent
+	)
 	m.HandleEvent(matchCreatedEvent.EventName(), m.handleMatchCreatedEvent)
+	m.HandleEvent(matchAcceptedEvent.EventName(), m.handleMatchAcceptedEvent)
+	m.HandleEvent(matchConfirmedEvent.EventName(), m.handleMatchConfirmedEvent)
+	m.HandleEvent(matchCancelledEvent.EventName(), m.handleMatchCancelledEvent)
 }
 
 func (m *Match) handleMatchCreatedEvent(evt domain.Event) error {
@@ -58,20 +247,76 @@ func (m *Match) handleMatchCreatedEvent(evt domain.Event) error {
 	}
 
 	m.requester = &User{
-		id:     payload.MatchUserRequesterID,
-		age:    payload.MatchUserRequesterAge,
-		gender: payload.MatchUserRequesterGender,
-		prefs:  payload.MatchUserRequesterPreferences,
+		id:       payload.MatchUserRequesterID,
+		age:      payload.MatchUserRequesterAge,
+		gender:   payload.MatchUserRequesterGender,
+		prefs:    payload.MatchUserRequesterPreferences,
+		location: payload.MatchUserRequesterLocation,
+
+		blockedUserIDs: payload.MatchUserRequesterBlockedIDs,
+		languages:      payload.MatchUserRequesterLanguages,
+		reputation:     payload.MatchUserRequesterReputation,
+		quarantined:    payload.MatchUserRequesterQuarantined,
+		requestedAt:    payload.MatchUserRequesterRequestedAt,
 	}
 
 	m.match = &User{
-		id:     payload.MatchUserMatchedID,
-		age:    payload.MatchUserMatchedAge,
-		gender: payload.MatchUserMatchedGender,
-		prefs:  payload.MatchUserMatchedPreferences,
+		id:       payload.MatchUserMatchedID,
+		age:      payload.MatchUserMatchedAge,
+		gender:   payload.MatchUserMatchedGender,
+		prefs:    payload.MatchUserMatchedPreferences,
+		location: payload.MatchUserMatchedLocation,
+
+		blockedUserIDs: payload.MatchUserMatchedBlockedIDs,
+		languages:      payload.MatchUserMatchedLanguages,
+		reputation:     payload.MatchUserMatchedReputation,
+		quarantined:    payload.MatchUserMatchedQuarantined,
+		requestedAt:    payload.MatchUserMatchedRequestedAt,
+	}
+
+	m.members = make([]*User, 0, len(payload.MatchGroupMembers))
+	for _, member := range payload.MatchGroupMembers {
+		m.members = append(m.members, member.user())
 	}
 
-	m.createdAt = time.Now()
+	m.createdAt = evt.Timestamp()
+	m.expiresAt = payload.ProposalExpiresAt
+	m.accepted = make(map[string]bool, 2+len(m.members))
+	m.status = MatchConfirmed
+	if !m.expiresAt.IsZero() {
+		m.status = MatchProposed
+	}
+	return nil
+}
+
+func (m *Match) handleMatchAcceptedEvent(evt domain.Event) error {
+	payload, ok := evt.(*MatchAcceptedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event payload type: %T", evt)
+	}
+
+	m.accepted[payload.UserID] = true
+	return nil
+}
+
+func (m *Match) handleMatchConfirmedEvent(evt domain.Event) error {
+	if _, ok := evt.(*MatchConfirmedEvent); !ok {
+		return fmt.Errorf("unexpected event payload type: %T", evt)
+	}
+
+	m.status = MatchConfirmed
+	return nil
+}
+
+func (m *Match) handleMatchCancelledEvent(evt domain.Event) error {
+	payload, ok := evt.(*MatchCancelledEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event payload type: %T", evt)
+	}
+
+	m.status = MatchCancelled
+	m.declinedBy = payload.DeclinedBy
+	m.requeued = payload.RequeuedUserIDs
 	return nil
 }
 
@@ -88,8 +333,17 @@ func (m *Match) validate() error {
 		return ErrMatchCandidateNotProvided
 	}
 
-	if m.Requester().ID() == m.Candidate().ID() {
+	participants := m.Participants()
+	unique := make(map[string]struct{}, len(participants))
+	for _, user := range participants {
+		unique[user.ID()] = struct{}{}
+	}
+	if len(unique) != len(participants) {
 		return ErrUserCannotMatchWithItself
 	}
+
+	if m.IsGroup() && len(participants) != int(m.requester.Preferences().GroupSize) {
+		return ErrInvalidGroupMatchSize
+	}
 	return nil
 }
@@ -0,0 +1,23 @@
+package matchdomain
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// MatchAcceptedEvent is an event that is published when an user accepts a proposed match.
+type MatchAcceptedEvent struct {
+	domain.BaseEvent
+
+	MatchID string `json:"match_id"`
+	UserID  string `json:"user_id"`
+}
+
+func (e MatchAcceptedEvent) EventName() string {
+	return "match_accepted"
+}
+
+func NewMatchAcceptedEvent(match *Match, userID string) *MatchAcceptedEvent {
+	return &MatchAcceptedEvent{
+		BaseEvent: domain.NewEvent("match_accepted", domain.CreateEventAggregateRef(match)),
+		MatchID:   match.ID(),
+		UserID:    userID,
+	}
+}
@@ -0,0 +1,45 @@
+package matchdomain
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+const (
+	// MatchDeclinedReason is the reason a proposed match is cancelled with when an user declines it.
+	MatchDeclinedReason = "declined"
+
+	// MatchExpiredReason is the reason a proposed match is cancelled with when
+	// the users did not accept it in time.
+	MatchExpiredReason = "expired"
+)
+
+// MatchCancelledEvent is an event that is published when a proposed match is declined
+// or is not accepted in time.
+type MatchCancelledEvent struct {
+	domain.BaseEvent
+
+	MatchID              string `json:"match_id"`
+	MatchUserRequesterID string `json:"match_user_requester_id"`
+	MatchUserMatchedID   string `json:"match_user_matched_id"`
+	Reason               string `json:"reason"`
+
+	// DeclinedBy is the user that declined the match, if any.
+	DeclinedBy string `json:"declined_by,omitempty"`
+	// RequeuedUserIDs are the users that go back to the waiting pool.
+	// The rest of the users stop waiting for a match.
+	RequeuedUserIDs []string `json:"requeued_user_ids,omitempty"`
+}
+
+func (e MatchCancelledEvent) EventName() string {
+	return "match_cancelled"
+}
+
+func NewMatchCancelledEvent(match *Match, reason, declinedBy string, requeuedUserIDs []string) *MatchCancelledEvent {
+	return &MatchCancelledEvent{
+		BaseEvent:            domain.NewEvent("match_cancelled", domain.CreateEventAggregateRef(match)),
+		MatchID:              match.ID(),
+		MatchUserRequesterID: match.Requester().ID(),
+		MatchUserMatchedID:   match.Candidate().ID(),
+		Reason:               reason,
+		DeclinedBy:           declinedBy,
+		RequeuedUserIDs:      requeuedUserIDs,
+	}
+}
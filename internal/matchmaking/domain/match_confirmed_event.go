@@ -0,0 +1,25 @@
+package matchdomain
+
+import "github.com/xfrr/go-cqrsify/domain"
+
+// MatchConfirmedEvent is an event that is published when both users accepted a proposed match.
+type MatchConfirmedEvent struct {
+	domain.BaseEvent
+
+	MatchID              string `json:"match_id"`
+	MatchUserRequesterID string `json:"match_user_requester_id"`
+	MatchUserMatchedID   string `json:"match_user_matched_id"`
+}
+
+func (e MatchConfirmedEvent) EventName() string {
+	return "match_confirmed"
+}
+
+func NewMatchConfirmedEvent(match *Match) *MatchConfirmedEvent {
+	return &MatchConfirmedEvent{
+		BaseEvent:            domain.NewEvent("match_confirmed", domain.CreateEventAggregateRef(match)),
+		MatchID:              match.ID(),
+		MatchUserRequesterID: match.Requester().ID(),
+		MatchUserMatchedID:   match.Candidate().ID(),
+	}
+}
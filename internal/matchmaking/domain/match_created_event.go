@@ -1,8 +1,11 @@
 package matchdomain
 
 import (
+	"time"
+
 	"github.com/xfrr/go-cqrsify/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -15,11 +18,75 @@ type MatchCreatedEvent struct {
 	MatchUserRequesterAge         int32                   `json:"match_user_requester_age"`
 	MatchUserRequesterGender      gender.Gender           `json:"match_user_requester_gender"`
 	MatchUserRequesterPreferences matchmaking.Preferences `json:"match_user_requester_preferences"`
+	MatchUserRequesterLocation    *location.Location      `json:"match_user_requester_location,omitempty"`
+	MatchUserRequesterBlockedIDs  []string                `json:"match_user_requester_blocked_user_ids,omitempty"`
+	MatchUserRequesterLanguages   []string                `json:"match_user_requester_languages,omitempty"`
+	MatchUserRequesterReputation  *float64                `json:"match_user_requester_reputation,omitempty"`
+	MatchUserRequesterQuarantined bool                    `json:"match_user_requester_quarantined,omitempty"`
+	MatchUserRequesterRequestedAt time.Time               `json:"match_user_requester_requested_at,omitzero"`
 
 	MatchUserMatchedID          string                  `json:"match_user_matched_id"`
 	MatchUserMatchedAge         int32                   `json:"match_user_matched_age"`
 	MatchUserMatchedGender      gender.Gender           `json:"match_user_matched_gender"`
 	MatchUserMatchedPreferences matchmaking.Preferences `json:"match_user_matched_preferences"`
+	MatchUserMatchedLocation    *location.Location      `json:"match_user_matched_location,omitempty"`
+	MatchUserMatchedBlockedIDs  []string                `json:"match_user_matched_blocked_user_ids,omitempty"`
+	MatchUserMatchedLanguages   []string                `json:"match_user_matched_languages,omitempty"`
+	MatchUserMatchedReputation  *float64                `json:"match_user_matched_reputation,omitempty"`
+	MatchUserMatchedQuarantined bool                    `json:"match_user_matched_quarantined,omitempty"`
+	MatchUserMatchedRequestedAt time.Time               `json:"match_user_matched_requested_at,omitzero"`
+
+	// MatchGroupMembers are the participants of a group room other than the requester and the matched user.
+	MatchGroupMembers []MatchMember `json:"match_group_members,omitempty"`
+
+	// ProposalExpiresAt is when the proposed match is cancelled unless both users accepted it.
+	// It is zero when the match does not need to be accepted.
+	ProposalExpiresAt time.Time `json:"proposal_expires_at,omitzero"`
+}
+
+// MatchMember is a participant of a group room, as it was when the room was created.
+type MatchMember struct {
+	ID          string                  `json:"id"`
+	Age         int32                   `json:"age"`
+	Gender      gender.Gender           `json:"gender"`
+	Preferences matchmaking.Preferences `json:"preferences"`
+	Location    *location.Location      `json:"location,omitempty"`
+	BlockedIDs  []string                `json:"blocked_user_ids,omitempty"`
+	Languages   []string                `json:"languages,omitempty"`
+	Reputation  *float64                `json:"reputation,omitempty"`
+	Quarantined bool                    `json:"quarantined,omitempty"`
+	RequestedAt time.Time               `json:"requested_at,omitzero"`
+}
+
+func newMatchMember(u User) MatchMember {
+	return MatchMember{
+		ID:          u.ID(),
+		Age:         u.Age(),
+		Gender:      u.Gender(),
+		Preferences: u.Preferences(),
+		Location:    u.Location(),
+		BlockedIDs:  u.BlockedUserIDs(),
+		Languages:   u.Languages(),
+		Reputation:  u.reputation,
+		Quarantined: u.Quarantined(),
+		RequestedAt: u.RequestedAt(),
+	}
+}
+
+func (m MatchMember) user() *User {
+	return &User{
+		id:       m.ID,
+		age:      m.Age,
+		gender:   m.Gender,
+		prefs:    m.Preferences,
+		location: m.Location,
+
+		blockedUserIDs: m.BlockedIDs,
+		languages:      m.Languages,
+		reputation:     m.Reputation,
+		quarantined:    m.Quarantined,
+		requestedAt:    m.RequestedAt,
+	}
 }
 
 func (e MatchCreatedEvent) EventName() string {
@@ -30,6 +97,7 @@ func NewMatchCreatedEvent(
 	match *Match,
 	requesterUser User,
 	matchedUser User,
+	proposalExpiresAt time.Time,
 ) *MatchCreatedEvent {
 	return &MatchCreatedEvent{
 		BaseEvent:                     domain.NewEvent("match_created", domain.CreateEventAggregateRef(match)),
@@ -38,9 +106,22 @@ func NewMatchCreatedEvent(
 		MatchUserRequesterAge:         requesterUser.Age(),
 		MatchUserRequesterGender:      requesterUser.Gender(),
 		MatchUserRequesterPreferences: requesterUser.Preferences(),
+		MatchUserRequesterLocation:    requesterUser.Location(),
+		MatchUserRequesterBlockedIDs:  requesterUser.BlockedUserIDs(),
+		MatchUserRequesterLanguages:   requesterUser.Languages(),
+		MatchUserRequesterReputation:  requesterUser.reputation,
+		MatchUserRequesterQuarantined: requesterUser.Quarantined(),
+		MatchUserRequesterRequestedAt: requesterUser.RequestedAt(),
 		MatchUserMatchedID:            matchedUser.ID(),
 		MatchUserMatchedAge:           matchedUser.Age(),
 		MatchUserMatchedGender:        matchedUser.Gender(),
 		MatchUserMatchedPreferences:   matchedUser.Preferences(),
+		MatchUserMatchedLocation:      matchedUser.Location(),
+		MatchUserMatchedBlockedIDs:    matchedUser.BlockedUserIDs(),
+		MatchUserMatchedLanguages:     matchedUser.Languages(),
+		MatchUserMatchedReputation:    matchedUser.reputation,
+		MatchUserMatchedQuarantined:   matchedUser.Quarantined(),
+		MatchUserMatchedRequestedAt:   matchedUser.RequestedAt(),
+		ProposalExpiresAt:             proposalExpiresAt,
 	}
 }
@@ -1,9 +1,12 @@
 package matchdomain
 
 import (
+	"time"
+
 	"github.com/xfrr/go-cqrsify/domain"
 
 	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
 const EventSourceName = "randomtalk.matchmaking"
@@ -25,14 +28,29 @@ var (
 	// ErrMatchCandidatePreferencesNotProvided is returned when the match  candidate preferences are not provided.
 	ErrMatchCandidatePreferencesNotProvided = domainerror.New("match candidate preferences not provided")
 
+	// ErrInvalidGroupMatchSize is returned when a group room has not as many users as its users asked for.
+	ErrInvalidGroupMatchSize = domainerror.New("invalid group match size")
+
 	// ErrUserCannotMatchWithItself is returned when the match  requester tries to match with itself.
 	ErrUserCannotMatchWithItself = domainerror.New("user cannot match with itself")
 )
 
+// NewMatch creates a confirmed match, the users can start talking right away.
 func NewMatch(
 	msid MatchID,
 	requesterUser User,
 	matchedUser User,
+) (*Match, error) {
+	return NewProposedMatch(msid, requesterUser, matchedUser, time.Time{})
+}
+
+// NewProposedMatch creates a match that both users must accept before the given expiration time.
+// A zero expiration time creates a confirmed match.
+func NewProposedMatch(
+	msid MatchID,
+	requesterUser User,
+	matchedUser User,
+	expiresAt time.Time,
 ) (*Match, error) {
 	match := newMatch(msid)
 
@@ -40,6 +58,7 @@ func NewMatch(
 		match,
 		requesterUser,
 		matchedUser,
+		expiresAt,
 	)
 
 	err := domain.NextEvent(match, event)
@@ -54,6 +73,31 @@ func NewMatch(
 	return match, nil
 }
 
+// NewGroupMatch creates the confirmed match of a group room, the users can start talking right away.
+// The first user is recorded as the requester.
+func NewGroupMatch(msid MatchID, users ...User) (*Match, error) {
+	if len(users) < int(matchmaking.MinGroupSize) {
+		return nil, ErrInvalidGroupMatchSize
+	}
+
+	match := newMatch(msid)
+
+	event := NewMatchCreatedEvent(match, users[0], users[1], time.Time{})
+	for _, user := range users[2:] {
+		event.MatchGroupMembers = append(event.MatchGroupMembers, newMatchMember(user))
+	}
+
+	if err := domain.NextEvent(match, event); err != nil {
+		return nil, err
+	}
+
+	if validateErr := match.validate(); validateErr != nil {
+		return nil, validateErr
+	}
+
+	return match, nil
+}
+
 func NewMatchFromEvents(id MatchID, events ...domain.Event) (*Match, error) {
 	match := newMatch(id)
 
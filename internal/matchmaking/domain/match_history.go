@@ -0,0 +1,134 @@
+package matchdomain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	domainerror "github.com/xfrr/randomtalk/internal/shared/domain"
+)
+
+var (
+	// ErrInvalidMatchHistoryCursor is returned when the cursor does not point to a match of the user history.
+	ErrInvalidMatchHistoryCursor = domainerror.New("invalid match history cursor")
+)
+
+const (
+	// DefaultMatchHistoryLimit is the number of matches listed when no limit is given.
+	DefaultMatchHistoryLimit = 20
+	// MaxMatchHistoryLimit is the maximum number of matches listed at once.
+	MaxMatchHistoryLimit = 100
+)
+
+// MatchHistoryEntry is a match in the history of a user.
+type MatchHistoryEntry struct {
+	MatchID   string    `json:"match_id"`
+	PartnerID string    `json:"partner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// MemberIDs are the other participants of a group room. The PartnerID is empty then.
+	MemberIDs []string `json:"member_ids,omitempty"`
+}
+
+// MatchHistoryPage is a page of the history of a user, from the most recent match.
+type MatchHistoryPage struct {
+	Entries []MatchHistoryEntry
+	// NextCursor lists the following page. It is empty on the last page.
+	NextCursor string
+}
+
+// MatchHistory is the read model indexing the matches of every user.
+type MatchHistory interface {
+	// AddMatch adds the match to the history of the user. Adding a match twice has no effect.
+	AddMatch(ctx context.Context, userID string, entry MatchHistoryEntry) error
+
+	// ListMatchesByUser lists the matches of the user from the most recent one,
+	// starting after the match of the cursor, if any.
+	ListMatchesByUser(ctx context.Context, userID, cursor string, limit int) (MatchHistoryPage, error)
+
+	// GetLastMatch retrieves the most recent match of the user.
+	GetLastMatch(ctx context.Context, userID string) (MatchHistoryEntry, error)
+}
+
+// MatchHistoryEntries returns the history entries of every user of the created match, by user ID.
+func MatchHistoryEntries(evt *MatchCreatedEvent, createdAt time.Time) map[string]MatchHistoryEntry {
+	if len(evt.MatchGroupMembers) > 0 {
+		return groupMatchHistoryEntries(evt, createdAt)
+	}
+
+	return map[string]MatchHistoryEntry{
+		evt.MatchUserRequesterID: {MatchID: evt.MatchID, PartnerID: evt.MatchUserMatchedID, CreatedAt: createdAt},
+		evt.MatchUserMatchedID:   {MatchID: evt.MatchID, PartnerID: evt.MatchUserRequesterID, CreatedAt: createdAt},
+	}
+}
+
+func groupMatchHistoryEntries(evt *MatchCreatedEvent, createdAt time.Time) map[string]MatchHistoryEntry {
+	userIDs := []string{evt.MatchUserRequesterID, evt.MatchUserMatchedID}
+	for _, member := range evt.MatchGroupMembers {
+		userIDs = append(userIDs, member.ID)
+	}
+
+	entries := make(map[string]MatchHistoryEntry, len(userIDs))
+	for i, userID := range userIDs {
+		memberIDs := slices.Concat(userIDs[:i], userIDs[i+1:])
+		entries[userID] = MatchHistoryEntry{MatchID: evt.MatchID, CreatedAt: createdAt, MemberIDs: memberIDs}
+	}
+	return entries
+}
+
+// UserMatchHistory is the history of a user, sorted from the most recent match.
+type UserMatchHistory []MatchHistoryEntry
+
+// Add returns the history with the entry in place, keeping up to maxEntries of the most recent matches.
+// A zero maxEntries keeps every match. It reports false when the match was already in the history.
+func (h UserMatchHistory) Add(entry MatchHistoryEntry, maxEntries int) (UserMatchHistory, bool) {
+	if slices.ContainsFunc(h, func(e MatchHistoryEntry) bool { return e.MatchID == entry.MatchID }) {
+		return h, false
+	}
+
+	idx, _ := slices.BinarySearchFunc(h, entry, func(e, target MatchHistoryEntry) int {
+		// sorted in descending order of creation, the last added first on ties
+		if target.CreatedAt.Before(e.CreatedAt) {
+			return -1
+		}
+		return 1
+	})
+	h = slices.Insert(slices.Clone(h), idx, entry)
+
+	if maxEntries > 0 && len(h) > maxEntries {
+		h = h[:maxEntries]
+	}
+	return h, true
+}
+
+// Last returns the most recent match of the history.
+func (h UserMatchHistory) Last() (MatchHistoryEntry, error) {
+	if len(h) == 0 {
+		return MatchHistoryEntry{}, ErrMatchNotFound
+	}
+	return h[0], nil
+}
+
+// Page returns up to limit matches following the match of the cursor.
+// The limit defaults to DefaultMatchHistoryLimit and is capped at MaxMatchHistoryLimit.
+func (h UserMatchHistory) Page(cursor string, limit int) (MatchHistoryPage, error) {
+	if limit <= 0 {
+		limit = DefaultMatchHistoryLimit
+	}
+	limit = min(limit, MaxMatchHistoryLimit)
+
+	start := 0
+	if cursor != "" {
+		idx := slices.IndexFunc(h, func(e MatchHistoryEntry) bool { return e.MatchID == cursor })
+		if idx == -1 {
+			return MatchHistoryPage{}, ErrInvalidMatchHistoryCursor
+		}
+		start = idx + 1
+	}
+
+	end := min(start+limit, len(h))
+	page := MatchHistoryPage{Entries: slices.Clone(h[start:end])}
+	if end < len(h) {
+		page.NextCursor = h[end-1].MatchID
+	}
+	return page, nil
+}
@@ -0,0 +1,80 @@
+package matchdomain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+func TestUserMatchHistory(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(matchID string, minutes int) matchdomain.MatchHistoryEntry {
+		return matchdomain.MatchHistoryEntry{
+			MatchID:   matchID,
+			PartnerID: "partner-" + matchID,
+			CreatedAt: createdAt.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+
+	matchIDs := func(entries []matchdomain.MatchHistoryEntry) []string {
+		ids := make([]string, 0, len(entries))
+		for _, e := range entries {
+			ids = append(ids, e.MatchID)
+		}
+		return ids
+	}
+
+	t.Run("should keep the matches sorted from the most recent one", func(t *testing.T) {
+		var history matchdomain.UserMatchHistory
+		history, _ = history.Add(entry("m1", 1), 0)
+		history, _ = history.Add(entry("m3", 3), 0)
+		history, _ = history.Add(entry("m2", 2), 0)
+
+		require.Equal(t, []string{"m3", "m2", "m1"}, matchIDs(history))
+
+		last, err := history.Last()
+		require.NoError(t, err)
+		require.Equal(t, "m3", last.MatchID)
+	})
+
+	t.Run("should ignore duplicated matches and drop the oldest ones", func(t *testing.T) {
+		var history matchdomain.UserMatchHistory
+		history, _ = history.Add(entry("m1", 1), 2)
+		history, _ = history.Add(entry("m2", 2), 2)
+
+		history, added := history.Add(entry("m2", 2), 2)
+		require.False(t, added)
+
+		history, added = history.Add(entry("m3", 3), 2)
+		require.True(t, added)
+		require.Equal(t, []string{"m3", "m2"}, matchIDs(history))
+	})
+
+	t.Run("should page the matches after the cursor", func(t *testing.T) {
+		var history matchdomain.UserMatchHistory
+		for i, matchID := range []string{"m1", "m2", "m3"} {
+			history, _ = history.Add(entry(matchID, i), 0)
+		}
+
+		page, err := history.Page("", 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"m3", "m2"}, matchIDs(page.Entries))
+		require.Equal(t, "m2", page.NextCursor)
+
+		page, err = history.Page(page.NextCursor, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"m1"}, matchIDs(page.Entries))
+		require.Empty(t, page.NextCursor)
+
+		_, err = history.Page("unknown", 2)
+		require.ErrorIs(t, err, matchdomain.ErrInvalidMatchHistoryCursor)
+	})
+
+	t.Run("should fail to get the last match of an empty history", func(t *testing.T) {
+		_, err := matchdomain.UserMatchHistory(nil).Last()
+		require.ErrorIs(t, err, matchdomain.ErrMatchNotFound)
+	})
+}
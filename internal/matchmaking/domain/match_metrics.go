@@ -0,0 +1,16 @@
+package matchdomain
+
+import (
+	"context"
+	"time"
+)
+
+// MatchMetrics records the quality of the created matches and how long the users waited for them,
+// so the matching modes can be compared.
+type MatchMetrics interface {
+	// RecordMatch records a created match, its score and the time each user waited for it.
+	RecordMatch(ctx context.Context, mode MatchingMode, score float64, waited ...time.Duration)
+
+	// RecordRound records a batch matching round over the given pool size.
+	RecordRound(ctx context.Context, mode MatchingMode, poolSize, matches int, duration time.Duration)
+}
@@ -10,10 +10,13 @@ var (
 	ErrMatchAlreadyExists = domainerror.New("match already exists")
 	ErrMatchNotFound      = domainerror.New("match not found")
 	ErrNoActiveUsers      = domainerror.New("no active users available")
+	// ErrMatchVersionConflict is returned when saving a match that was changed since it was found.
+	ErrMatchVersionConflict = domainerror.New("match was changed concurrently")
 )
 
 type MatchRepository interface {
-	// Save persists a match .
+	// Save persists the changes of a match. It fails with ErrMatchVersionConflict
+	// when the match was changed since it was found, so the change can be retried.
 	Save(ctx context.Context, match *Match) error
 
 	// FindByID retrieves a match  by its ID.
@@ -0,0 +1,152 @@
+package matchdomain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// ageClosenessScale is the age gap, in years, that halves the age score.
+	ageClosenessScale = 10.0
+	// distanceScaleKm is the distance that halves the distance score.
+	distanceScaleKm = 50.0
+	// waitTimeScale is the waiting time that scores half of the wait time score.
+	waitTimeScale = time.Minute
+)
+
+// MatchScorer ranks the candidates a user can be matched with.
+// The higher the score, the more the user prefers the candidate.
+type MatchScorer interface {
+	Score(user, candidate *User) float64
+}
+
+// ScoreWeights holds the weight of every criterion of the WeightedScorer.
+// A zero weight ignores the criterion.
+type ScoreWeights struct {
+	Interests float64
+	Age       float64
+	Distance  float64
+	WaitTime  float64
+	// Reputation favors the candidates whose reputation is close to the user's one.
+	Reputation float64
+}
+
+// DefaultScoreWeights returns the default weights of the WeightedScorer.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Interests:  1,
+		Age:        1,
+		Distance:   1,
+		WaitTime:   0.5,
+		Reputation: 1,
+	}
+}
+
+var _ MatchScorer = (*WeightedScorer)(nil)
+
+// WeightedScorer scores candidates by interest overlap, age closeness, distance,
+// reputation closeness and the time the candidate has been waiting, each criterion
+// normalized to [0, 1].
+type WeightedScorer struct {
+	weights ScoreWeights
+	now     func() time.Time
+}
+
+// WeightedScorerOption defines a functional option to configure the WeightedScorer.
+type WeightedScorerOption func(*WeightedScorer)
+
+// WithScorerClock overrides the function used to get the current time.
+func WithScorerClock(now func() time.Time) WeightedScorerOption {
+	return func(s *WeightedScorer) {
+		s.now = now
+	}
+}
+
+// NewWeightedScorer creates a WeightedScorer with the given weights.
+func NewWeightedScorer(weights ScoreWeights, opts ...WeightedScorerOption) *WeightedScorer {
+	s := &WeightedScorer{
+		weights: weights,
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Score implements MatchScorer.
+func (s *WeightedScorer) Score(user, candidate *User) float64 {
+	return s.weights.Interests*interestsScore(user, candidate) +
+		s.weights.Age*ageScore(user, candidate) +
+		s.weights.Distance*distanceScore(user, candidate) +
+		s.weights.WaitTime*s.waitTimeScore(candidate) +
+		s.weights.Reputation*reputationScore(user, candidate)
+}
+
+// interestsScore is the Jaccard index of the users interests.
+func interestsScore(user, candidate *User) float64 {
+	a, b := user.Preferences().Interests, candidate.Preferences().Interests
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, interest := range a {
+		set[interest] = true
+	}
+
+	shared := 0
+	union := len(set)
+	seen := make(map[string]bool, len(b))
+	for _, interest := range b {
+		if seen[interest] {
+			continue
+		}
+		seen[interest] = true
+
+		if set[interest] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
+
+func ageScore(user, candidate *User) float64 {
+	gap := float64(user.Age() - candidate.Age())
+	if gap < 0 {
+		gap = -gap
+	}
+	return 1 / (1 + gap/ageClosenessScale)
+}
+
+func distanceScore(user, candidate *User) float64 {
+	if user.Location() == nil || candidate.Location() == nil {
+		return 0
+	}
+
+	km, err := user.Location().DistanceTo(*candidate.Location())
+	if err != nil {
+		return 0
+	}
+	return 1 / (1 + km/distanceScaleKm)
+}
+
+// reputationScore is the closeness of the users reputation scores.
+func reputationScore(user, candidate *User) float64 {
+	return 1 - math.Abs(user.Reputation()-candidate.Reputation())
+}
+
+func (s *WeightedScorer) waitTimeScore(candidate *User) float64 {
+	if candidate.RequestedAt().IsZero() {
+		return 0
+	}
+
+	waited := s.now().Sub(candidate.RequestedAt())
+	if waited <= 0 {
+		return 0
+	}
+	return float64(waited) / float64(waited+waitTimeScale)
+}
@@ -0,0 +1,54 @@
+package matchdomain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestMatchPartners(t *testing.T) {
+	newUser := func(id string) matchdomain.User {
+		return *matchdomain.NewUser(id, 25, gender.Unspecified, matchmaking.DefaultPreferences().WithGroupSize(3))
+	}
+
+	partnerIDs := func(t *testing.T, match *matchdomain.Match, userID string) []string {
+		t.Helper()
+
+		partners, err := match.Partners(userID)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(partners))
+		for _, partner := range partners {
+			ids = append(ids, partner.ID())
+		}
+		return ids
+	}
+
+	t.Run("should return the other user of a pair", func(t *testing.T) {
+		match, err := matchdomain.NewMatch("match-id", newUser("alice"), newUser("bob"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"bob"}, partnerIDs(t, match, "alice"))
+		require.Equal(t, []string{"alice"}, partnerIDs(t, match, "bob"))
+	})
+
+	t.Run("should return every other member of a group", func(t *testing.T) {
+		match, err := matchdomain.NewGroupMatch("match-id", newUser("alice"), newUser("bob"), newUser("carol"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"alice", "bob"}, partnerIDs(t, match, "carol"))
+		require.Equal(t, []string{"alice", "carol"}, partnerIDs(t, match, "bob"))
+	})
+
+	t.Run("should fail for the users out of the match", func(t *testing.T) {
+		match, err := matchdomain.NewMatch("match-id", newUser("alice"), newUser("bob"))
+		require.NoError(t, err)
+
+		_, err = match.Partners("carol")
+		require.ErrorIs(t, err, matchdomain.ErrUserNotInMatch)
+	})
+}
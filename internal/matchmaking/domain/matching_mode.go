@@ -0,0 +1,28 @@
+package matchdomain
+
+import "strings"
+
+// MatchingMode selects when the waiting users are matched.
+type MatchingMode string
+
+const (
+	// ImmediateMatching matches every user against the waiting pool as soon as it requests a match.
+	ImmediateMatching MatchingMode = "immediate"
+	// BatchMatching matches the whole waiting pool at once, in periodic rounds.
+	BatchMatching MatchingMode = "batch"
+)
+
+// ParseMatchingMode returns the MatchingMode for a given string, defaulting to ImmediateMatching.
+func ParseMatchingMode(str string) MatchingMode {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "batch":
+		return BatchMatching
+	default:
+		return ImmediateMatching
+	}
+}
+
+// String implements fmt.Stringer.
+func (m MatchingMode) String() string {
+	return string(m)
+}
@@ -7,13 +7,34 @@ import (
 var _ StableMatchFinder = (*GaleShapleyService)(nil)
 
 // GaleShapleyService is the implementation of the Gale-Shapley stable matching algorithm.
-type GaleShapleyService struct{}
+type GaleShapleyService struct {
+	scorer MatchScorer
+}
+
+// GaleShapleyOption defines a functional option to configure the GaleShapleyService.
+type GaleShapleyOption func(*GaleShapleyService)
+
+// WithScorer sets the scorer used to order the preference lists.
+func WithScorer(scorer MatchScorer) GaleShapleyOption {
+	return func(s *GaleShapleyService) {
+		s.scorer = scorer
+	}
+}
 
-func NewGaleShapleyStableMatcher() *GaleShapleyService {
-	return &GaleShapleyService{}
+// NewGaleShapleyStableMatcher creates a GaleShapleyService ranking the candidates
+// with a WeightedScorer using the default weights, unless another scorer is given.
+func NewGaleShapleyStableMatcher(opts ...GaleShapleyOption) *GaleShapleyService {
+	s := &GaleShapleyService{
+		scorer: NewWeightedScorer(DefaultScoreWeights()),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// GaleShapley builds preference lists using User's MatchPreferences
+// GaleShapley builds preference lists of the mutually compatible users ordered by score
 // and runs the Gale-Shapley algorithm, returning matches from setA to setB.
 func (s *GaleShapleyService) FindStableMatches(setA, setB []*User) []int {
 	nA, nB := len(setA), len(setB)
@@ -25,23 +46,13 @@ func (s *GaleShapleyService) FindStableMatches(setA, setB []*User) []int {
 	// preferencesA[aIndex] = [] of bIndexes in order of preference
 	preferencesA := make([][]int, nA)
 	for aIndex, aUser := range setA {
-		candidates := s.findCompatible(aUser, setB)
-		// Sort candidate IDs or apply other logic to define the order
-		sort.Slice(candidates, func(i, j int) bool {
-			return setB[candidates[i]].ID() < setB[candidates[j]].ID()
-		})
-		preferencesA[aIndex] = candidates
+		preferencesA[aIndex] = rankCandidates(s.scorer, aUser, setB)
 	}
 
 	// build preference lists for B
 	preferencesB := make([][]int, nB)
 	for bIndex, bUser := range setB {
-		candidates := s.findCompatible(bUser, setA)
-		// Sort candidate IDs or apply other logic for preference ordering
-		sort.Slice(candidates, func(i, j int) bool {
-			return setA[candidates[i]].ID() < setA[candidates[j]].ID()
-		})
-		preferencesB[bIndex] = candidates
+		preferencesB[bIndex] = rankCandidates(s.scorer, bUser, setA)
 	}
 
 	// we need "inverse ranking" for B, so we can quickly
@@ -117,10 +128,31 @@ func (s *GaleShapleyService) FindStableMatches(setA, setB []*User) []int {
 	return matches
 }
 
+// rankCandidates returns the indices of the users in `others` that are mutually compatible
+// with `user`, from the highest to the lowest score given by the scorer. Ties are broken
+// by user ID, so the outcome is deterministic.
+func rankCandidates(scorer MatchScorer, user *User, others []*User) []int {
+	candidates := findCompatible(user, others)
+
+	scores := make(map[int]float64, len(candidates))
+	for _, idx := range candidates {
+		scores[idx] = scorer.Score(user, others[idx])
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := scores[candidates[i]], scores[candidates[j]]
+		if si != sj {
+			return si > sj
+		}
+		return others[candidates[i]].ID() < others[candidates[j]].ID()
+	})
+	return candidates
+}
+
 // findCompatible returns the indices of users in `others` that are
 // mutually compatible with `user`. The result is a slice of indices
 // referencing positions in `others`.
-func (s *GaleShapleyService) findCompatible(user *User, others []*User) []int {
+func findCompatible(user *User, others []*User) []int {
 	var compatibleIndexes []int
 	for idx, other := range others {
 		if isMutuallyCompatible(user, other) {
@@ -130,12 +162,34 @@ func (s *GaleShapleyService) findCompatible(user *User, others []*User) []int {
 	return compatibleIndexes
 }
 
-// isMutuallyCompatible checks if 'user1' passes 'user2' preferences and vice versa.
+// AreCompatible reports whether the users can be matched with each other.
+func AreCompatible(u1, u2 *User) bool {
+	return isMutuallyCompatible(u1, u2)
+}
+
+// isMutuallyCompatible checks if 'user1' passes 'user2' effective preferences and vice versa,
+// including the location and language constraints of both users. Users only match with
+// users asking for a group room of the same size, or for a one-to-one match.
 func isMutuallyCompatible(u1, u2 *User) bool {
-	if u1.ID() == u2.ID() {
+	if u1.ID() == u2.ID() || isBlockedPair(u1, u2) {
+		return false
+	}
+	// quarantined users are kept apart from the rest
+	if u1.Quarantined() != u2.Quarantined() {
 		return false
 	}
 
-	return u1.Preferences().IsSatisfiedBy(u2) &&
-		u2.Preferences().IsSatisfiedBy(u1)
+	p1, p2 := u1.EffectivePreferences(), u2.EffectivePreferences()
+	return p1.GroupSize == p2.GroupSize &&
+		p1.IsSatisfiedBy(u2) &&
+		p2.IsSatisfiedBy(u1) &&
+		p1.IsWithinReach(u1.Location(), u2.Location()) &&
+		p2.IsWithinReach(u2.Location(), u1.Location()) &&
+		p1.IsSpokenBy(u1.Languages(), u2.Languages()) &&
+		p2.IsSpokenBy(u2.Languages(), u1.Languages())
+}
+
+// isBlockedPair reports whether any of the users blocked the other one.
+func isBlockedPair(u1, u2 *User) bool {
+	return u1.Blocks(u2.ID()) || u2.Blocks(u1.ID())
 }
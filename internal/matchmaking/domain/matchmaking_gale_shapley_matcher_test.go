@@ -1,13 +1,17 @@
 package matchdomain_test
 
 import (
+	"fmt"
+	"math/rand/v2"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	domain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -32,12 +36,9 @@ func TestGaleShapleyStableMatcher_FindStableMatches(t *testing.T) {
 		assert.Equal(t, 0, matches[0], "A1 should be matched with B1")
 	})
 
-	t.Run("multiple A and B with partial compatibility", func(t *testing.T) {
-		// B1 only compatible with A1, B2 only with A2
-		prefsB1 := matchmaking.DefaultPreferences()
-		prefsB2 := matchmaking.DefaultPreferences()
-		userB1 := domain.NewUser("B1", 25, gender.Unspecified, prefsB1)
-		userB2 := domain.NewUser("B2", 22, gender.Unspecified, prefsB2)
+	t.Run("multiple A and B ranked by age closeness", func(t *testing.T) {
+		userB1 := domain.NewUser("B1", 25, gender.Unspecified, matchmaking.DefaultPreferences())
+		userB2 := domain.NewUser("B2", 22, gender.Unspecified, matchmaking.DefaultPreferences())
 
 		matcher := domain.NewGaleShapleyStableMatcher()
 
@@ -45,10 +46,12 @@ func TestGaleShapleyStableMatcher_FindStableMatches(t *testing.T) {
 		userA1 := domain.NewUser("A1", 20, gender.Unspecified, matchmaking.DefaultPreferences())
 		userA2 := domain.NewUser("A2", 24, gender.Unspecified, matchmaking.DefaultPreferences())
 
-		matches := matcher.FindStableMatches([]*domain.User{userA1, userA2}, []*domain.User{userB1, userB2})
+		setA, setB := []*domain.User{userA1, userA2}, []*domain.User{userB1, userB2}
+		matches := matcher.FindStableMatches(setA, setB)
 		require.Len(t, matches, 2, "expecting matches for A1 and A2")
-		assert.Equal(t, 0, matches[0], "A1 should be matched with B1")
-		assert.Equal(t, 1, matches[1], "A2 should be matched with B2")
+		assert.Equal(t, 1, matches[0], "A1 should be matched with B2, the closest in age")
+		assert.Equal(t, 0, matches[1], "A2 should be matched with B1, the closest in age")
+		requireStableMatches(t, domain.NewWeightedScorer(domain.DefaultScoreWeights()), setA, setB, matches)
 	})
 
 	t.Run("no compatibility found", func(t *testing.T) {
@@ -67,4 +70,213 @@ func TestGaleShapleyStableMatcher_FindStableMatches(t *testing.T) {
 		assert.Equal(t, -1, matches[0], "A1 should not be matched")
 		assert.Equal(t, -1, matches[1], "A2 should not be matched")
 	})
+
+	t.Run("location constraints are mutual", func(t *testing.T) {
+		madrid := location.New(40.4168, -3.7038).WithCountryCode("ES")
+		toledo := location.New(39.8628, -4.0273).WithCountryCode("ES")
+		lisbon := location.New(38.7223, -9.1393).WithCountryCode("PT")
+
+		// B1 is far away from A1, B2 is nearby
+		userB1 := domain.NewUser("B1", 25, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithLocation(&lisbon))
+		userB2 := domain.NewUser("B2", 30, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithLocation(&toledo))
+
+		// A1 only accepts users within 100km, A2 only users from its country
+		userA1 := domain.NewUser("A1", 20, gender.Unspecified,
+			matchmaking.DefaultPreferences().WithMaxDistanceKm(100), domain.WithLocation(&madrid))
+		userA2 := domain.NewUser("A2", 24, gender.Unspecified,
+			matchmaking.DefaultPreferences().WithLocationScope(matchmaking.LocationScopeCountry), domain.WithLocation(&lisbon))
+
+		matcher := domain.NewGaleShapleyStableMatcher()
+
+		matches := matcher.FindStableMatches([]*domain.User{userA1, userA2}, []*domain.User{userB1, userB2})
+		require.Len(t, matches, 2)
+		assert.Equal(t, 1, matches[0], "A1 should be matched with the nearby B2")
+		assert.Equal(t, 0, matches[1], "A2 should be matched with B1 from its country")
+	})
+
+	t.Run("blocked users are never matched in either direction", func(t *testing.T) {
+		// B1 is the closest in age to both A1 and A2, but A1 blocked B1 and B1 blocked A2
+		userB1 := domain.NewUser("B1", 25, gender.Unspecified, matchmaking.DefaultPreferences(),
+			domain.WithBlockedUsers("A2"))
+		userA1 := domain.NewUser("A1", 25, gender.Unspecified, matchmaking.DefaultPreferences(),
+			domain.WithBlockedUsers("B1"))
+		userA2 := domain.NewUser("A2", 25, gender.Unspecified, matchmaking.DefaultPreferences())
+
+		matcher := domain.NewGaleShapleyStableMatcher()
+
+		matches := matcher.FindStableMatches([]*domain.User{userA1, userA2}, []*domain.User{userB1})
+		require.Len(t, matches, 2)
+		assert.Equal(t, -1, matches[0], "A1 blocked B1")
+		assert.Equal(t, -1, matches[1], "B1 blocked A2")
+	})
+
+	t.Run("users requiring a shared language are only matched with speakers of it", func(t *testing.T) {
+		sharedLanguage := matchmaking.DefaultPreferences().WithRequireSharedLanguage(true)
+		userA1 := domain.NewUser("A1", 25, gender.Unspecified, sharedLanguage, domain.WithLanguages("es", "en-US"))
+		userA2 := domain.NewUser("A2", 25, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithLanguages("ja"))
+		userB1 := domain.NewUser("B1", 25, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithLanguages("fr"))
+		userB2 := domain.NewUser("B2", 25, gender.Unspecified, sharedLanguage, domain.WithLanguages("en-GB"))
+
+		matcher := domain.NewGaleShapleyStableMatcher()
+
+		matches := matcher.FindStableMatches([]*domain.User{userA1, userA2}, []*domain.User{userB1, userB2})
+		require.Len(t, matches, 2)
+		assert.Equal(t, 1, matches[0], "A1 and B2 speak english")
+		assert.Equal(t, 0, matches[1], "neither A2 nor B1 require a shared language")
+	})
+}
+
+func TestGaleShapleyStableMatcher_Scoring(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	t.Run("candidates sharing more interests are preferred", func(t *testing.T) {
+		scorer := domain.NewWeightedScorer(domain.ScoreWeights{Interests: 1})
+		matcher := domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer))
+
+		userA1 := domain.NewUser("A1", 30, gender.Unspecified, matchmaking.DefaultPreferences().WithInterests([]string{"jazz", "chess"}))
+		userB1 := domain.NewUser("B1", 30, gender.Unspecified, matchmaking.DefaultPreferences().WithInterests([]string{"rock"}))
+		userB2 := domain.NewUser("B2", 30, gender.Unspecified, matchmaking.DefaultPreferences().WithInterests([]string{"jazz", "chess"}))
+
+		setA, setB := []*domain.User{userA1}, []*domain.User{userB1, userB2}
+		matches := matcher.FindStableMatches(setA, setB)
+		require.Equal(t, []int{1}, matches)
+		requireStableMatches(t, scorer, setA, setB, matches)
+	})
+
+	t.Run("candidates waiting longer are preferred", func(t *testing.T) {
+		scorer := domain.NewWeightedScorer(domain.ScoreWeights{WaitTime: 1}, domain.WithScorerClock(clock))
+		matcher := domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer))
+
+		users := newWaitingUsers(t, now, map[string]time.Duration{"B1": time.Second, "B2": time.Minute})
+		userA1 := domain.NewUser("A1", 30, gender.Unspecified, matchmaking.DefaultPreferences())
+
+		setA, setB := []*domain.User{userA1}, []*domain.User{users["B1"], users["B2"]}
+		matches := matcher.FindStableMatches(setA, setB)
+		require.Equal(t, []int{1}, matches)
+	})
+
+	t.Run("candidates with a closer reputation are preferred", func(t *testing.T) {
+		scorer := domain.NewWeightedScorer(domain.ScoreWeights{Reputation: 1})
+		matcher := domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer))
+
+		userA1 := domain.NewUser("A1", 30, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithReputation(0.9, false))
+		userB1 := domain.NewUser("B1", 30, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithReputation(0.3, false))
+		userB2 := domain.NewUser("B2", 30, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithReputation(0.8, false))
+
+		setA, setB := []*domain.User{userA1}, []*domain.User{userB1, userB2}
+		matches := matcher.FindStableMatches(setA, setB)
+		require.Equal(t, []int{1}, matches)
+	})
+
+	t.Run("quarantined users are only matched with each other", func(t *testing.T) {
+		matcher := domain.NewGaleShapleyStableMatcher()
+
+		userA1 := domain.NewUser("A1", 30, gender.Unspecified, matchmaking.DefaultPreferences(), domain.WithReputation(0.1, true))
+		userA2 := domain.NewUser("A2", 30, gender.Unspecified, matchmaking.DefaultPreferences())
+		userB1 := domain.NewUser("B1", 30, gender.Unspecified, matchmaking.DefaultPreferences())
+
+		matches := matcher.FindStableMatches([]*domain.User{userA1, userA2}, []*domain.User{userB1})
+		require.Equal(t, []int{-1, 0}, matches)
+	})
+
+	t.Run("outcomes are stable for any weights", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(1, 2))
+		interests := []string{"jazz", "rock", "chess", "films", "travel"}
+
+		newRandomUser := func(id string) *domain.User {
+			picked := make([]string, 0, len(interests))
+			for _, interest := range interests {
+				if rnd.IntN(2) == 0 {
+					picked = append(picked, interest)
+				}
+			}
+			loc := location.New(40+rnd.Float64(), -3-rnd.Float64())
+			return domain.NewUser(id, 18+rnd.Int32N(40), gender.Unspecified,
+				matchmaking.DefaultPreferences().WithInterests(picked), domain.WithLocation(&loc))
+		}
+
+		for round := range 20 {
+			weights := domain.ScoreWeights{
+				Interests: rnd.Float64(),
+				Age:       rnd.Float64(),
+				Distance:  rnd.Float64(),
+			}
+			scorer := domain.NewWeightedScorer(weights)
+			matcher := domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer))
+
+			setA := make([]*domain.User, 8)
+			setB := make([]*domain.User, 10)
+			for i := range setA {
+				setA[i] = newRandomUser(fmt.Sprintf("A%d-%d", round, i))
+			}
+			for i := range setB {
+				setB[i] = newRandomUser(fmt.Sprintf("B%d-%d", round, i))
+			}
+
+			matches := matcher.FindStableMatches(setA, setB)
+			require.Len(t, matches, len(setA))
+			requireStableMatches(t, scorer, setA, setB, matches)
+		}
+	})
+}
+
+// newWaitingUsers returns users that started waiting the given time before now.
+func newWaitingUsers(t *testing.T, now time.Time, waited map[string]time.Duration) map[string]*domain.User {
+	t.Helper()
+
+	users := make(map[string]*domain.User, len(waited))
+	for id, wait := range waited {
+		user := domain.NewUser(id, 30, gender.Unspecified, matchmaking.DefaultPreferences())
+		user.StartWaiting(now.Add(-wait))
+		users[id] = user
+	}
+	return users
+}
+
+// requireStableMatches fails when a pair of compatible users prefer each other
+// over the users they were matched with.
+func requireStableMatches(t *testing.T, scorer domain.MatchScorer, setA, setB []*domain.User, matches []int) {
+	t.Helper()
+
+	engagedTo := make(map[int]int, len(matches))
+	for a, b := range matches {
+		if b != -1 {
+			engagedTo[b] = a
+		}
+	}
+
+	prefers := func(user, candidate, current *domain.User) bool {
+		if current == nil {
+			return true
+		}
+		return scorer.Score(user, candidate) > scorer.Score(user, current)
+	}
+
+	for a, userA := range setA {
+		for b, userB := range setB {
+			if matches[a] == b || !compatible(userA, userB) {
+				continue
+			}
+
+			var currentB, currentA *domain.User
+			if matches[a] != -1 {
+				currentB = setB[matches[a]]
+			}
+			if engaged, ok := engagedTo[b]; ok {
+				currentA = setA[engaged]
+			}
+
+			if prefers(userA, userB, currentB) && prefers(userB, userA, currentA) {
+				t.Fatalf("blocking pair found: %s and %s prefer each other", userA.ID(), userB.ID())
+			}
+		}
+	}
+}
+
+func compatible(u1, u2 *domain.User) bool {
+	p1, p2 := u1.EffectivePreferences(), u2.EffectivePreferences()
+	return p1.IsSatisfiedBy(u2) && p2.IsSatisfiedBy(u1) &&
+		p1.IsWithinReach(u1.Location(), u2.Location()) &&
+		p2.IsWithinReach(u2.Location(), u1.Location())
 }
@@ -0,0 +1,80 @@
+package matchdomain
+
+import (
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+// GroupMatcher forms the group rooms of the users waiting for one.
+type GroupMatcher struct {
+	scorer MatchScorer
+}
+
+// NewGroupMatcher creates a GroupMatcher ranking the candidates with the given scorer,
+// or a WeightedScorer using the default weights when it is nil.
+func NewGroupMatcher(scorer MatchScorer) *GroupMatcher {
+	if scorer == nil {
+		scorer = NewWeightedScorer(DefaultScoreWeights())
+	}
+	return &GroupMatcher{scorer: scorer}
+}
+
+// FindGroups forms groups of the size asked for by their users, where every member is
+// mutually compatible with all the others. The users not grouped yet seed a group in order,
+// and are joined by their best ranked candidates that fit with the members so far.
+// It returns the indices of the members of every group, starting with the seed.
+func (g *GroupMatcher) FindGroups(users []*User) [][]int {
+	grouped := make([]bool, len(users))
+
+	var groups [][]int
+	for seedIdx, seed := range users {
+		size := int(seed.EffectivePreferences().GroupSize)
+		if grouped[seedIdx] || size < int(matchmaking.MinGroupSize) {
+			continue
+		}
+
+		group := []int{seedIdx}
+		for _, idx := range rankCandidates(g.scorer, seed, users) {
+			if grouped[idx] || !fitsGroup(users[idx], users, group) {
+				continue
+			}
+
+			group = append(group, idx)
+			if len(group) == size {
+				break
+			}
+		}
+
+		if len(group) < size {
+			continue
+		}
+
+		for _, idx := range group {
+			grouped[idx] = true
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// fitsGroup reports whether the user is mutually compatible with every member of the group.
+func fitsGroup(user *User, users []*User, group []int) bool {
+	for _, idx := range group {
+		if !isMutuallyCompatible(user, users[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitGroupUsers separates the users waiting for a one-to-one match
+// from the users waiting for a group room.
+func splitGroupUsers(users []*User) (pairs, groups []*User) {
+	for _, user := range users {
+		if user.Preferences().IsGroup() {
+			groups = append(groups, user)
+			continue
+		}
+		pairs = append(pairs, user)
+	}
+	return pairs, groups
+}
@@ -0,0 +1,60 @@
+package matchdomain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestGroupMatcher_FindGroups(t *testing.T) {
+	groupPrefs := matchmaking.DefaultPreferences().WithGroupSize(3)
+
+	t.Run("should group the users asking for the same size", func(t *testing.T) {
+		users := []*matchdomain.User{
+			matchdomain.NewUser("A", 25, gender.Male, groupPrefs),
+			matchdomain.NewUser("B", 26, gender.Female, groupPrefs),
+			matchdomain.NewUser("C", 27, gender.Male, matchmaking.DefaultPreferences()),
+			matchdomain.NewUser("D", 28, gender.Female, groupPrefs),
+		}
+
+		groups := matchdomain.NewGroupMatcher(nil).FindGroups(users)
+		require.Len(t, groups, 1)
+		assert.ElementsMatch(t, []int{0, 1, 3}, groups[0])
+		assert.Equal(t, 0, groups[0][0], "the seed goes first")
+	})
+
+	t.Run("should only group mutually compatible users", func(t *testing.T) {
+		users := []*matchdomain.User{
+			matchdomain.NewUser("A", 25, gender.Male, groupPrefs),
+			matchdomain.NewUser("B", 26, gender.Female, groupPrefs),
+			// C does not want to talk with A
+			matchdomain.NewUser("C", 27, gender.Male, groupPrefs, matchdomain.WithBlockedUsers("A")),
+		}
+
+		assert.Empty(t, matchdomain.NewGroupMatcher(nil).FindGroups(users))
+	})
+
+	t.Run("should form several groups", func(t *testing.T) {
+		var users []*matchdomain.User
+		for _, id := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+			users = append(users, matchdomain.NewUser(id, 25, gender.Unspecified, groupPrefs))
+		}
+
+		groups := matchdomain.NewGroupMatcher(nil).FindGroups(users)
+		require.Len(t, groups, 2)
+
+		grouped := make(map[int]bool)
+		for _, group := range groups {
+			require.Len(t, group, 3)
+			for _, idx := range group {
+				require.False(t, grouped[idx], "an user joins a single group")
+				grouped[idx] = true
+			}
+		}
+	})
+}
@@ -0,0 +1,351 @@
+package matchdomain
+
+import (
+	"math"
+	"sort"
+)
+
+var _ StableMatchFinder = (*MaximumMatchingService)(nil)
+
+// MatchingObjective is what the MaximumMatchingService maximizes.
+type MatchingObjective int
+
+const (
+	// MaximizeCardinality matches as many users as possible, preferring the higher scored pairs.
+	MaximizeCardinality MatchingObjective = iota
+	// MaximizeWeight maximizes the sum of the mutual scores of the matched pairs.
+	MaximizeWeight
+)
+
+// defaultWeightBucketSize is the number of users of the smaller set matched at once by
+// the Hungarian algorithm. 500 users of each set take ~90ms.
+const defaultWeightBucketSize = 500
+
+// MaximumMatchingService matches two sets of users maximizing the number of matches
+// with the Hopcroft-Karp algorithm, or their total score with the Hungarian algorithm.
+// Unlike GaleShapleyService, the matches are not guaranteed to be stable.
+type MaximumMatchingService struct {
+	scorer     MatchScorer
+	objective  MatchingObjective
+	bucketSize int
+}
+
+// MaximumMatchingOption defines a functional option to configure the MaximumMatchingService.
+type MaximumMatchingOption func(*MaximumMatchingService)
+
+// WithMaximumMatchingScorer sets the scorer used to weight the compatible pairs.
+func WithMaximumMatchingScorer(scorer MatchScorer) MaximumMatchingOption {
+	return func(s *MaximumMatchingService) {
+		s.scorer = scorer
+	}
+}
+
+// WithObjective sets what the matching maximizes.
+func WithObjective(objective MatchingObjective) MaximumMatchingOption {
+	return func(s *MaximumMatchingService) {
+		s.objective = objective
+	}
+}
+
+// WithWeightBucketSize sets the number of users of the smaller set the Hungarian algorithm
+// matches at once when maximizing the total score. Larger sets are matched bucket by bucket.
+func WithWeightBucketSize(size int) MaximumMatchingOption {
+	return func(s *MaximumMatchingService) {
+		if size > 0 {
+			s.bucketSize = size
+		}
+	}
+}
+
+// NewMaximumMatcher creates a MaximumMatchingService maximizing the number of matches and
+// weighting the pairs with a WeightedScorer using the default weights, unless told otherwise.
+func NewMaximumMatcher(opts ...MaximumMatchingOption) *MaximumMatchingService {
+	s := &MaximumMatchingService{
+		scorer:     NewWeightedScorer(DefaultScoreWeights()),
+		objective:  MaximizeCardinality,
+		bucketSize: defaultWeightBucketSize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// FindStableMatches returns the maximum matching from setA to setB.
+// Only mutually compatible users are matched.
+func (s *MaximumMatchingService) FindStableMatches(setA, setB []*User) []int {
+	if len(setA) == 0 || len(setB) == 0 {
+		return nil
+	}
+
+	if s.objective == MaximizeWeight {
+		return s.bucketedMaxWeightMatches(setA, setB)
+	}
+	return s.maxCardinalityMatches(setA, setB)
+}
+
+// mutualScore is the weight of a compatible pair.
+func (s *MaximumMatchingService) mutualScore(a, b *User) float64 {
+	return s.scorer.Score(a, b) + s.scorer.Score(b, a)
+}
+
+// maxCardinalityMatches runs Hopcroft-Karp over the compatible pairs. The candidates are
+// visited from the highest to the lowest mutual score, so the initial greedy matching
+// and the augmenting paths favour the better pairs.
+func (s *MaximumMatchingService) maxCardinalityMatches(setA, setB []*User) []int {
+	nA, nB := len(setA), len(setB)
+
+	adjacency := make([][]int, nA)
+	for aIndex, aUser := range setA {
+		candidates := findCompatible(aUser, setB)
+		scores := make(map[int]float64, len(candidates))
+		for _, bIndex := range candidates {
+			scores[bIndex] = s.mutualScore(aUser, setB[bIndex])
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return scores[candidates[i]] > scores[candidates[j]]
+		})
+		adjacency[aIndex] = candidates
+	}
+
+	matches := make([]int, nA)
+	for i := range matches {
+		matches[i] = -1
+	}
+	matchedTo := make([]int, nB)
+	for i := range matchedTo {
+		matchedTo[i] = -1
+	}
+
+	// greedy initial matching
+	for aIndex, candidates := range adjacency {
+		for _, bIndex := range candidates {
+			if matchedTo[bIndex] == -1 {
+				matches[aIndex], matchedTo[bIndex] = bIndex, aIndex
+				break
+			}
+		}
+	}
+
+	dist := make([]int, nA)
+	next := make([]int, nA)
+
+	// bfs layers the free users of A by distance, returning whether an augmenting path exists
+	bfs := func() bool {
+		queue := make([]int, 0, nA)
+		for aIndex := range setA {
+			if matches[aIndex] == -1 {
+				dist[aIndex] = 0
+				queue = append(queue, aIndex)
+			} else {
+				dist[aIndex] = -1
+			}
+		}
+
+		found := false
+		for len(queue) > 0 {
+			aIndex := queue[0]
+			queue = queue[1:]
+			for _, bIndex := range adjacency[aIndex] {
+				owner := matchedTo[bIndex]
+				if owner == -1 {
+					found = true
+				} else if dist[owner] == -1 {
+					dist[owner] = dist[aIndex] + 1
+					queue = append(queue, owner)
+				}
+			}
+		}
+		return found
+	}
+
+	// dfs augments along the layers from the given user of A
+	var dfs func(aIndex int) bool
+	dfs = func(aIndex int) bool {
+		for ; next[aIndex] < len(adjacency[aIndex]); next[aIndex]++ {
+			bIndex := adjacency[aIndex][next[aIndex]]
+			owner := matchedTo[bIndex]
+			if owner == -1 || (dist[owner] == dist[aIndex]+1 && dfs(owner)) {
+				matches[aIndex], matchedTo[bIndex] = bIndex, aIndex
+				next[aIndex]++
+				return true
+			}
+		}
+		dist[aIndex] = -1
+		return false
+	}
+
+	for bfs() {
+		clear(next)
+		for aIndex := range setA {
+			if matches[aIndex] == -1 {
+				dfs(aIndex)
+			}
+		}
+	}
+	return matches
+}
+
+// bucketedMaxWeightMatches runs the Hungarian algorithm over buckets of both sets, in their
+// order, so that the smaller set of every bucket has at most bucketSize users. The total score
+// is then only maximized within every bucket.
+//
+// The users left unmatched by their bucket are matched again together, so the compatible
+// pairs split across buckets are still matched. Once a pass matches nobody, the leftovers
+// are matched with maxCardinalityMatches.
+func (s *MaximumMatchingService) bucketedMaxWeightMatches(setA, setB []*User) []int {
+	buckets := (min(len(setA), len(setB)) + s.bucketSize - 1) / s.bucketSize
+	if buckets <= 1 {
+		return s.maxWeightMatches(setA, setB)
+	}
+
+	matches := make([]int, len(setA))
+	matchedB := make([]bool, len(setB))
+	found := false
+	for bucket := range buckets {
+		aFrom, aTo := bucket*len(setA)/buckets, (bucket+1)*len(setA)/buckets
+		bFrom, bTo := bucket*len(setB)/buckets, (bucket+1)*len(setB)/buckets
+		for aIndex, bIndex := range s.maxWeightMatches(setA[aFrom:aTo], setB[bFrom:bTo]) {
+			matches[aFrom+aIndex] = -1
+			if bIndex != -1 {
+				matches[aFrom+aIndex] = bFrom + bIndex
+				matchedB[bFrom+bIndex] = true
+				found = true
+			}
+		}
+	}
+
+	var leftA, leftB []int
+	for aIndex, bIndex := range matches {
+		if bIndex == -1 {
+			leftA = append(leftA, aIndex)
+		}
+	}
+	for bIndex, matched := range matchedB {
+		if !matched {
+			leftB = append(leftB, bIndex)
+		}
+	}
+	if len(leftA) == 0 || len(leftB) == 0 {
+		return matches
+	}
+
+	leftUsersA, leftUsersB := make([]*User, len(leftA)), make([]*User, len(leftB))
+	for i, aIndex := range leftA {
+		leftUsersA[i] = setA[aIndex]
+	}
+	for i, bIndex := range leftB {
+		leftUsersB[i] = setB[bIndex]
+	}
+
+	var leftMatches []int
+	if found {
+		leftMatches = s.bucketedMaxWeightMatches(leftUsersA, leftUsersB)
+	} else {
+		leftMatches = s.maxCardinalityMatches(leftUsersA, leftUsersB)
+	}
+	for i, j := range leftMatches {
+		if j != -1 {
+			matches[leftA[i]] = leftB[j]
+		}
+	}
+	return matches
+}
+
+// maxWeightMatches runs the Hungarian algorithm over the mutual scores. Every compatible
+// pair is worth one more than its mutual score, so that matching a pair always beats
+// leaving both users unmatched. It runs in O(n²m), so larger pools are matched in buckets,
+// see bucketedMaxWeightMatches.
+func (s *MaximumMatchingService) maxWeightMatches(setA, setB []*User) []int {
+	// the algorithm assigns every row, so the rows are the smaller set
+	transposed := len(setA) > len(setB)
+	rows, cols := setA, setB
+	if transposed {
+		rows, cols = setB, setA
+	}
+
+	n, m := len(rows), len(cols)
+	weights := make([][]float64, n)
+	for r, rowUser := range rows {
+		weights[r] = make([]float64, m)
+		for c, colUser := range cols {
+			if isMutuallyCompatible(rowUser, colUser) {
+				weights[r][c] = 1 + s.mutualScore(rowUser, colUser)
+			}
+		}
+	}
+
+	// potentials and assignment are 1-indexed, column 0 being a virtual one
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	assigned := make([]int, m+1)
+	way := make([]int, m+1)
+	minSlack := make([]float64, m+1)
+	used := make([]bool, m+1)
+
+	for r := 1; r <= n; r++ {
+		assigned[0] = r
+		c0 := 0
+		for c := range minSlack {
+			minSlack[c] = math.Inf(1)
+			used[c] = false
+		}
+
+		for {
+			used[c0] = true
+			r0, delta, c1 := assigned[c0], math.Inf(1), 0
+			for c := 1; c <= m; c++ {
+				if used[c] {
+					continue
+				}
+				// costs are the negated weights, as the algorithm minimizes
+				cur := -weights[r0-1][c-1] - u[r0] - v[c]
+				if cur < minSlack[c] {
+					minSlack[c], way[c] = cur, c0
+				}
+				if minSlack[c] < delta {
+					delta, c1 = minSlack[c], c
+				}
+			}
+
+			for c := 0; c <= m; c++ {
+				if used[c] {
+					u[assigned[c]] += delta
+					v[c] -= delta
+				} else {
+					minSlack[c] -= delta
+				}
+			}
+
+			c0 = c1
+			if assigned[c0] == 0 {
+				break
+			}
+		}
+
+		for c0 != 0 {
+			c1 := way[c0]
+			assigned[c0] = assigned[c1]
+			c0 = c1
+		}
+	}
+
+	matches := make([]int, len(setA))
+	for i := range matches {
+		matches[i] = -1
+	}
+	for c := 1; c <= m; c++ {
+		r := assigned[c]
+		if r == 0 || weights[r-1][c-1] == 0 {
+			// assigned to an incompatible user
+			continue
+		}
+		if transposed {
+			matches[c-1] = r - 1
+		} else {
+			matches[r-1] = c - 1
+		}
+	}
+	return matches
+}
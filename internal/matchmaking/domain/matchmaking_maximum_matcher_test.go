@@ -0,0 +1,221 @@
+package matchdomain_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestMaximumMatcher_FindStableMatches(t *testing.T) {
+	t.Run("should return nil when a set is empty", func(t *testing.T) {
+		users := newPoolUsers("a")
+		require.Nil(t, domain.NewMaximumMatcher().FindStableMatches(users, nil))
+		require.Nil(t, domain.NewMaximumMatcher(domain.WithObjective(domain.MaximizeWeight)).FindStableMatches(nil, users))
+	})
+
+	t.Run("should match more users than the stable matching", func(t *testing.T) {
+		// a1 and b1 prefer each other, but b1 is the only partner of a2
+		scorer := newRankScorer(map[string][]string{
+			"a1": {"b1", "b2"},
+			"a2": {"b1"},
+			"b1": {"a1", "a2"},
+			"b2": {"a1"},
+		})
+		a1, a2 := newRestrictedUser("a1", "b1", "b2"), newRestrictedUser("a2", "b1")
+		b1, b2 := newRestrictedUser("b1", "a1", "a2"), newRestrictedUser("b2", "a1")
+		setA, setB := []*domain.User{a1, a2}, []*domain.User{b1, b2}
+
+		stable := domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer)).FindStableMatches(setA, setB)
+		require.Equal(t, []int{0, -1}, stable)
+
+		for _, objective := range []domain.MatchingObjective{domain.MaximizeCardinality, domain.MaximizeWeight} {
+			matcher := domain.NewMaximumMatcher(
+				domain.WithMaximumMatchingScorer(scorer),
+				domain.WithObjective(objective),
+			)
+			require.Equal(t, []int{1, 0}, matcher.FindStableMatches(setA, setB))
+		}
+	})
+
+	t.Run("should find the optimal matching of random pools", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(5, 6))
+		scorer := domain.NewWeightedScorer(domain.DefaultScoreWeights())
+
+		for round := range 100 {
+			setA := newRandomUsers(rnd, fmt.Sprintf("A%d", round), 1+rnd.IntN(6))
+			setB := newRandomUsers(rnd, fmt.Sprintf("B%d", round), 1+rnd.IntN(6))
+			bestCardinality, bestWeight := bruteForceMaximum(scorer, setA, setB)
+
+			cardinality := domain.NewMaximumMatcher(domain.WithMaximumMatchingScorer(scorer)).
+				FindStableMatches(setA, setB)
+			requireValidMatches(t, setA, setB, cardinality)
+			count, _ := matchingValue(scorer, setA, setB, cardinality)
+			require.Equal(t, bestCardinality, count, "round %d", round)
+
+			weighted := domain.NewMaximumMatcher(
+				domain.WithMaximumMatchingScorer(scorer),
+				domain.WithObjective(domain.MaximizeWeight),
+			).FindStableMatches(setA, setB)
+			requireValidMatches(t, setA, setB, weighted)
+			_, weight := matchingValue(scorer, setA, setB, weighted)
+			require.InDelta(t, bestWeight, weight, 1e-9, "round %d", round)
+		}
+	})
+}
+
+func TestMaximumMatcher_WeightBuckets(t *testing.T) {
+	t.Run("should match the compatible users of different buckets", func(t *testing.T) {
+		a1, a2 := newRestrictedUser("a1", "b2"), newRestrictedUser("a2", "b1")
+		b1, b2 := newRestrictedUser("b1", "a2"), newRestrictedUser("b2", "a1")
+
+		matcher := domain.NewMaximumMatcher(
+			domain.WithObjective(domain.MaximizeWeight),
+			domain.WithWeightBucketSize(1),
+		)
+		require.Equal(t, []int{1, 0}, matcher.FindStableMatches([]*domain.User{a1, a2}, []*domain.User{b1, b2}))
+	})
+
+	t.Run("should find a maximal matching scoring at most the optimal one", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(11, 12))
+		scorer := domain.NewWeightedScorer(domain.DefaultScoreWeights())
+
+		for round := range 100 {
+			setA := newRandomUsers(rnd, fmt.Sprintf("A%d", round), 1+rnd.IntN(8))
+			setB := newRandomUsers(rnd, fmt.Sprintf("B%d", round), 1+rnd.IntN(8))
+			bestCardinality, bestWeight := bruteForceMaximum(scorer, setA, setB)
+
+			matches := domain.NewMaximumMatcher(
+				domain.WithMaximumMatchingScorer(scorer),
+				domain.WithObjective(domain.MaximizeWeight),
+				domain.WithWeightBucketSize(2),
+			).FindStableMatches(setA, setB)
+			requireValidMatches(t, setA, setB, matches)
+
+			count, weight := matchingValue(scorer, setA, setB, matches)
+			require.LessOrEqual(t, weight, bestWeight+1e-9, "round %d", round)
+			require.GreaterOrEqual(t, 2*count, bestCardinality, "round %d is not maximal", round)
+		}
+	})
+}
+
+func BenchmarkStableMatchFinders(b *testing.B) {
+	scorer := domain.NewWeightedScorer(domain.DefaultScoreWeights())
+	matchers := []struct {
+		name    string
+		matcher domain.StableMatchFinder
+	}{
+		{name: "gale_shapley", matcher: domain.NewGaleShapleyStableMatcher(domain.WithScorer(scorer))},
+		{name: "max_cardinality", matcher: domain.NewMaximumMatcher(domain.WithMaximumMatchingScorer(scorer))},
+		{
+			// the Hungarian algorithm is O(n³): above 500 users per set, it matches them in buckets
+			name: "max_weight",
+			matcher: domain.NewMaximumMatcher(
+				domain.WithMaximumMatchingScorer(scorer),
+				domain.WithObjective(domain.MaximizeWeight),
+			),
+		},
+	}
+
+	for _, size := range []int{1000, 10000} {
+		rnd := rand.New(rand.NewPCG(7, 8))
+		setA := newRandomUsers(rnd, "A", size/2)
+		setB := newRandomUsers(rnd, "B", size/2)
+
+		for _, m := range matchers {
+			b.Run(fmt.Sprintf("%s/%d", m.name, size), func(b *testing.B) {
+				var matches []int
+				for b.Loop() {
+					matches = m.matcher.FindStableMatches(setA, setB)
+				}
+
+				count, weight := matchingValue(scorer, setA, setB, matches)
+				b.ReportMetric(float64(count), "matches")
+				b.ReportMetric(weight, "weight")
+			})
+		}
+	}
+}
+
+// newRestrictedUser returns a user compatible only with the given users.
+func newRestrictedUser(id string, compatibleIDs ...string) *domain.User {
+	return domain.NewUser(id, 30, gender.Unspecified,
+		matchmaking.DefaultPreferences().WithInterests(append(compatibleIDs, id)))
+}
+
+func newRandomUsers(rnd *rand.Rand, prefix string, n int) []*domain.User {
+	genders := []gender.Gender{gender.Female, gender.Male}
+	users := make([]*domain.User, n)
+	for i := range users {
+		prefs := matchmaking.DefaultPreferences().
+			WithGender(genders[rnd.IntN(2)]).
+			WithMinAge(18 + rnd.Int32N(10)).
+			WithMaxAge(35 + rnd.Int32N(20))
+		loc := location.New(40+rnd.Float64(), -3-rnd.Float64())
+		users[i] = domain.NewUser(fmt.Sprintf("%s-%d", prefix, i), 18+rnd.Int32N(40), genders[rnd.IntN(2)],
+			prefs, domain.WithLocation(&loc))
+	}
+	return users
+}
+
+func requireValidMatches(t *testing.T, setA, setB []*domain.User, matches []int) {
+	t.Helper()
+
+	require.Len(t, matches, len(setA))
+	seen := make(map[int]bool, len(matches))
+	for a, b := range matches {
+		if b == -1 {
+			continue
+		}
+		require.False(t, seen[b], "%s is matched twice", setB[b].ID())
+		require.True(t, compatible(setA[a], setB[b]))
+		seen[b] = true
+	}
+}
+
+func matchingValue(scorer domain.MatchScorer, setA, setB []*domain.User, matches []int) (int, float64) {
+	count, weight := 0, 0.0
+	for a, b := range matches {
+		if b != -1 {
+			count++
+			weight += 1 + scorer.Score(setA[a], setB[b]) + scorer.Score(setB[b], setA[a])
+		}
+	}
+	return count, weight
+}
+
+// bruteForceMaximum returns the maximum cardinality and weight of the matchings from setA to setB.
+func bruteForceMaximum(scorer domain.MatchScorer, setA, setB []*domain.User) (int, float64) {
+	bestCount, bestWeight := 0, math.Inf(-1)
+	matches := make([]int, len(setA))
+	used := make([]bool, len(setB))
+
+	var visit func(a int)
+	visit = func(a int) {
+		if a == len(setA) {
+			count, weight := matchingValue(scorer, setA, setB, matches)
+			bestCount, bestWeight = max(bestCount, count), max(bestWeight, weight)
+			return
+		}
+
+		matches[a] = -1
+		visit(a + 1)
+		for b := range setB {
+			if used[b] || !compatible(setA[a], setB[b]) {
+				continue
+			}
+			used[b], matches[a] = true, b
+			visit(a + 1)
+			used[b] = false
+		}
+	}
+	visit(0)
+	return bestCount, bestWeight
+}
@@ -7,6 +7,22 @@ import "context"
 type MatchmakingProcessor interface {
 	// ProcessMatchRequest stores and enqueues the user.
 	ProcessMatchRequest(ctx context.Context, user User) error
+
+	// SkipPair prevents the users from being matched again during the skip cooldown.
+	SkipPair(ctx context.Context, userID, skippedUserID string) error
+
+	// RespondToMatch records whether the user accepts the proposed match.
+	RespondToMatch(ctx context.Context, matchID, userID string, accepted bool) error
+
+	// CancelMatchRequest removes the user from the waiting users, so it is no longer matched.
+	CancelMatchRequest(ctx context.Context, userID string) error
+
+	// ProcessWaitingUsers cancels the expired match proposals, releases the users whose
+	// wait deadline passed and retries matching the remaining waiting users.
+	ProcessWaitingUsers(ctx context.Context) error
+
+	// NotifyQueueStatus tells every waiting user its queue status.
+	NotifyQueueStatus(ctx context.Context) error
 }
 
 // StableMatchFinder defines the interface for a stable matching algorithm.
@@ -18,3 +34,11 @@ type StableMatchFinder interface {
 	// The algorithm should return nil if no matches are possible.
 	FindStableMatches(setA, setB []*User) []int
 }
+
+// PoolMatchFinder is implemented by the matching algorithms that match the users
+// of a single pool with each other, instead of two disjoint sets.
+type PoolMatchFinder interface {
+	// FindPoolMatches returns a list of indexes where matches[i] = j means that
+	// users[i] is matched with users[j], and matches[j] = i. Unmatched users are -1.
+	FindPoolMatches(users []*User) []int
+}
@@ -0,0 +1,407 @@
+package matchdomain
+
+import (
+	"sort"
+)
+
+var (
+	_ StableMatchFinder = (*StableRoommatesService)(nil)
+	_ PoolMatchFinder   = (*StableRoommatesService)(nil)
+)
+
+// StableRoommatesService is the implementation of Irving's stable roommates algorithm,
+// matching the users of a single pool with each other. When the pool admits no stable
+// matching, it falls back to a maximum matching.
+type StableRoommatesService struct {
+	scorer MatchScorer
+}
+
+// StableRoommatesOption defines a functional option to configure the StableRoommatesService.
+type StableRoommatesOption func(*StableRoommatesService)
+
+// WithRoommatesScorer sets the scorer used to order the preference lists.
+func WithRoommatesScorer(scorer MatchScorer) StableRoommatesOption {
+	return func(s *StableRoommatesService) {
+		s.scorer = scorer
+	}
+}
+
+// NewStableRoommatesMatcher creates a StableRoommatesService ranking the candidates
+// with a WeightedScorer using the default weights, unless another scorer is given.
+func NewStableRoommatesMatcher(opts ...StableRoommatesOption) *StableRoommatesService {
+	s := &StableRoommatesService{
+		scorer: NewWeightedScorer(DefaultScoreWeights()),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// FindStableMatches matches the users of both sets as a single pool, returning
+// the matches from setA to setB. Users matched within the same set are left unmatched.
+func (s *StableRoommatesService) FindStableMatches(setA, setB []*User) []int {
+	if len(setA) == 0 || len(setB) == 0 {
+		return nil
+	}
+
+	pool := make([]*User, 0, len(setA)+len(setB))
+	pool = append(pool, setA...)
+
+	// users of setB that are also in setA only appear once in the pool
+	inPool := make(map[string]bool, len(setA))
+	for _, user := range setA {
+		inPool[user.ID()] = true
+	}
+	indexB := make(map[int]int, len(setB))
+	for bIndex, user := range setB {
+		if inPool[user.ID()] {
+			continue
+		}
+		indexB[len(pool)] = bIndex
+		pool = append(pool, user)
+	}
+
+	poolMatches := s.FindPoolMatches(pool)
+	matches := make([]int, len(setA))
+	for aIndex := range setA {
+		matches[aIndex] = -1
+		if bIndex, ok := indexB[poolMatches[aIndex]]; ok {
+			matches[aIndex] = bIndex
+		}
+	}
+	return matches
+}
+
+// FindPoolMatches builds preference lists of the mutually compatible users ordered by score
+// and runs Irving's algorithm over them. When no stable matching exists, the users are
+// matched greedily from the highest to the lowest mutual score, and the matching is then
+// grown to a maximum one.
+func (s *StableRoommatesService) FindPoolMatches(users []*User) []int {
+	preferences := make([][]int, len(users))
+	for idx, user := range users {
+		preferences[idx] = rankCandidates(s.scorer, user, users)
+	}
+
+	if matches, ok := newRoommatesTable(preferences).solve(); ok {
+		return matches
+	}
+	return maximumPoolMatches(preferences, s.greedyMatches(users, preferences))
+}
+
+// greedyMatches pairs the mutually compatible users from the highest to the lowest mutual score.
+func (s *StableRoommatesService) greedyMatches(users []*User, preferences [][]int) []int {
+	type edge struct {
+		u, v  int
+		score float64
+	}
+
+	var edges []edge
+	for u, candidates := range preferences {
+		for _, v := range candidates {
+			if u < v {
+				score := s.scorer.Score(users[u], users[v]) + s.scorer.Score(users[v], users[u])
+				edges = append(edges, edge{u: u, v: v, score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].score > edges[j].score
+	})
+
+	matches := make([]int, len(users))
+	for i := range matches {
+		matches[i] = -1
+	}
+	for _, e := range edges {
+		if matches[e.u] == -1 && matches[e.v] == -1 {
+			matches[e.u], matches[e.v] = e.v, e.u
+		}
+	}
+	return matches
+}
+
+// maximumPoolMatches grows the given matching of the pool to a maximum one with Edmonds'
+// blossom algorithm, augmenting it from every unmatched user. Augmenting never unmatches
+// a user, it only changes the partners along the path. It runs in O(n³).
+func maximumPoolMatches(adjacency [][]int, matches []int) []int {
+	n := len(adjacency)
+	base := make([]int, n)
+	parent := make([]int, n)
+	used := make([]bool, n)
+	blossom := make([]bool, n)
+
+	// lca finds the base of the blossom closing the odd cycle through a and b
+	lca := func(a, b int) int {
+		inPath := make([]bool, n)
+		for {
+			a = base[a]
+			inPath[a] = true
+			if matches[a] == -1 {
+				break
+			}
+			a = parent[matches[a]]
+		}
+		for {
+			b = base[b]
+			if inPath[b] {
+				return b
+			}
+			b = parent[matches[b]]
+		}
+	}
+
+	// markPath marks the blossom from v down to its base, linking the path back to child
+	markPath := func(v, b, child int) {
+		for base[v] != b {
+			blossom[base[v]], blossom[base[matches[v]]] = true, true
+			parent[v] = child
+			child = matches[v]
+			v = parent[matches[v]]
+		}
+	}
+
+	// findPath searches an augmenting path from root, returning its unmatched end or -1
+	findPath := func(root int) int {
+		for i := range n {
+			base[i], parent[i], used[i] = i, -1, false
+		}
+		used[root] = true
+		queue := []int{root}
+
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, to := range adjacency[v] {
+				if base[v] == base[to] || matches[v] == to {
+					continue
+				}
+
+				if to == root || (matches[to] != -1 && parent[matches[to]] != -1) {
+					// odd cycle, contract it into its base
+					cur := lca(v, to)
+					clear(blossom)
+					markPath(v, cur, to)
+					markPath(to, cur, v)
+					for i := range n {
+						if blossom[base[i]] {
+							base[i] = cur
+							if !used[i] {
+								used[i] = true
+								queue = append(queue, i)
+							}
+						}
+					}
+				} else if parent[to] == -1 {
+					parent[to] = v
+					if matches[to] == -1 {
+						return to
+					}
+					used[matches[to]] = true
+					queue = append(queue, matches[to])
+				}
+			}
+		}
+		return -1
+	}
+
+	for root := range n {
+		if matches[root] != -1 {
+			continue
+		}
+		for v := findPath(root); v != -1; {
+			pv := parent[v]
+			next := matches[pv]
+			matches[v], matches[pv] = pv, v
+			v = next
+		}
+	}
+	return matches
+}
+
+// roommatesTable holds the preference lists reduced by Irving's algorithm.
+// Removing a pair removes each user from the other's list.
+type roommatesTable struct {
+	preferences [][]int
+	ranks       []map[int]int
+	removed     [][]bool
+	head, tail  []int
+}
+
+func newRoommatesTable(preferences [][]int) *roommatesTable {
+	t := &roommatesTable{
+		preferences: preferences,
+		ranks:       make([]map[int]int, len(preferences)),
+		removed:     make([][]bool, len(preferences)),
+		head:        make([]int, len(preferences)),
+		tail:        make([]int, len(preferences)),
+	}
+
+	for u, candidates := range preferences {
+		t.ranks[u] = make(map[int]int, len(candidates))
+		for rank, v := range candidates {
+			t.ranks[u][v] = rank
+		}
+		t.removed[u] = make([]bool, len(candidates))
+		t.tail[u] = len(candidates) - 1
+	}
+	return t
+}
+
+// solve runs both phases of Irving's algorithm, returning false
+// when the preference lists admit no stable matching.
+func (t *roommatesTable) solve() ([]int, bool) {
+	t.propose()
+
+	// the users with an empty list after the proposals are unmatched in every stable matching
+	n := len(t.preferences)
+	hadPartner := make([]bool, n)
+	for u := range n {
+		hadPartner[u] = t.first(u) != -1
+	}
+
+	for {
+		start := -1
+		for u := range n {
+			if t.second(u) != -1 {
+				start = u
+				break
+			}
+		}
+		if start == -1 {
+			break
+		}
+
+		if !t.eliminateRotation(start) {
+			return nil, false
+		}
+
+		for u := range n {
+			if hadPartner[u] && t.first(u) == -1 {
+				return nil, false
+			}
+		}
+	}
+
+	matches := make([]int, n)
+	for u := range n {
+		matches[u] = t.first(u)
+		if v := matches[u]; v != -1 && t.first(v) != u {
+			return nil, false
+		}
+	}
+	return matches, true
+}
+
+// propose runs the first phase: every user proposes to the first user of its list,
+// which holds the best proposal received and drops the candidates ranked below it.
+func (t *roommatesTable) propose() {
+	holder := make([]int, len(t.preferences))
+	free := make([]int, len(t.preferences))
+	for u := range t.preferences {
+		holder[u] = -1
+		free[u] = u
+	}
+
+	for len(free) > 0 {
+		u := free[len(free)-1]
+		free = free[:len(free)-1]
+
+		v := t.first(u)
+		if v == -1 {
+			continue
+		}
+
+		// v prefers u over its current holder, who was dropped in the previous truncation otherwise
+		previous := holder[v]
+		holder[v] = u
+		t.truncateAfter(v, u)
+		if previous != -1 && previous != u {
+			free = append(free, previous)
+		}
+	}
+}
+
+// eliminateRotation finds the rotation reached from the given user and removes it from the table.
+func (t *roommatesTable) eliminateRotation(start int) bool {
+	var xs []int
+	seen := make(map[int]int)
+	x := start
+	for {
+		if pos, ok := seen[x]; ok {
+			xs = xs[pos:]
+			break
+		}
+		seen[x] = len(xs)
+		xs = append(xs, x)
+
+		y := t.second(x)
+		if y == -1 {
+			return false
+		}
+		x = t.last(y)
+		if x == -1 {
+			return false
+		}
+	}
+
+	// y(i+1) is the second of x(i), which drops every candidate ranked below x(i)
+	ys := make([]int, len(xs))
+	for i, x := range xs {
+		ys[i] = t.second(x)
+	}
+	for i, x := range xs {
+		t.truncateAfter(ys[i], x)
+	}
+	return true
+}
+
+// truncateAfter removes from the list of u every candidate ranked below v.
+func (t *roommatesTable) truncateAfter(u, v int) {
+	rank := t.ranks[u][v]
+	for t.tail[u] > rank {
+		if w := t.preferences[u][t.tail[u]]; !t.removed[u][t.tail[u]] {
+			t.remove(u, w)
+		}
+		t.tail[u]--
+	}
+}
+
+func (t *roommatesTable) remove(u, v int) {
+	t.removed[u][t.ranks[u][v]] = true
+	t.removed[v][t.ranks[v][u]] = true
+}
+
+func (t *roommatesTable) first(u int) int {
+	for t.head[u] <= t.tail[u] && t.removed[u][t.head[u]] {
+		t.head[u]++
+	}
+	if t.head[u] > t.tail[u] {
+		return -1
+	}
+	return t.preferences[u][t.head[u]]
+}
+
+func (t *roommatesTable) second(u int) int {
+	if t.first(u) == -1 {
+		return -1
+	}
+	for i := t.head[u] + 1; i <= t.tail[u]; i++ {
+		if !t.removed[u][i] {
+			return t.preferences[u][i]
+		}
+	}
+	return -1
+}
+
+func (t *roommatesTable) last(u int) int {
+	for t.tail[u] >= t.head[u] && t.removed[u][t.tail[u]] {
+		t.tail[u]--
+	}
+	if t.tail[u] < t.head[u] {
+		return -1
+	}
+	return t.preferences[u][t.tail[u]]
+}
@@ -0,0 +1,271 @@
+package matchdomain_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestStableRoommatesMatcher_FindPoolMatches(t *testing.T) {
+	t.Run("should find the stable matching of the pool", func(t *testing.T) {
+		// Irving's example, with the unique stable matching {1,6} {2,4} {3,5}
+		scorer := newRankScorer(map[string][]string{
+			"1": {"3", "4", "2", "6", "5"},
+			"2": {"6", "5", "4", "1", "3"},
+			"3": {"2", "4", "5", "1", "6"},
+			"4": {"5", "2", "3", "6", "1"},
+			"5": {"3", "1", "2", "4", "6"},
+			"6": {"5", "1", "3", "4", "2"},
+		})
+		users := newPoolUsers("1", "2", "3", "4", "5", "6")
+
+		matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).FindPoolMatches(users)
+		require.Equal(t, []int{5, 3, 4, 1, 2, 0}, matches)
+	})
+
+	t.Run("should fall back to a maximal matching when no stable matching exists", func(t *testing.T) {
+		// a, b and c prefer each other in a cycle and nobody wants d
+		scorer := newRankScorer(map[string][]string{
+			"a": {"b", "c", "d"},
+			"b": {"c", "a", "d"},
+			"c": {"a", "b", "d"},
+			"d": {"a", "b", "c"},
+		})
+		users := newPoolUsers("a", "b", "c", "d")
+
+		matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).FindPoolMatches(users)
+		requireValidPoolMatches(t, matches)
+		for i, j := range matches {
+			require.NotEqual(t, -1, j, "user %s should be matched", users[i].ID())
+		}
+	})
+
+	t.Run("should fall back to a maximum matching when the greedy one is not", func(t *testing.T) {
+		// a, b, c and d admit no stable matching, and q and r prefer each other
+		// although matching them leaves p and s unmatched
+		scorer := newRankScorer(map[string][]string{
+			"a": {"b", "c", "d"},
+			"b": {"c", "a", "d"},
+			"c": {"a", "b", "d"},
+			"d": {"a", "b", "c"},
+			"p": {"q"},
+			"q": {"r", "p"},
+			"r": {"q", "s"},
+			"s": {"r"},
+		})
+		users := append(newPoolUsers("a", "b", "c", "d"),
+			newRestrictedUser("p", "q"),
+			newRestrictedUser("q", "p", "r"),
+			newRestrictedUser("r", "q", "s"),
+			newRestrictedUser("s", "r"),
+		)
+
+		matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).FindPoolMatches(users)
+		requireValidPoolMatches(t, matches)
+		for i, j := range matches {
+			require.NotEqual(t, -1, j, "user %s should be matched", users[i].ID())
+		}
+	})
+
+	t.Run("should find a maximum matching of random pools without a stable one", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(9, 10))
+
+		for round := range 200 {
+			users := newRandomUsers(rnd, fmt.Sprintf("R%d", round), 2+rnd.IntN(9))
+			ids := make([]string, len(users))
+			for i, user := range users {
+				ids[i] = user.ID()
+			}
+
+			preferences := make(map[string][]string, len(ids))
+			for _, id := range ids {
+				preferences[id] = slices.Clone(ids)
+				rnd.Shuffle(len(preferences[id]), func(i, j int) {
+					preferences[id][i], preferences[id][j] = preferences[id][j], preferences[id][i]
+				})
+			}
+			scorer := newRankScorer(preferences)
+			if hasStablePoolMatching(scorer, users, make([]int, 0, len(users))) {
+				continue
+			}
+
+			matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).FindPoolMatches(users)
+			requireValidPoolMatches(t, matches)
+			require.Equal(t, maximumPoolMatchingSize(users), countPoolMatches(matches), "round %d", round)
+		}
+	})
+
+	t.Run("should only match mutually compatible users", func(t *testing.T) {
+		users := []*domain.User{
+			domain.NewUser("alice", 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male)),
+			domain.NewUser("carol", 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male)),
+			domain.NewUser("bob", 25, gender.Male, matchmaking.DefaultPreferences().WithGender(gender.Female)),
+		}
+
+		matches := domain.NewStableRoommatesMatcher().FindPoolMatches(users)
+		requireValidPoolMatches(t, matches)
+		require.NotEqual(t, 1, matches[0])
+		require.Equal(t, 2, matches[0]+matches[1]+1, "bob is matched with one of the women")
+	})
+
+	t.Run("should return a stable matching whenever one exists", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(3, 4))
+
+		for round := range 200 {
+			n := 2 + rnd.IntN(7)
+			ids := make([]string, n)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("U%d-%d", round, i)
+			}
+
+			preferences := make(map[string][]string, n)
+			for _, id := range ids {
+				for _, other := range ids {
+					if other != id {
+						preferences[id] = append(preferences[id], other)
+					}
+				}
+				rnd.Shuffle(len(preferences[id]), func(i, j int) {
+					preferences[id][i], preferences[id][j] = preferences[id][j], preferences[id][i]
+				})
+			}
+
+			scorer := newRankScorer(preferences)
+			users := newPoolUsers(ids...)
+			matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).FindPoolMatches(users)
+			requireValidPoolMatches(t, matches)
+
+			if hasStablePoolMatching(scorer, users, make([]int, 0, n)) {
+				require.True(t, isStablePoolMatching(scorer, users, matches), "round %d is not stable", round)
+			}
+		}
+	})
+}
+
+func TestStableRoommatesMatcher_FindStableMatches(t *testing.T) {
+	scorer := newRankScorer(map[string][]string{
+		"a1": {"b1", "a2", "b2"},
+		"a2": {"a1", "b2", "b1"},
+		"b1": {"a1", "a2", "b2"},
+		"b2": {"a2", "a1", "b1"},
+	})
+	users := newPoolUsers("a1", "a2", "b1", "b2")
+
+	matches := domain.NewStableRoommatesMatcher(domain.WithRoommatesScorer(scorer)).
+		FindStableMatches(users[:2], users[2:])
+	require.Equal(t, []int{0, 1}, matches)
+}
+
+// rankScorer scores the candidates by their position in fixed preference lists.
+type rankScorer map[string]map[string]float64
+
+func newRankScorer(preferences map[string][]string) rankScorer {
+	scorer := make(rankScorer, len(preferences))
+	for id, candidates := range preferences {
+		scorer[id] = make(map[string]float64, len(candidates))
+		for rank, candidate := range candidates {
+			scorer[id][candidate] = float64(len(candidates) - rank)
+		}
+	}
+	return scorer
+}
+
+func (s rankScorer) Score(user, candidate *domain.User) float64 {
+	return s[user.ID()][candidate.ID()]
+}
+
+func newPoolUsers(ids ...string) []*domain.User {
+	users := make([]*domain.User, len(ids))
+	for i, id := range ids {
+		users[i] = domain.NewUser(id, 30, gender.Unspecified, matchmaking.DefaultPreferences())
+	}
+	return users
+}
+
+// maximumPoolMatchingSize enumerates every matching of the compatible users, returning the largest size.
+func maximumPoolMatchingSize(users []*domain.User) int {
+	if len(users) < 2 {
+		return 0
+	}
+
+	// the first user is either left unmatched or matched with a compatible user
+	best := maximumPoolMatchingSize(users[1:])
+	for i := 1; i < len(users); i++ {
+		if !compatible(users[0], users[i]) {
+			continue
+		}
+		rest := slices.Concat(users[1:i], users[i+1:])
+		best = max(best, 1+maximumPoolMatchingSize(rest))
+	}
+	return best
+}
+
+func countPoolMatches(matches []int) int {
+	count := 0
+	for i, j := range matches {
+		if j > i {
+			count++
+		}
+	}
+	return count
+}
+
+func requireValidPoolMatches(t *testing.T, matches []int) {
+	t.Helper()
+
+	for i, j := range matches {
+		if j != -1 {
+			require.NotEqual(t, i, j)
+			require.Equal(t, i, matches[j], "matches must be symmetric")
+		}
+	}
+}
+
+// hasStablePoolMatching enumerates every matching of the pool looking for a stable one.
+func hasStablePoolMatching(scorer domain.MatchScorer, users []*domain.User, matches []int) bool {
+	i := len(matches)
+	if i == len(users) {
+		return isStablePoolMatching(scorer, users, matches)
+	}
+
+	// leave users[i] unmatched, or match it with any unmatched user before it
+	if matches = append(matches, -1); hasStablePoolMatching(scorer, users, matches) {
+		return true
+	}
+	for j := range i {
+		if matches[j] != -1 {
+			continue
+		}
+		matches[i], matches[j] = j, i
+		if hasStablePoolMatching(scorer, users, matches) {
+			return true
+		}
+		matches[i], matches[j] = -1, -1
+	}
+	return false
+}
+
+func isStablePoolMatching(scorer domain.MatchScorer, users []*domain.User, matches []int) bool {
+	prefers := func(u, candidate int) bool {
+		if matches[u] == -1 {
+			return true
+		}
+		return scorer.Score(users[u], users[candidate]) > scorer.Score(users[u], users[matches[u]])
+	}
+
+	for u := range users {
+		for v := u + 1; v < len(users); v++ {
+			if matches[u] != v && prefers(u, v) && prefers(v, u) {
+				return false
+			}
+		}
+	}
+	return true
+}
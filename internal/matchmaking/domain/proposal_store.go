@@ -0,0 +1,19 @@
+package matchdomain
+
+import (
+	"context"
+	"time"
+)
+
+// ProposalStore keeps the proposed matches waiting for both users to accept them
+// until they expire.
+type ProposalStore interface {
+	// AddProposal stores the proposed match until the given expiration time.
+	AddProposal(ctx context.Context, matchID string, expiresAt time.Time) error
+
+	// RemoveProposal removes the proposed match once it is confirmed or cancelled.
+	RemoveProposal(ctx context.Context, matchID string) error
+
+	// ExpiredProposals returns the proposed matches expired at the given time.
+	ExpiredProposals(ctx context.Context, at time.Time) ([]string, error)
+}
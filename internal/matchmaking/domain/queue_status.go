@@ -0,0 +1,135 @@
+package matchdomain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QueueStatus is the progress of a user waiting for a match.
+type QueueStatus struct {
+	UserID string
+	// CompatibleUsers is the number of waiting users the user can be matched with,
+	// up to MaxCompatibleUsers.
+	CompatibleUsers int
+	// Position is the 1-based position of the user among the waiting users of its
+	// preference segment, the prioritized and the longest waiting first.
+	Position int
+	// EstimatedWait is the expected time until the user is matched, given the recent
+	// match rate of its preference segment. It is zero when there were no recent matches.
+	EstimatedWait time.Duration
+}
+
+// MaxCompatibleUsers caps the compatible users counted for every waiting user. The users
+// only need to know there are plenty, and busy pools make counting all of them too slow.
+const MaxCompatibleUsers = 100
+
+// QueueStatusNotifier notifies the waiting users of their queue status.
+type QueueStatusNotifier interface {
+	NotifyQueueStatus(ctx context.Context, status QueueStatus) error
+}
+
+// PreferenceSegment groups the users by their gender and the gender they look for,
+// which mostly decides how fast they are matched.
+func PreferenceSegment(u *User) string {
+	return u.Gender().String() + ":" + u.EffectivePreferences().Gender.String()
+}
+
+// MatchRates tracks the users matched recently in every preference segment.
+type MatchRates struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	matched map[string][]time.Time
+}
+
+// NewMatchRates creates a MatchRates tracking the users matched within the given window.
+func NewMatchRates(window time.Duration) *MatchRates {
+	return &MatchRates{
+		window:  window,
+		matched: make(map[string][]time.Time),
+	}
+}
+
+// Record records that the user was matched at the given time.
+func (r *MatchRates) Record(u *User, at time.Time) {
+	segment := PreferenceSegment(u)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.matched[segment] = append(r.prune(segment, at), at)
+}
+
+// EstimateWait returns the expected wait of the user at the given position of its segment,
+// assuming the users keep being matched at the recent rate. It is zero without recent matches.
+func (r *MatchRates) EstimateWait(u *User, position int, now time.Time) time.Duration {
+	segment := PreferenceSegment(u)
+
+	r.mu.Lock()
+	matched := len(r.prune(segment, now))
+	r.mu.Unlock()
+
+	if matched == 0 {
+		return 0
+	}
+	return time.Duration(position) * r.window / time.Duration(matched)
+}
+
+// prune drops the matches of the segment that are out of the window.
+// It must be called with the lock held.
+func (r *MatchRates) prune(segment string, now time.Time) []time.Time {
+	matched := r.matched[segment]
+
+	from := now.Add(-r.window)
+	idx := 0
+	for idx < len(matched) && !matched[idx].After(from) {
+		idx++
+	}
+
+	matched = matched[idx:]
+	if len(matched) == 0 {
+		delete(r.matched, segment)
+		return nil
+	}
+	r.matched[segment] = matched
+	return matched
+}
+
+// QueueStatuses returns the queue status of the waiting users, which must be sorted
+// by priority. The estimated waits are only set when rates is not nil.
+// The compatible users are only looked for among the candidates of the secondary indexes.
+func QueueStatuses(waiting []*User, rates *MatchRates, now time.Time) []QueueStatus {
+	index := newCandidateIndex(waiting)
+
+	statuses := make([]QueueStatus, 0, len(waiting))
+	positions := make(map[string]int)
+	for _, user := range waiting {
+		segment := PreferenceSegment(user)
+		positions[segment]++
+
+		status := QueueStatus{
+			UserID:          user.ID(),
+			CompatibleUsers: countCompatible(user, index.candidates(user)),
+			Position:        positions[segment],
+		}
+		if rates != nil {
+			status.EstimatedWait = rates.EstimateWait(user, status.Position, now)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// countCompatible counts the candidates compatible with the user, up to MaxCompatibleUsers.
+func countCompatible(user *User, candidates []*User) int {
+	count := 0
+	for _, candidate := range candidates {
+		if isMutuallyCompatible(user, candidate) {
+			count++
+			if count == MaxCompatibleUsers {
+				break
+			}
+		}
+	}
+	return count
+}
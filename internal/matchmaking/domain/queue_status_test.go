@@ -0,0 +1,68 @@
+package matchdomain_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestQueueStatuses(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	woman := func(id string) *matchdomain.User {
+		return matchdomain.NewUser(id, 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male))
+	}
+	man := func(id string) *matchdomain.User {
+		return matchdomain.NewUser(id, 25, gender.Male, matchmaking.DefaultPreferences().WithGender(gender.Female))
+	}
+
+	t.Run("should count the compatible users and the position in the segment", func(t *testing.T) {
+		waiting := []*matchdomain.User{woman("alice"), man("bob"), woman("carol"), man("dave"), woman("erin")}
+
+		statuses := matchdomain.QueueStatuses(waiting, nil, now)
+		require.Len(t, statuses, len(waiting))
+
+		got := make(map[string][2]int, len(statuses))
+		for _, status := range statuses {
+			got[status.UserID] = [2]int{status.CompatibleUsers, status.Position}
+			require.Zero(t, status.EstimatedWait)
+		}
+		require.Equal(t, map[string][2]int{
+			"alice": {2, 1},
+			"bob":   {3, 1},
+			"carol": {2, 2},
+			"dave":  {3, 2},
+			"erin":  {2, 3},
+		}, got)
+	})
+
+	t.Run("should stop counting the compatible users past the cap", func(t *testing.T) {
+		waiting := []*matchdomain.User{woman("alice")}
+		for i := range matchdomain.MaxCompatibleUsers + 10 {
+			waiting = append(waiting, man(fmt.Sprintf("man-%d", i)))
+		}
+
+		statuses := matchdomain.QueueStatuses(waiting, nil, now)
+		require.Equal(t, matchdomain.MaxCompatibleUsers, statuses[0].CompatibleUsers)
+		require.Equal(t, 1, statuses[1].CompatibleUsers)
+	})
+
+	t.Run("should estimate the wait from the recent matches of the segment", func(t *testing.T) {
+		rates := matchdomain.NewMatchRates(10 * time.Minute)
+		rates.Record(woman("old"), now.Add(-time.Hour))
+		for i := range 5 {
+			rates.Record(woman("matched"), now.Add(-time.Duration(i)*time.Minute))
+		}
+
+		statuses := matchdomain.QueueStatuses([]*matchdomain.User{woman("alice"), woman("carol"), man("bob")}, rates, now)
+		require.Equal(t, 2*time.Minute, statuses[0].EstimatedWait)
+		require.Equal(t, 4*time.Minute, statuses[1].EstimatedWait)
+		require.Zero(t, statuses[2].EstimatedWait, "no recent matches in the segment")
+	})
+}
@@ -0,0 +1,61 @@
+package matchdomain
+
+import (
+	"time"
+
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+// RelaxationPolicy defines how the preferences of a waiting user are widened step by step,
+// so users with narrow preferences still get a match before their wait deadline.
+type RelaxationPolicy struct {
+	// Interval is the waiting time between two relaxation steps. Zero disables the relaxation.
+	Interval time.Duration
+	// MaxSteps caps the number of steps applied. Zero means no cap.
+	MaxSteps int
+	// AgeRangeStep widens the accepted age range on both ends at every step.
+	AgeRangeStep int32
+	// DistanceStepFactor multiplies the max distance at every step. Values below 1 keep it.
+	DistanceStepFactor float64
+	// DropInterestsAfterSteps ignores the interests once the given step is reached. Zero keeps them.
+	DropInterestsAfterSteps int
+}
+
+// Steps returns the number of relaxation steps reached after waiting for the given time.
+func (p RelaxationPolicy) Steps(waited time.Duration) int {
+	if p.Interval <= 0 || waited < p.Interval {
+		return 0
+	}
+
+	steps := int(waited / p.Interval)
+	if p.MaxSteps > 0 && steps > p.MaxSteps {
+		steps = p.MaxSteps
+	}
+	return steps
+}
+
+// Relax returns a copy of the preferences widened for a user that has been waiting for the given time.
+func (p RelaxationPolicy) Relax(prefs matchmaking.Preferences, waited time.Duration) matchmaking.Preferences {
+	steps := p.Steps(waited)
+	if steps == 0 {
+		return prefs
+	}
+
+	if p.AgeRangeStep > 0 {
+		widening := p.AgeRangeStep * int32(steps)
+		prefs = prefs.
+			WithMinAge(prefs.MinAge - widening).
+			WithMaxAge(prefs.MaxAge + widening)
+	}
+
+	if p.DistanceStepFactor > 1 && prefs.MaxDistanceKm > 0 {
+		for range steps {
+			prefs.MaxDistanceKm *= p.DistanceStepFactor
+		}
+	}
+
+	if p.DropInterestsAfterSteps > 0 && steps >= p.DropInterestsAfterSteps {
+		prefs.Interests = nil
+	}
+	return prefs
+}
@@ -0,0 +1,135 @@
+package matchdomain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	domain_error "github.com/xfrr/randomtalk/internal/shared/domain"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+const (
+	// NeutralReputation is the reputation score of the users nobody rated yet.
+	NeutralReputation = 0.5
+
+	// reputationPriorVotes is the number of virtual votes, half positive and half negative,
+	// added to the ratings of every user so a few ratings do not move the score to the extremes.
+	reputationPriorVotes = 4
+	// maxRecentRatings bounds the ratings remembered to ignore the repeated ones.
+	maxRecentRatings = 100
+)
+
+var (
+	ErrInvalidRating = domain_error.New("invalid rating")
+	ErrSelfRating    = domain_error.New("users cannot rate themselves")
+)
+
+// Rating is the feedback a user gives about the partner of a finished chat.
+type Rating struct {
+	RaterUserID string
+	RatedUserID string
+	MatchID     string
+	// Liked is the thumbs up (true) or down (false) of the rater.
+	Liked   bool
+	Tags    []matchmaking.RatingTag
+	RatedAt time.Time
+}
+
+// Validate checks the rating can be added to a reputation.
+func (r Rating) Validate() error {
+	if r.RaterUserID == "" || r.RatedUserID == "" {
+		return ErrInvalidRating
+	}
+	if r.RaterUserID == r.RatedUserID {
+		return ErrSelfRating
+	}
+	return nil
+}
+
+// IsReport reports whether the rating reports a misbehaving user.
+func (r Rating) IsReport() bool {
+	return slices.ContainsFunc(r.Tags, matchmaking.RatingTag.IsReport)
+}
+
+// ReputationPolicy decides which users are quarantined because of their low reputation.
+// Quarantined users are only matched with each other.
+type ReputationPolicy struct {
+	// QuarantineScore is the score below which the users are quarantined. Zero disables the quarantine.
+	QuarantineScore float64
+	// MinRatings is the number of ratings a user needs before being quarantined.
+	MinRatings int
+}
+
+// Reputation aggregates the ratings given to a user by its partners.
+type Reputation struct {
+	UserID   string `json:"user_id"`
+	Likes    int    `json:"likes"`
+	Dislikes int    `json:"dislikes"`
+	// Reports counts the ratings tagged as rude, spam or inappropriate.
+	Reports int `json:"reports"`
+	// Recent are the keys of the latest ratings, used to ignore the repeated ones.
+	Recent    []string  `json:"recent,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewReputation creates the neutral reputation of a user nobody rated yet.
+func NewReputation(userID string) Reputation {
+	return Reputation{UserID: userID}
+}
+
+// Ratings returns the number of ratings the user received.
+func (r Reputation) Ratings() int {
+	return r.Likes + r.Dislikes
+}
+
+// Score returns the reputation score in [0, 1]. A report weighs as a second dislike, and
+// the virtual prior votes keep the score of the users with few ratings close to neutral.
+func (r Reputation) Score() float64 {
+	positive := float64(r.Likes) + reputationPriorVotes/2
+	total := float64(r.Likes+r.Dislikes+r.Reports) + reputationPriorVotes
+	return positive / total
+}
+
+// IsQuarantined reports whether the policy quarantines the user. Users nobody rated are never quarantined.
+func (r Reputation) IsQuarantined(policy ReputationPolicy) bool {
+	if policy.QuarantineScore <= 0 || r.Ratings() == 0 || r.Ratings() < policy.MinRatings {
+		return false
+	}
+	return r.Score() < policy.QuarantineScore
+}
+
+// Add returns the reputation with the rating added. It reports false when the rater
+// already rated the user for the same match, leaving the reputation untouched.
+func (r Reputation) Add(rating Rating) (Reputation, bool) {
+	key := rating.RaterUserID + ":" + rating.MatchID
+	if slices.Contains(r.Recent, key) {
+		return r, false
+	}
+
+	if rating.Liked {
+		r.Likes++
+	} else {
+		r.Dislikes++
+	}
+	if rating.IsReport() {
+		r.Reports++
+	}
+
+	r.Recent = append(slices.Clone(r.Recent), key)
+	if len(r.Recent) > maxRecentRatings {
+		r.Recent = r.Recent[len(r.Recent)-maxRecentRatings:]
+	}
+	r.UpdatedAt = rating.RatedAt
+	return r, true
+}
+
+// ReputationStore keeps the reputation of the users.
+type ReputationStore interface {
+	// AddRating adds the rating to the reputation of the rated user.
+	// Repeated ratings of the same match are ignored.
+	AddRating(ctx context.Context, rating Rating) error
+
+	// Get returns the reputation of the user, which is neutral when nobody rated the user yet.
+	Get(ctx context.Context, userID string) (Reputation, error)
+}
@@ -0,0 +1,75 @@
+package matchdomain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestReputation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	rate := func(reputation matchdomain.Reputation, raterID string, liked bool, tags ...matchmaking.RatingTag) matchdomain.Reputation {
+		t.Helper()
+
+		reputation, added := reputation.Add(matchdomain.Rating{
+			RaterUserID: raterID,
+			RatedUserID: reputation.UserID,
+			MatchID:     "match-" + raterID,
+			Liked:       liked,
+			Tags:        tags,
+			RatedAt:     now,
+		})
+		require.True(t, added)
+		return reputation
+	}
+
+	t.Run("should start neutral", func(t *testing.T) {
+		reputation := matchdomain.NewReputation("alice")
+		require.InDelta(t, matchdomain.NeutralReputation, reputation.Score(), 1e-9)
+		require.False(t, reputation.IsQuarantined(matchdomain.ReputationPolicy{QuarantineScore: 0.9}))
+	})
+
+	t.Run("should weigh the reports as a second dislike", func(t *testing.T) {
+		reputation := matchdomain.NewReputation("alice")
+		reputation = rate(reputation, "bob", true)
+		reputation = rate(reputation, "carol", false)
+		reputation = rate(reputation, "dave", false, matchmaking.RatingTagSpam)
+
+		require.Equal(t, 1, reputation.Likes)
+		require.Equal(t, 2, reputation.Dislikes)
+		require.Equal(t, 1, reputation.Reports)
+		require.InDelta(t, 3.0/8.0, reputation.Score(), 1e-9)
+	})
+
+	t.Run("should ignore the repeated ratings of a match", func(t *testing.T) {
+		reputation := rate(matchdomain.NewReputation("alice"), "bob", false)
+
+		_, added := reputation.Add(matchdomain.Rating{
+			RaterUserID: "bob",
+			RatedUserID: "alice",
+			MatchID:     "match-bob",
+			Liked:       false,
+		})
+		require.False(t, added)
+	})
+
+	t.Run("should quarantine low scores after enough ratings", func(t *testing.T) {
+		policy := matchdomain.ReputationPolicy{QuarantineScore: 0.2, MinRatings: 5}
+
+		reputation := matchdomain.NewReputation("alice")
+		for _, raterID := range []string{"r1", "r2", "r3", "r4"} {
+			reputation = rate(reputation, raterID, false, matchmaking.RatingTagRude)
+		}
+		require.Less(t, reputation.Score(), policy.QuarantineScore)
+		require.False(t, reputation.IsQuarantined(policy), "not enough ratings yet")
+
+		reputation = rate(reputation, "r5", false)
+		require.True(t, reputation.IsQuarantined(policy))
+		require.False(t, reputation.IsQuarantined(matchdomain.ReputationPolicy{}), "quarantine disabled")
+	})
+}
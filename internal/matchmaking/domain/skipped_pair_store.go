@@ -0,0 +1,16 @@
+package matchdomain
+
+import (
+	"context"
+	"time"
+)
+
+// SkippedPairStore keeps the pairs of users that must not be matched again
+// until their skip cooldown expires. Pairs are unordered.
+type SkippedPairStore interface {
+	// AddPair stores the pair until the given expiration time.
+	AddPair(ctx context.Context, userID, otherUserID string, expiresAt time.Time) error
+
+	// HasPair reports whether the pair is still cooling down at the given time.
+	HasPair(ctx context.Context, userID, otherUserID string, at time.Time) (bool, error)
+}
@@ -2,10 +2,13 @@ package matchdomain
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
+	"time"
 
 	domain_error "github.com/xfrr/randomtalk/internal/shared/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -16,6 +19,7 @@ var (
 	ErrCandidateDoesNotPreferences  = domain_error.New("candidate does not match preferences")
 	ErrUserNotWaitingForMatch       = domain_error.New("user is not waiting for a match")
 	ErrUserNotFound                 = domain_error.New("user not found")
+	ErrUserAlreadyClaimed           = domain_error.New("user already claimed")
 )
 
 // UserStatus represents the status of a user in the matchmaking process.
@@ -66,11 +70,59 @@ func (s *UserStatus) UnmarshalText(text []byte) error {
 
 // User is a domain entity representing a user who can be matched.
 type User struct {
-	id     string
-	age    int32
-	gender gender.Gender
-	prefs  matchmaking.Preferences
-	status UserStatus
+	id       string
+	age      int32
+	gender   gender.Gender
+	location *location.Location
+	prefs    matchmaking.Preferences
+	status   UserStatus
+
+	// requestedAt is when the user started waiting for a match.
+	requestedAt time.Time
+	// relaxedPrefs are the preferences widened while the user waits, if any.
+	relaxedPrefs *matchmaking.Preferences
+	// priority is set when the user goes back to the pool after a cancelled match proposal.
+	priority bool
+	// blockedUserIDs are the users this user never wants to be matched with.
+	blockedUserIDs []string
+	// languages are the BCP-47 tags of the languages spoken by the user.
+	languages []string
+	// reputation is the score given by the user's past partners, neutral when unknown.
+	reputation *float64
+	// quarantined users are only matched with other quarantined users.
+	quarantined bool
+}
+
+// UserOption configures optional User attributes.
+type UserOption func(*User)
+
+// WithLocation sets the current location of the User.
+func WithLocation(loc *location.Location) UserOption {
+	return func(u *User) {
+		u.location = loc
+	}
+}
+
+// WithBlockedUsers sets the users the User never wants to be matched with.
+func WithBlockedUsers(userIDs ...string) UserOption {
+	return func(u *User) {
+		u.blockedUserIDs = slices.Clone(userIDs)
+	}
+}
+
+// WithLanguages sets the BCP-47 tags of the languages spoken by the User.
+func WithLanguages(languages ...string) UserOption {
+	return func(u *User) {
+		u.languages = slices.Clone(languages)
+	}
+}
+
+// WithReputation sets the reputation score of the User and whether it is quarantined.
+func WithReputation(score float64, quarantined bool) UserOption {
+	return func(u *User) {
+		u.reputation = &score
+		u.quarantined = quarantined
+	}
 }
 
 // NewUser constructs a new User with default status=Waiting.
@@ -79,14 +131,20 @@ func NewUser(
 	age int32,
 	g gender.Gender,
 	preferences matchmaking.Preferences,
+	opts ...UserOption,
 ) *User {
-	return &User{
+	u := &User{
 		id:     id,
 		age:    age,
 		gender: g,
 		prefs:  preferences,
 		status: Waiting,
 	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
 // ID returns the user's unique identifier.
@@ -98,9 +156,87 @@ func (u User) Age() int32 { return u.age }
 // Gender returns the user's gender.
 func (u User) Gender() gender.Gender { return u.gender }
 
+// Location returns the user's location, or nil when it is unknown.
+func (u User) Location() *location.Location { return u.location }
+
 // Preferences returns the user's match preferences.
 func (u User) Preferences() matchmaking.Preferences { return u.prefs }
 
+// EffectivePreferences returns the preferences used to find a match for the user,
+// which may have been relaxed while waiting.
+func (u User) EffectivePreferences() matchmaking.Preferences {
+	if u.relaxedPrefs != nil {
+		return *u.relaxedPrefs
+	}
+	return u.prefs
+}
+
+// RequestedAt returns when the user started waiting for a match.
+func (u User) RequestedAt() time.Time { return u.requestedAt }
+
+// Priority reports whether the user is matched before the rest of the waiting users.
+func (u User) Priority() bool { return u.priority }
+
+// BlockedUserIDs returns the users the user never wants to be matched with.
+func (u User) BlockedUserIDs() []string { return u.blockedUserIDs }
+
+// Blocks reports whether the user blocked the given user.
+func (u User) Blocks(userID string) bool { return slices.Contains(u.blockedUserIDs, userID) }
+
+// Languages returns the BCP-47 tags of the languages spoken by the user.
+func (u User) Languages() []string { return u.languages }
+
+// Reputation returns the reputation score of the user, NeutralReputation when unknown.
+func (u User) Reputation() float64 {
+	if u.reputation == nil {
+		return NeutralReputation
+	}
+	return *u.reputation
+}
+
+// Quarantined reports whether the user is only matched with other quarantined users.
+func (u User) Quarantined() bool { return u.quarantined }
+
+// requeued returns a copy of the user that waits again, ahead of the rest when prioritized.
+// The user keeps the time it first requested the match, so its wait deadline does not move.
+func (u User) requeued(priority bool) User {
+	u.status = Waiting
+	u.relaxedPrefs = nil
+	u.priority = priority
+	return u
+}
+
+// WaitDeadline returns when the user stops waiting for a match. The user max wait time
+// is capped by the given max wait time. A zero time means the user waits indefinitely.
+func (u User) WaitDeadline(maxWaitTime time.Duration) time.Time {
+	wait := u.prefs.MaxWaitTime()
+	if wait <= 0 || (maxWaitTime > 0 && wait > maxWaitTime) {
+		wait = maxWaitTime
+	}
+	if wait <= 0 || u.requestedAt.IsZero() {
+		return time.Time{}
+	}
+	return u.requestedAt.Add(wait)
+}
+
+// startWaiting records when the user started waiting for a match.
+func (u *User) startWaiting(at time.Time) {
+	if u.requestedAt.IsZero() {
+		u.requestedAt = at
+	}
+}
+
+// relaxed returns a copy of the user whose effective preferences are relaxed by the policy.
+func (u User) relaxed(policy RelaxationPolicy, now time.Time) *User {
+	if u.requestedAt.IsZero() {
+		return &u
+	}
+
+	prefs := policy.Relax(u.prefs, now.Sub(u.requestedAt))
+	u.relaxedPrefs = &prefs
+	return &u
+}
+
 // Status returns the user's current status.
 func (u User) Status() UserStatus { return u.status }
 
@@ -122,15 +258,29 @@ func (u User) MarshalJSON() ([]byte, error) {
 		ID          string                  `json:"id"`
 		Age         int32                   `json:"age"`
 		Gender      gender.Gender           `json:"gender"`
+		Location    *location.Location      `json:"location,omitempty"`
 		Preferences matchmaking.Preferences `json:"preferences"`
 		Status      UserStatus              `json:"status"`
+		RequestedAt time.Time               `json:"requested_at"`
+		Priority    bool                    `json:"priority,omitempty"`
+		BlockedIDs  []string                `json:"blocked_user_ids,omitempty"`
+		Languages   []string                `json:"languages,omitempty"`
+		Reputation  *float64                `json:"reputation,omitempty"`
+		Quarantined bool                    `json:"quarantined,omitempty"`
 	}
 	return json.Marshal(dto{
 		ID:          u.id,
 		Age:         u.age,
 		Gender:      u.gender,
+		Location:    u.location,
 		Preferences: u.prefs,
 		Status:      u.status,
+		RequestedAt: u.requestedAt,
+		Priority:    u.priority,
+		BlockedIDs:  u.blockedUserIDs,
+		Languages:   u.languages,
+		Reputation:  u.reputation,
+		Quarantined: u.quarantined,
 	})
 }
 
@@ -140,8 +290,15 @@ func (u *User) UnmarshalJSON(data []byte) error {
 		ID          string                  `json:"id"`
 		Age         int32                   `json:"age"`
 		Gender      gender.Gender           `json:"gender"`
+		Location    *location.Location      `json:"location,omitempty"`
 		Preferences matchmaking.Preferences `json:"preferences"`
 		Status      UserStatus              `json:"status"`
+		RequestedAt time.Time               `json:"requested_at"`
+		Priority    bool                    `json:"priority,omitempty"`
+		BlockedIDs  []string                `json:"blocked_user_ids,omitempty"`
+		Languages   []string                `json:"languages,omitempty"`
+		Reputation  *float64                `json:"reputation,omitempty"`
+		Quarantined bool                    `json:"quarantined,omitempty"`
 	}
 	var d dto
 	if err := json.Unmarshal(data, &d); err != nil {
@@ -150,7 +307,14 @@ func (u *User) UnmarshalJSON(data []byte) error {
 	u.id = d.ID
 	u.age = d.Age
 	u.gender = d.Gender
+	u.location = d.Location
 	u.prefs = d.Preferences
 	u.status = d.Status
+	u.requestedAt = d.RequestedAt
+	u.priority = d.Priority
+	u.blockedUserIDs = d.BlockedIDs
+	u.languages = d.Languages
+	u.reputation = d.Reputation
+	u.quarantined = d.Quarantined
 	return nil
 }
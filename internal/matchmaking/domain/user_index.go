@@ -0,0 +1,160 @@
+package matchdomain
+
+import (
+	"encoding/base64"
+	"slices"
+	"strconv"
+
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+// The secondary indexes of the waiting users, to find the candidates without scanning the whole pool.
+const (
+	genderIndex   = "gender"
+	ageIndex      = "age"
+	interestIndex = "interest"
+	geohashIndex  = "geohash"
+
+	// ageBucketSize is the number of years grouped in the same age index entry.
+	ageBucketSize = 5
+	// geohashIndexPrecision is the precision of the geohash cells, about 39x20km.
+	geohashIndexPrecision = 4
+	// maxGeohashQueryCells skips the geohash index when the max distance covers too many cells.
+	maxGeohashQueryCells = 64
+)
+
+// IndexEntries returns the secondary index entries of the user, as "<index>.<value>" keys
+// made of the characters allowed in NATS subjects.
+func (u User) IndexEntries() []string {
+	entries := []string{
+		indexEntry(genderIndex, u.gender.String()),
+		indexEntry(ageIndex, ageBucket(u.age)),
+	}
+
+	for _, interest := range u.prefs.Interests {
+		entries = append(entries, indexEntry(interestIndex, encodeIndexValue(interest)))
+	}
+
+	if u.location != nil && u.location.Coordinates.IsValid() {
+		entries = append(entries, indexEntry(geohashIndex, u.location.Coordinates.Geohash(geohashIndexPrecision)))
+	}
+	return entries
+}
+
+// CandidateQuery returns the index entries that the candidates for the preferences of a user
+// located at origin must have: at least one entry of every group. No groups means that any
+// waiting user is a candidate, and an empty group means that none is.
+//
+// The query is a superset of the candidates satisfying the preferences, which still need to be
+// checked, but never leaves out one of them.
+func CandidateQuery(prefs matchmaking.Preferences, origin *location.Location) [][]string {
+	if prefs.HasLocationConstraint() && origin == nil {
+		// nobody is within reach of a user without location
+		return [][]string{{}}
+	}
+
+	var query [][]string
+	if !prefs.Gender.IsUnspecified() {
+		query = append(query, []string{indexEntry(genderIndex, prefs.Gender.String())})
+	}
+
+	if prefs.MinAge > matchmaking.MinAllowedAge || prefs.MaxAge < matchmaking.MaxAllowedAge {
+		var ages []string
+		for bucket := prefs.MinAge / ageBucketSize; bucket <= prefs.MaxAge/ageBucketSize; bucket++ {
+			ages = append(ages, indexEntry(ageIndex, strconv.Itoa(int(bucket))))
+		}
+		query = append(query, ages)
+	}
+
+	if len(prefs.Interests) > 0 {
+		interests := make([]string, 0, len(prefs.Interests))
+		for _, interest := range prefs.Interests {
+			interests = append(interests, indexEntry(interestIndex, encodeIndexValue(interest)))
+		}
+		query = append(query, interests)
+	}
+
+	if prefs.MaxDistanceKm > 0 {
+		cells := location.GeohashCellsWithin(origin.Coordinates, prefs.MaxDistanceKm,
+			geohashIndexPrecision, maxGeohashQueryCells)
+		if cells != nil {
+			entries := make([]string, 0, len(cells))
+			for _, cell := range cells {
+				entries = append(entries, indexEntry(geohashIndex, cell))
+			}
+			query = append(query, entries)
+		}
+	}
+	return query
+}
+
+// candidateIndex keeps a pool of users in the secondary indexes, to find the candidates
+// of every user of the pool without scanning all of it.
+type candidateIndex struct {
+	users   []*User
+	entries map[string][]int
+}
+
+func newCandidateIndex(users []*User) candidateIndex {
+	idx := candidateIndex{
+		users:   users,
+		entries: make(map[string][]int),
+	}
+	for i, user := range users {
+		for _, entry := range user.IndexEntries() {
+			idx.entries[entry] = append(idx.entries[entry], i)
+		}
+	}
+	return idx
+}
+
+// candidates returns the users of the pool that may be compatible with the user,
+// in the order of the pool. They still need to be checked.
+func (idx candidateIndex) candidates(user *User) []*User {
+	query := CandidateQuery(user.EffectivePreferences(), user.Location())
+	if len(query) == 0 {
+		return idx.users
+	}
+
+	var matched map[int]struct{}
+	for _, group := range query {
+		groupMatched := make(map[int]struct{})
+		for _, entry := range group {
+			for _, i := range idx.entries[entry] {
+				if _, ok := matched[i]; matched == nil || ok {
+					groupMatched[i] = struct{}{}
+				}
+			}
+		}
+		matched = groupMatched
+		if len(matched) == 0 {
+			return nil
+		}
+	}
+
+	positions := make([]int, 0, len(matched))
+	for i := range matched {
+		positions = append(positions, i)
+	}
+	slices.Sort(positions)
+
+	candidates := make([]*User, 0, len(positions))
+	for _, i := range positions {
+		candidates = append(candidates, idx.users[i])
+	}
+	return candidates
+}
+
+func indexEntry(index, value string) string {
+	return index + "." + value
+}
+
+func ageBucket(age int32) string {
+	return strconv.Itoa(int(age / ageBucketSize))
+}
+
+// encodeIndexValue encodes free text values, like interests, as valid subject tokens.
+func encodeIndexValue(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
@@ -0,0 +1,88 @@
+package matchdomain_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchmakinginmemory "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestCandidateQuery(t *testing.T) {
+	t.Run("should not restrict the default preferences", func(t *testing.T) {
+		assert.Empty(t, domain.CandidateQuery(matchmaking.DefaultPreferences(), nil))
+	})
+
+	t.Run("should find nobody for a location constraint without location", func(t *testing.T) {
+		prefs := matchmaking.DefaultPreferences().WithMaxDistanceKm(10)
+		assert.Equal(t, [][]string{{}}, domain.CandidateQuery(prefs, nil))
+	})
+
+	t.Run("should never leave out a user satisfying the preferences", func(t *testing.T) {
+		ctx := context.Background()
+		rnd := rand.New(rand.NewPCG(9, 10))
+		interests := []string{"jazz", "rock", "chess", "films", "travel"}
+		genders := []gender.Gender{gender.Unspecified, gender.Female, gender.Male}
+
+		randomInterests := func() []string {
+			var picked []string
+			for _, interest := range interests {
+				if rnd.IntN(3) == 0 {
+					picked = append(picked, interest)
+				}
+			}
+			return picked
+		}
+		randomLocation := func() *location.Location {
+			if rnd.IntN(10) == 0 {
+				return nil
+			}
+			loc := location.New(40+rnd.Float64(), -3-rnd.Float64())
+			return &loc
+		}
+
+		store := matchmakinginmemory.NewUserStore(nil)
+		users := make([]*domain.User, 300)
+		for i := range users {
+			users[i] = domain.NewUser(fmt.Sprintf("user-%d", i), 18+rnd.Int32N(60), genders[rnd.IntN(3)],
+				matchmaking.DefaultPreferences().WithInterests(randomInterests()),
+				domain.WithLocation(randomLocation()))
+			require.NoError(t, store.AddUser(ctx, *users[i]))
+		}
+
+		for range 100 {
+			minAge := 18 + rnd.Int32N(40)
+			prefs := matchmaking.DefaultPreferences().
+				WithGender(genders[rnd.IntN(3)]).
+				WithMinAge(minAge).
+				WithMaxAge(minAge + rnd.Int32N(30)).
+				WithInterests(randomInterests())
+			if rnd.IntN(2) == 0 {
+				prefs = prefs.WithMaxDistanceKm(5 + rnd.Float64()*80)
+			}
+			origin := randomLocation()
+
+			candidates, err := store.FindCandidates(ctx, prefs, origin)
+			require.NoError(t, err)
+			found := make(map[string]bool, len(candidates))
+			for _, candidate := range candidates {
+				found[candidate.ID()] = true
+			}
+
+			for _, user := range users {
+				if prefs.IsSatisfiedBy(user) && prefs.IsWithinReach(origin, user.Location()) {
+					require.True(t, found[user.ID()], "%s satisfies %+v", user.ID(), prefs)
+				}
+			}
+			assert.LessOrEqual(t, len(candidates), len(users))
+		}
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -12,17 +15,44 @@ import (
 
 var _ MatchmakingProcessor = (*UserMatchProcessor)(nil)
 
+// maxProposalResolveAttempts bounds the retries of the concurrent responses to the same proposed match.
+const maxProposalResolveAttempts = 5
+
 // NotificationsChannel defines push-based notification behavior.
 type NotificationsChannel interface {
 	Notify(ctx context.Context, userID string, match *Match) error
 }
 
+// NoMatchNotifier notifies the users whose wait deadline passed without a match.
+type NoMatchNotifier interface {
+	NotifyNoMatchFound(ctx context.Context, user User, waited time.Duration) error
+}
+
 // UserMatchProcessor is the concrete matchmaking service.
 type UserMatchProcessor struct {
 	matchRepository MatchRepository
 	userStore       UserStore
 	matcher         StableMatchFinder
+	groups          *GroupMatcher
+	notifications   NotificationsChannel
+	noMatchNotifier NoMatchNotifier
+	skippedPairs    SkippedPairStore
+	skipCooldown    time.Duration
+	maxWaitTime     time.Duration
+	relaxation      RelaxationPolicy
+	mode            MatchingMode
+	batchPoolSize   int
+	metrics         MatchMetrics
+	metricsScorer   MatchScorer
+	proposals       ProposalStore
+	proposalTimeout time.Duration
+	queueStatus     QueueStatusNotifier
+	matchRates      *MatchRates
+	now             func() time.Time
 	logger          *zerolog.Logger
+
+	// roundMu prevents the waiting users from being processed by concurrent rounds.
+	roundMu sync.Mutex
 }
 
 // UserMatchMakerOption defines a functional option to configure the UserMatchMaker.
@@ -35,6 +65,95 @@ func WithLogger(logger *zerolog.Logger) UserMatchMakerOption {
 	}
 }
 
+// WithNotificationsChannel sets the channel used to notify the matched users
+// as soon as a new match is confirmed.
+func WithNotificationsChannel(ch NotificationsChannel) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.notifications = ch
+	}
+}
+
+// WithSkipCooldown keeps the users that skipped each other apart for the given cooldown.
+func WithSkipCooldown(store SkippedPairStore, cooldown time.Duration) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.skippedPairs = store
+		s.skipCooldown = cooldown
+	}
+}
+
+// WithMaxWaitTime sets how long the users wait for a match when they have no max wait time,
+// and caps the max wait time of the users. Zero lets the users wait indefinitely.
+func WithMaxWaitTime(maxWaitTime time.Duration) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.maxWaitTime = maxWaitTime
+	}
+}
+
+// WithRelaxationPolicy widens the preferences of the waiting users according to the policy.
+func WithRelaxationPolicy(policy RelaxationPolicy) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.relaxation = policy
+	}
+}
+
+// WithNoMatchNotifier sets the notifier used to tell the users that no match was found
+// before their wait deadline.
+func WithNoMatchNotifier(notifier NoMatchNotifier) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.noMatchNotifier = notifier
+	}
+}
+
+// WithBatchMatching matches the waiting users in rounds over the whole pool instead of
+// as soon as they request a match. Rounds run when ProcessWaitingUsers is called, and when
+// the pool reaches the given size, if positive.
+func WithBatchMatching(poolSize int) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.mode = BatchMatching
+		s.batchPoolSize = poolSize
+	}
+}
+
+// WithMatchMetrics records the created matches, scored by the given scorer.
+func WithMatchMetrics(metrics MatchMetrics, scorer MatchScorer) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.metrics = metrics
+		s.metricsScorer = scorer
+	}
+}
+
+// WithMatchProposals makes both users accept every match before they can start talking.
+// Matches not accepted within the given timeout are cancelled.
+func WithMatchProposals(store ProposalStore, timeout time.Duration) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.proposals = store
+		s.proposalTimeout = timeout
+	}
+}
+
+// WithQueueStatus sets the notifier used to tell the waiting users their queue status,
+// and the match rates used to estimate their wait. The rates may be nil.
+func WithQueueStatus(notifier QueueStatusNotifier, rates *MatchRates) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.queueStatus = notifier
+		s.matchRates = rates
+	}
+}
+
+// WithGroupMatcher overrides the matcher used to form the group rooms.
+func WithGroupMatcher(matcher *GroupMatcher) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.groups = matcher
+	}
+}
+
+// WithClock overrides the function used to get the current time.
+func WithClock(now func() time.Time) UserMatchMakerOption {
+	return func(s *UserMatchProcessor) {
+		s.now = now
+	}
+}
+
 // NewUserMatchProcessor initializes a new UserMatchProcessor.
 func NewUserMatchProcessor(
 	matchRepo MatchRepository,
@@ -45,8 +164,11 @@ func NewUserMatchProcessor(
 	svc := &UserMatchProcessor{
 		matchRepository: matchRepo,
 		matcher:         matcher,
+		groups:          NewGroupMatcher(nil),
 		logger:          &zerolog.Logger{},
 		userStore:       userStore,
+		mode:            ImmediateMatching,
+		now:             time.Now,
 	}
 
 	for _, opt := range opts {
@@ -67,6 +189,16 @@ func (svc *UserMatchProcessor) ProcessMatchRequest(ctx context.Context, user Use
 		Str("user_id", user.ID()).
 		Msg("processing match user request")
 
+	user.startWaiting(svc.now())
+
+	if svc.mode == BatchMatching {
+		return svc.enqueueForBatch(ctx, user)
+	}
+
+	if user.Preferences().IsGroup() {
+		return svc.enqueueForGroup(ctx, user)
+	}
+
 	// try to attempt a match immediately
 	err := svc.attemptMatch(ctx, &user)
 	if err != nil {
@@ -87,18 +219,462 @@ func (svc *UserMatchProcessor) ProcessMatchRequest(ctx context.Context, user Use
 	return nil
 }
 
+// enqueueForBatch adds the user to the waiting pool, and runs a round when the pool is full.
+func (svc *UserMatchProcessor) enqueueForBatch(ctx context.Context, user User) error {
+	if err := svc.userStore.AddUser(ctx, user); err != nil {
+		return fmt.Errorf("ading user to user store: %w", err)
+	}
+
+	if svc.batchPoolSize <= 0 {
+		return nil
+	}
+
+	users, err := svc.userStore.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get all active users: %w", err)
+	}
+
+	if len(users) < svc.batchPoolSize {
+		return nil
+	}
+	return svc.ProcessWaitingUsers(ctx)
+}
+
+// enqueueForGroup adds the user to the waiting pool, and forms the group rooms
+// that can be completed with the users waiting for one.
+func (svc *UserMatchProcessor) enqueueForGroup(ctx context.Context, user User) error {
+	if err := svc.userStore.AddUser(ctx, user); err != nil {
+		return fmt.Errorf("ading user to user store: %w", err)
+	}
+
+	users, err := svc.userStore.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get all active users: %w", err)
+	}
+
+	now := svc.now()
+	waiting := make([]*User, 0, len(users))
+	for _, waitingUser := range users {
+		waiting = append(waiting, waitingUser.relaxed(svc.relaxation, now))
+	}
+
+	_, groupUsers := splitGroupUsers(waiting)
+	return svc.matchGroups(ctx, groupUsers)
+}
+
+// SkipPair prevents the users from being matched again during the skip cooldown.
+// It does nothing when the processor has no skip cooldown.
+func (svc *UserMatchProcessor) SkipPair(ctx context.Context, userID, skippedUserID string) error {
+	if svc.skippedPairs == nil || svc.skipCooldown <= 0 {
+		return nil
+	}
+
+	expiresAt := svc.now().Add(svc.skipCooldown)
+	if err := svc.skippedPairs.AddPair(ctx, userID, skippedUserID, expiresAt); err != nil {
+		return fmt.Errorf("failed to add skipped pair: %w", err)
+	}
+
+	svc.logger.Debug().
+		Str("user_id", userID).
+		Str("skipped_user_id", skippedUserID).
+		Time("expires_at", expiresAt).
+		Msg("skipped pair added")
+	return nil
+}
+
+// CancelMatchRequest removes the user from the waiting users.
+// It does nothing when the user is no longer waiting, because it was matched or released.
+func (svc *UserMatchProcessor) CancelMatchRequest(ctx context.Context, userID string) error {
+	err := svc.userStore.RemoveUsers(ctx, userID)
+	if isClaimLost(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove waiting user: %w", err)
+	}
+
+	svc.logger.Debug().
+		Str("user_id", userID).
+		Msg("match request cancelled")
+	return nil
+}
+
+// ProcessWaitingUsers cancels the expired match proposals and releases the users whose wait
+// deadline passed, notifying them that no match was found, and retries matching the remaining
+// users with their relaxed preferences. The users waiting for a group room are grouped first.
+// In batch mode, the remaining users are matched in a single round over the whole pool.
+func (svc *UserMatchProcessor) ProcessWaitingUsers(ctx context.Context) error {
+	if err := svc.expireProposals(ctx); err != nil {
+		return err
+	}
+
+	svc.roundMu.Lock()
+	defer svc.roundMu.Unlock()
+
+	users, err := svc.userStore.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get all active users: %w", err)
+	}
+
+	now := svc.now()
+	waiting := make([]*User, 0, len(users))
+	for _, user := range users {
+		deadline := user.WaitDeadline(svc.maxWaitTime)
+		if deadline.IsZero() || now.Before(deadline) {
+			waiting = append(waiting, user.relaxed(svc.relaxation, now))
+			continue
+		}
+
+		if err = svc.releaseUnmatchedUser(ctx, user, now); err != nil {
+			return err
+		}
+	}
+
+	waiting, groupUsers := splitGroupUsers(waiting)
+	if err = svc.matchGroups(ctx, groupUsers); err != nil {
+		return err
+	}
+
+	if svc.mode == BatchMatching {
+		return svc.runBatchRound(ctx, waiting)
+	}
+	return svc.matchWaitingUsers(ctx, waiting)
+}
+
+// matchGroups forms the group rooms of the waiting users, the prioritized and the longest waiting first.
+func (svc *UserMatchProcessor) matchGroups(ctx context.Context, waiting []*User) error {
+	sortByPriority(waiting)
+
+	for _, group := range svc.groups.FindGroups(waiting) {
+		members := make([]*User, 0, len(group))
+		for _, idx := range group {
+			members = append(members, waiting[idx])
+		}
+
+		if err := svc.groupUsers(ctx, members); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupUsers claims the waiting users from the pool and opens their group room.
+// It does nothing when any of them was claimed by another match in the meantime.
+func (svc *UserMatchProcessor) groupUsers(ctx context.Context, members []*User) error {
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.ID())
+	}
+
+	claimed, err := svc.userStore.ClaimUsers(ctx, userIDs...)
+	if isClaimLost(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim grouped users: %w", err)
+	}
+
+	users := make([]User, 0, len(members))
+	for _, member := range members {
+		users = append(users, *member)
+	}
+
+	match, err := NewGroupMatch(MatchID(uuid.New().String()), users...)
+	if err == nil {
+		err = svc.matchRepository.Save(ctx, match)
+	}
+	if err != nil {
+		return svc.releaseClaimedUsers(ctx, claimed, fmt.Errorf("failed to create group match: %w", err))
+	}
+
+	svc.logger.Debug().
+		Str("match_id", match.ID()).
+		Strs("user_ids", userIDs).
+		Msg("new group match created")
+
+	if svc.matchRates != nil {
+		now := svc.now()
+		for _, member := range members {
+			svc.matchRates.Record(member, now)
+		}
+	}
+
+	svc.notifyMatch(ctx, match, userIDs...)
+	return nil
+}
+
+// NotifyQueueStatus tells every waiting user how many compatible users are waiting, its position
+// in the queue and its estimated wait. It does nothing when the processor has no queue status notifier.
+func (svc *UserMatchProcessor) NotifyQueueStatus(ctx context.Context) error {
+	if svc.queueStatus == nil {
+		return nil
+	}
+
+	users, err := svc.userStore.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get all active users: %w", err)
+	}
+
+	now := svc.now()
+	waiting := make([]*User, 0, len(users))
+	for _, user := range users {
+		waiting = append(waiting, user.relaxed(svc.relaxation, now))
+	}
+	sortByPriority(waiting)
+
+	for _, status := range QueueStatuses(waiting, svc.matchRates, now) {
+		if err = svc.queueStatus.NotifyQueueStatus(ctx, status); err != nil {
+			svc.logger.Warn().
+				Err(err).
+				Str("user_id", status.UserID).
+				Msg("failed to notify queue status")
+		}
+	}
+	return nil
+}
+
+// runBatchRound runs the stable matcher over the whole waiting pool, then matches the users
+// left over with each other.
+func (svc *UserMatchProcessor) runBatchRound(ctx context.Context, waiting []*User) error {
+	startedAt := svc.now()
+	sortByPriority(waiting)
+
+	matched := make(map[string]bool, len(waiting))
+	matches := 0
+	for _, pair := range svc.findRoundPairs(waiting) {
+		proposer, receiver := pair[0], pair[1]
+		skipped, err := svc.isSkippedPair(ctx, proposer, receiver)
+		if err != nil {
+			return fmt.Errorf("failed to check skipped pair: %w", err)
+		}
+		if skipped {
+			continue
+		}
+
+		ok, err := svc.pairUsers(ctx, proposer, receiver)
+		if err != nil {
+			return err
+		}
+		if ok {
+			matched[proposer.ID()], matched[receiver.ID()] = true, true
+			matches++
+		}
+	}
+
+	leftovers := make([]*User, 0, len(waiting)-2*matches)
+	for _, user := range waiting {
+		if !matched[user.ID()] {
+			leftovers = append(leftovers, user)
+		}
+	}
+
+	svc.logger.Debug().
+		Int("pool_size", len(waiting)).
+		Int("matches", matches).
+		Msg("batch matching round completed")
+
+	if svc.metrics != nil {
+		svc.metrics.RecordRound(ctx, svc.mode, len(waiting), matches, svc.now().Sub(startedAt))
+	}
+
+	return svc.matchWaitingUsers(ctx, leftovers)
+}
+
+// findRoundPairs matches the waiting pool at once when the matcher supports single pools.
+// Otherwise, see splitRoundPairs.
+func (svc *UserMatchProcessor) findRoundPairs(waiting []*User) [][2]*User {
+	poolMatcher, ok := svc.matcher.(PoolMatchFinder)
+	if !ok {
+		return svc.splitRoundPairs(waiting)
+	}
+
+	var pairs [][2]*User
+	for i, j := range poolMatcher.FindPoolMatches(waiting) {
+		if j > i {
+			pairs = append(pairs, [2]*User{waiting[i], waiting[j]})
+		}
+	}
+	return pairs
+}
+
+// splitRoundPairs matches the pool with a matcher of two sets: the longest waiting half
+// proposes to the other half. The users of the same half cannot be matched with each other,
+// so the users left unmatched in each half are split and matched again, until the halves
+// are single users. Every two users of the pool end up in opposite halves once.
+func (svc *UserMatchProcessor) splitRoundPairs(pool []*User) [][2]*User {
+	if len(pool) < 2 {
+		return nil
+	}
+
+	half := (len(pool) + 1) / 2
+	proposers, receivers := pool[:half], pool[half:]
+
+	var pairs [][2]*User
+	matched := make([]bool, len(pool))
+	for proposerIdx, receiverIdx := range svc.matcher.FindStableMatches(proposers, receivers) {
+		if receiverIdx != -1 {
+			pairs = append(pairs, [2]*User{proposers[proposerIdx], receivers[receiverIdx]})
+			matched[proposerIdx], matched[half+receiverIdx] = true, true
+		}
+	}
+
+	leftovers := make([]*User, 0, len(pool)-2*len(pairs))
+	for i, user := range pool {
+		if !matched[i] {
+			leftovers = append(leftovers, user)
+		}
+	}
+	// the leftovers keep the order of the pool, so the unmatched proposers come first
+	unmatchedProposers := half - len(pairs)
+	pairs = append(pairs, svc.splitRoundPairs(leftovers[:unmatchedProposers])...)
+	return append(pairs, svc.splitRoundPairs(leftovers[unmatchedProposers:])...)
+}
+
+// isSkippedPair reports whether the users are still cooling down after one skipped the other.
+func (svc *UserMatchProcessor) isSkippedPair(ctx context.Context, u1, u2 *User) (bool, error) {
+	if svc.skippedPairs == nil {
+		return false, nil
+	}
+	return svc.skippedPairs.HasPair(ctx, u1.ID(), u2.ID(), svc.now())
+}
+
+// pairUsers claims both waiting users from the pool and matches them.
+// It returns false when one of them was claimed by another match in the meantime.
+func (svc *UserMatchProcessor) pairUsers(ctx context.Context, u1, u2 *User) (bool, error) {
+	claimed, err := svc.userStore.ClaimUsers(ctx, u1.ID(), u2.ID())
+	if isClaimLost(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim matched users: %w", err)
+	}
+
+	if err = svc.processMatch(ctx, u1, u2); err != nil {
+		return false, svc.releaseClaimedUsers(ctx, claimed, fmt.Errorf("failed to process match: %w", err))
+	}
+	return true, nil
+}
+
+// releaseClaimedUsers puts the claimed users back in the pool after the match failed.
+func (svc *UserMatchProcessor) releaseClaimedUsers(ctx context.Context, claimed []*User, cause error) error {
+	errs := []error{cause}
+	for _, user := range claimed {
+		if err := svc.userStore.AddUser(ctx, *user); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release claimed user %s: %w", user.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isClaimLost reports whether the users could not be claimed because
+// they are no longer waiting.
+func isClaimLost(err error) bool {
+	return errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrUserAlreadyClaimed)
+}
+
+// releaseUnmatchedUser removes a user whose wait deadline passed and notifies it.
+func (svc *UserMatchProcessor) releaseUnmatchedUser(ctx context.Context, user *User, now time.Time) error {
+	err := svc.userStore.RemoveUsers(ctx, user.ID())
+	if isClaimLost(err) {
+		// matched in the meantime
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove unmatched user: %w", err)
+	}
+
+	waited := now.Sub(user.RequestedAt())
+	svc.logger.Debug().
+		Str("user_id", user.ID()).
+		Dur("waited", waited).
+		Msg("no match found before the wait deadline")
+
+	if svc.noMatchNotifier == nil {
+		return nil
+	}
+
+	if err = svc.noMatchNotifier.NotifyNoMatchFound(ctx, *user, waited); err != nil {
+		svc.logger.Warn().
+			Err(err).
+			Str("user_id", user.ID()).
+			Msg("failed to notify no match found")
+	}
+	return nil
+}
+
+// matchWaitingUsers matches the waiting users with each other, the prioritized and
+// the longest waiting first.
+func (svc *UserMatchProcessor) matchWaitingUsers(ctx context.Context, waiting []*User) error {
+	sortByPriority(waiting)
+
+	matched := make(map[string]bool, len(waiting))
+	for i, user := range waiting {
+		if matched[user.ID()] {
+			continue
+		}
+
+		others := make([]*User, 0, len(waiting)-i-1)
+		for _, other := range waiting[i+1:] {
+			if !matched[other.ID()] {
+				others = append(others, other)
+			}
+		}
+
+		others, err := svc.excludeSkippedUsers(ctx, []*User{user}, others)
+		if err != nil {
+			return fmt.Errorf("failed to exclude skipped users: %w", err)
+		}
+
+		idxCol := svc.matcher.FindStableMatches([]*User{user}, others)
+		if len(idxCol) == 0 || idxCol[0] == -1 {
+			continue
+		}
+
+		partner := others[idxCol[0]]
+		ok, err := svc.pairUsers(ctx, user, partner)
+		if err != nil {
+			return err
+		}
+		if ok {
+			matched[user.ID()], matched[partner.ID()] = true, true
+		}
+	}
+	return nil
+}
+
+// sortByPriority sorts the users requeued with priority first, then the longest waiting.
+func sortByPriority(users []*User) {
+	sort.SliceStable(users, func(i, j int) bool {
+		if users[i].Priority() != users[j].Priority() {
+			return users[i].Priority()
+		}
+		return users[i].RequestedAt().Before(users[j].RequestedAt())
+	})
+}
+
 func (svc *UserMatchProcessor) attemptMatch(ctx context.Context, candidates ...*User) error {
-	activeUsers, err := svc.userStore.GetAll(ctx)
+	activeUsers, err := svc.findActiveUsers(ctx, candidates)
 	if err != nil {
 		return fmt.Errorf("failed to get all active users: %w", err)
 	}
 
+	now := svc.now()
+	for i, activeUser := range activeUsers {
+		activeUsers[i] = activeUser.relaxed(svc.relaxation, now)
+	}
+
+	activeUsers, err = svc.excludeSkippedUsers(ctx, candidates, activeUsers)
+	if err != nil {
+		return fmt.Errorf("failed to exclude skipped users: %w", err)
+	}
+
 	idxCol := svc.matcher.FindStableMatches(candidates, activeUsers)
 	if len(idxCol) == 0 {
 		return ErrNoActiveUsers
 	}
 
 	// process the match
+	matched := 0
 	for idxsa, idxsb := range idxCol {
 		if idxsb == -1 {
 			// no match found for this user
@@ -108,18 +684,65 @@ func (svc *UserMatchProcessor) attemptMatch(ctx context.Context, candidates ...*
 		candidate := candidates[idxsa]
 		matchedUser := activeUsers[idxsb]
 
-		// remove the matched user from the store
-		if err = svc.userStore.RemoveUsers(ctx, matchedUser.ID()); err != nil {
-			return fmt.Errorf("failed to remove matched user: %w", err)
+		// claim the matched user, unless another matchmaker claimed it first
+		claimed, claimErr := svc.userStore.ClaimUsers(ctx, matchedUser.ID())
+		if isClaimLost(claimErr) {
+			continue
+		}
+		if claimErr != nil {
+			return fmt.Errorf("failed to claim matched user: %w", claimErr)
 		}
 
 		if err = svc.processMatch(ctx, candidate, matchedUser); err != nil {
-			return fmt.Errorf("failed to process match: %w", err)
+			return svc.releaseClaimedUsers(ctx, claimed, fmt.Errorf("failed to process match: %w", err))
 		}
+		matched++
+	}
+
+	if matched == 0 {
+		// none of the active users is compatible
+		return ErrNoActiveUsers
 	}
 	return nil
 }
 
+// findActiveUsers loads the waiting users that may match a single candidate from the store indexes,
+// or the whole pool for several candidates.
+func (svc *UserMatchProcessor) findActiveUsers(ctx context.Context, candidates []*User) ([]*User, error) {
+	if len(candidates) != 1 {
+		return svc.userStore.GetAll(ctx)
+	}
+	return svc.userStore.FindCandidates(ctx, candidates[0].EffectivePreferences(), candidates[0].Location())
+}
+
+// excludeSkippedUsers filters out the active users that are still cooling down with any of the candidates.
+func (svc *UserMatchProcessor) excludeSkippedUsers(ctx context.Context, candidates, activeUsers []*User) ([]*User, error) {
+	if svc.skippedPairs == nil {
+		return activeUsers, nil
+	}
+
+	now := svc.now()
+	available := make([]*User, 0, len(activeUsers))
+	for _, activeUser := range activeUsers {
+		skipped := false
+		for _, candidate := range candidates {
+			inCooldown, err := svc.skippedPairs.HasPair(ctx, candidate.ID(), activeUser.ID(), now)
+			if err != nil {
+				return nil, err
+			}
+			if inCooldown {
+				skipped = true
+				break
+			}
+		}
+
+		if !skipped {
+			available = append(available, activeUser)
+		}
+	}
+	return available, nil
+}
+
 func (svc *UserMatchProcessor) processMatch(ctx context.Context, candidate, matchedUser *User) error {
 	match, createErr := svc.createAndPersistMatch(ctx, *candidate, *matchedUser)
 	if createErr != nil {
@@ -129,17 +752,74 @@ func (svc *UserMatchProcessor) processMatch(ctx context.Context, candidate, matc
 	svc.logger.Debug().
 		Str("match_id", match.ID()).
 		Strs("user_ids", []string{candidate.ID(), matchedUser.ID()}).
+		Stringer("status", match.Status()).
 		Msg("new match created")
+
+	svc.recordMatch(ctx, candidate, matchedUser)
+	svc.notifyMatch(ctx, match, candidate.ID(), matchedUser.ID())
 	return nil
 }
 
+// recordMatch records the matched users in the match rates, and their mutual score and
+// how long they waited if metrics are enabled.
+func (svc *UserMatchProcessor) recordMatch(ctx context.Context, u1, u2 *User) {
+	now := svc.now()
+	if svc.matchRates != nil {
+		svc.matchRates.Record(u1, now)
+		svc.matchRates.Record(u2, now)
+	}
+
+	if svc.metrics == nil {
+		return
+	}
+
+	score := 0.0
+	if svc.metricsScorer != nil {
+		score = (svc.metricsScorer.Score(u1, u2) + svc.metricsScorer.Score(u2, u1)) / 2
+	}
+
+	svc.metrics.RecordMatch(ctx, svc.mode, score, now.Sub(u1.RequestedAt()), now.Sub(u2.RequestedAt()))
+}
+
+// notifyMatch pushes the match to the notifications channel, if any, once it is confirmed.
+// Proposed matches are pushed when both users accepted them, see RespondToMatch.
+// Notification failures are logged but never fail the match, which is already persisted.
+func (svc *UserMatchProcessor) notifyMatch(ctx context.Context, match *Match, userIDs ...string) {
+	if svc.notifications == nil || match.Status() != MatchConfirmed {
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := svc.notifications.Notify(ctx, userID, match); err != nil {
+			svc.logger.Warn().
+				Err(err).
+				Str("match_id", match.ID()).
+				Str("user_id", userID).
+				Msg("failed to notify match")
+		}
+	}
+}
+
 func (svc *UserMatchProcessor) createAndPersistMatch(ctx context.Context, user1, user2 User) (*Match, error) {
 	matchID := uuid.New().String()
-	match, err := NewMatch(MatchID(matchID), user1, user2)
+
+	var expiresAt time.Time
+	if svc.proposals != nil && svc.proposalTimeout > 0 {
+		expiresAt = svc.now().Add(svc.proposalTimeout)
+	}
+
+	match, err := NewProposedMatch(MatchID(matchID), user1, user2, expiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to instantiate match: %w", err)
 	}
 
+	// track the proposal first, so it expires even if saving the match partially fails
+	if match.Status() == MatchProposed {
+		if err = svc.proposals.AddProposal(ctx, match.ID(), expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to add match proposal: %w", err)
+		}
+	}
+
 	if saveErr := svc.matchRepository.Save(ctx, match); saveErr != nil {
 		return nil, fmt.Errorf("failed to save match: %w", saveErr)
 	}
@@ -147,6 +827,158 @@ func (svc *UserMatchProcessor) createAndPersistMatch(ctx context.Context, user1,
 	return match, nil
 }
 
+// RespondToMatch records whether the user accepts the proposed match. The match is confirmed
+// once both users accepted it, and cancelled as soon as one declines it.
+//
+// When the match is cancelled, the partners of the user that declined it go back to the
+// waiting pool with priority, while the user that declined it stops waiting.
+func (svc *UserMatchProcessor) RespondToMatch(ctx context.Context, matchID, userID string, accepted bool) error {
+	now := svc.now()
+	match, err := svc.resolveProposal(ctx, matchID, func(match *Match) error {
+		if accepted {
+			return match.Accept(userID, now)
+		}
+		return match.Decline(userID, now)
+	})
+	if errors.Is(err, ErrMatchProposalExpired) {
+		// the response arrived before the proposal was expired by the waiting users processor
+		if expireErr := svc.expireProposal(ctx, matchID, now); expireErr != nil {
+			return errors.Join(err, expireErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	svc.logger.Debug().
+		Str("match_id", matchID).
+		Str("user_id", userID).
+		Bool("accepted", accepted).
+		Stringer("status", match.Status()).
+		Msg("match proposal response recorded")
+
+	if match.Status() == MatchConfirmed {
+		userIDs := make([]string, 0, len(match.Participants()))
+		for _, user := range match.Participants() {
+			userIDs = append(userIDs, user.ID())
+		}
+		svc.notifyMatch(ctx, match, userIDs...)
+	}
+	return svc.requeueCancelledMatch(ctx, match)
+}
+
+// expireProposals cancels the proposed matches that were not accepted in time.
+func (svc *UserMatchProcessor) expireProposals(ctx context.Context) error {
+	if svc.proposals == nil {
+		return nil
+	}
+
+	now := svc.now()
+	matchIDs, err := svc.proposals.ExpiredProposals(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get expired match proposals: %w", err)
+	}
+
+	for _, matchID := range matchIDs {
+		if err = svc.expireProposal(ctx, matchID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireProposal cancels the proposed match, requeueing the users that accepted it.
+func (svc *UserMatchProcessor) expireProposal(ctx context.Context, matchID string, now time.Time) error {
+	match, err := svc.resolveProposal(ctx, matchID, func(match *Match) error {
+		return match.Expire(now)
+	})
+	if errors.Is(err, ErrMatchNotFound) || errors.Is(err, ErrMatchNotProposed) {
+		// never saved or already resolved
+		return svc.removeProposal(ctx, matchID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to expire match proposal: %w", err)
+	}
+
+	svc.logger.Debug().
+		Str("match_id", matchID).
+		Stringer("status", match.Status()).
+		Msg("match proposal expired")
+
+	return svc.requeueCancelledMatch(ctx, match)
+}
+
+// resolveProposal applies the change to the proposed match and saves it. The change is
+// retried on the latest match when another response or instance saved it first, so both
+// users accepting at once confirm the match.
+// The proposal is no longer tracked once the match is confirmed or cancelled.
+func (svc *UserMatchProcessor) resolveProposal(ctx context.Context, matchID string, change func(*Match) error) (*Match, error) {
+	for range maxProposalResolveAttempts {
+		match, err := svc.matchRepository.FindByID(ctx, matchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find match: %w", err)
+		}
+
+		if err = change(match); err != nil {
+			return nil, err
+		}
+
+		err = svc.matchRepository.Save(ctx, match)
+		if errors.Is(err, ErrMatchVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save match: %w", err)
+		}
+
+		if match.Status() == MatchProposed {
+			return match, nil
+		}
+		return match, svc.removeProposal(ctx, matchID)
+	}
+	return nil, fmt.Errorf("failed to save match %s: %w", matchID, ErrMatchVersionConflict)
+}
+
+func (svc *UserMatchProcessor) removeProposal(ctx context.Context, matchID string) error {
+	if svc.proposals == nil {
+		return nil
+	}
+
+	if err := svc.proposals.RemoveProposal(ctx, matchID); err != nil {
+		return fmt.Errorf("failed to remove match proposal: %w", err)
+	}
+	return nil
+}
+
+// requeueCancelledMatch puts the users of a cancelled match back in the waiting pool
+// with priority. The user that declined the match is kept apart from its partners
+// during the skip cooldown, in case it requests a match again.
+func (svc *UserMatchProcessor) requeueCancelledMatch(ctx context.Context, match *Match) error {
+	if match.Status() != MatchCancelled {
+		return nil
+	}
+
+	if declinedBy := match.DeclinedBy(); declinedBy != "" {
+		partners, err := match.Partners(declinedBy)
+		if err != nil {
+			return err
+		}
+		for _, partner := range partners {
+			if err = svc.SkipPair(ctx, declinedBy, partner.ID()); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, user := range match.RequeuedUsers() {
+		requeued := user.requeued(true)
+		if err := svc.ProcessMatchRequest(ctx, requeued); err != nil {
+			return fmt.Errorf("failed to requeue user %s: %w", user.ID(), err)
+		}
+	}
+	return nil
+}
+
 func (svc *UserMatchProcessor) ensureDependencies() error {
 	if svc.matchRepository == nil {
 		return domain_error.New("missing match repository")
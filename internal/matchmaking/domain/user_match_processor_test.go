@@ -0,0 +1,631 @@
+package matchdomain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchmakinginmemory "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+func TestUserMatchProcessorSkipCooldown(t *testing.T) {
+	ctx := context.Background()
+
+	// alice only talks with men and the men only talk with women
+	newUser := func(id string) matchdomain.User {
+		if id == "alice" {
+			return *matchdomain.NewUser(id, 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male))
+		}
+		return *matchdomain.NewUser(id, 25, gender.Male, matchmaking.DefaultPreferences().WithGender(gender.Female))
+	}
+
+	newProcessor := func(t *testing.T, cooldown time.Duration) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchmakinginmemory.NewMatchRepository(),
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+			matchdomain.WithSkipCooldown(matchmakinginmemory.NewSkippedPairStore(), cooldown),
+		)
+		require.NoError(t, err)
+		return processor, userStore
+	}
+
+	t.Run("should not match a skipped pair during the cooldown", func(t *testing.T) {
+		processor, userStore := newProcessor(t, time.Minute)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("bob")))
+		require.NoError(t, processor.SkipPair(ctx, "alice", "bob"))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("alice")))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 2)
+	})
+
+	t.Run("should match the skipped user with someone else", func(t *testing.T) {
+		processor, userStore := newProcessor(t, time.Minute)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("bob")))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("carol")))
+		require.NoError(t, processor.SkipPair(ctx, "bob", "alice"))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("alice")))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 1)
+		require.Equal(t, "bob", waiting[0].ID())
+	})
+
+	t.Run("should match the pair again once the cooldown expires", func(t *testing.T) {
+		processor, userStore := newProcessor(t, time.Millisecond)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("bob")))
+		require.NoError(t, processor.SkipPair(ctx, "alice", "bob"))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("alice")))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+	})
+}
+
+type recordingNoMatchNotifier struct {
+	notified []string
+}
+
+func (n *recordingNoMatchNotifier) NotifyNoMatchFound(_ context.Context, user matchdomain.User, _ time.Duration) error {
+	n.notified = append(n.notified, user.ID())
+	return nil
+}
+
+func TestUserMatchProcessorWaitingUsers(t *testing.T) {
+	ctx := context.Background()
+
+	newProcessor := func(t *testing.T, now *time.Time) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore, *recordingNoMatchNotifier) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		notifier := &recordingNoMatchNotifier{}
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchmakinginmemory.NewMatchRepository(),
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+			matchdomain.WithClock(func() time.Time { return *now }),
+			matchdomain.WithMaxWaitTime(2*time.Minute),
+			matchdomain.WithRelaxationPolicy(matchdomain.RelaxationPolicy{
+				Interval:     30 * time.Second,
+				AgeRangeStep: 2,
+			}),
+			matchdomain.WithNoMatchNotifier(notifier),
+		)
+		require.NoError(t, err)
+		return processor, userStore, notifier
+	}
+
+	// alice only talks with men aged 30 and bob is 33
+	alice := *matchdomain.NewUser("alice", 25, gender.Female,
+		matchmaking.DefaultPreferences().WithGender(gender.Male).WithMinAge(30).WithMaxAge(30))
+	bob := *matchdomain.NewUser("bob", 33, gender.Male,
+		matchmaking.DefaultPreferences().WithGender(gender.Female))
+
+	t.Run("should match the users once their preferences are relaxed", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, _ := newProcessor(t, &now)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, alice))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, bob))
+
+		now = now.Add(30 * time.Second)
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 2, "one step only widens the age range to [28, 32]")
+
+		now = now.Add(30 * time.Second)
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		waiting, err = userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+	})
+
+	t.Run("should release the users after their wait deadline", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, notifier := newProcessor(t, &now)
+
+		impatient := *matchdomain.NewUser("carol", 25, gender.Female,
+			matchmaking.DefaultPreferences().WithGender(gender.Male).WithMaxWaitTimeSeconds(10))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, impatient))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, alice))
+
+		now = now.Add(15 * time.Second)
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		require.Equal(t, []string{"carol"}, notifier.notified)
+
+		now = now.Add(2 * time.Minute)
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		require.Equal(t, []string{"carol", "alice"}, notifier.notified)
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+	})
+}
+
+func TestUserMatchProcessorBatchMatching(t *testing.T) {
+	ctx := context.Background()
+
+	newProcessor := func(t *testing.T, poolSize int) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore, *matchmakinginmemory.MatchMetrics) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		metrics := matchmakinginmemory.NewMatchMetrics()
+		scorer := matchdomain.NewWeightedScorer(matchdomain.DefaultScoreWeights())
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchmakinginmemory.NewMatchRepository(),
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(matchdomain.WithScorer(scorer)),
+			matchdomain.WithBatchMatching(poolSize),
+			matchdomain.WithMatchMetrics(metrics, scorer),
+		)
+		require.NoError(t, err)
+		return processor, userStore, metrics
+	}
+
+	newUser := func(id string, g gender.Gender, other gender.Gender) matchdomain.User {
+		return *matchdomain.NewUser(id, 25, g, matchmaking.DefaultPreferences().WithGender(other))
+	}
+
+	t.Run("should wait for the round to match the users", func(t *testing.T) {
+		processor, userStore, metrics := newProcessor(t, 0)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("alice", gender.Female, gender.Male)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("bob", gender.Male, gender.Female)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("carol", gender.Female, gender.Male)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("dave", gender.Male, gender.Female)))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 4)
+
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		waiting, err = userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+
+		snapshot := metrics.Snapshot(matchdomain.BatchMatching)
+		require.Equal(t, 2, snapshot.Matches)
+		require.Equal(t, 1, snapshot.Rounds)
+		require.Positive(t, snapshot.AverageScore)
+	})
+
+	t.Run("should match the users of the same half in the round", func(t *testing.T) {
+		metrics := &roundMetrics{}
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchmakinginmemory.NewMatchRepository(),
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+			matchdomain.WithBatchMatching(0),
+			matchdomain.WithMatchMetrics(metrics, nil),
+		)
+		require.NoError(t, err)
+
+		// alice and bob wait the longest, so the first half has no partner in the second one
+		requestedAt := time.Now().Add(-time.Minute)
+		for i, user := range []matchdomain.User{
+			newUser("alice", gender.Female, gender.Male),
+			newUser("bob", gender.Male, gender.Female),
+			newUser("carol", gender.Female, gender.Female),
+			newUser("dave", gender.Female, gender.Female),
+		} {
+			user.StartWaiting(requestedAt.Add(time.Duration(i) * time.Second))
+			require.NoError(t, userStore.AddUser(ctx, user))
+		}
+
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+		require.Equal(t, []int{2}, metrics.rounds, "both pairs are matched by the round")
+	})
+
+	t.Run("should run a round when the pool is full", func(t *testing.T) {
+		processor, userStore, metrics := newProcessor(t, 2)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("alice", gender.Female, gender.Male)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newUser("bob", gender.Male, gender.Female)))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+		require.Equal(t, 1, metrics.Snapshot(matchdomain.BatchMatching).Matches)
+		require.Zero(t, metrics.Snapshot(matchdomain.ImmediateMatching).Matches)
+	})
+}
+
+func TestUserMatchProcessorConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should never match a user twice across processors", func(t *testing.T) {
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		repository := &recordingMatchRepository{MatchRepository: matchmakinginmemory.NewMatchRepository()}
+
+		const replicas, usersPerReplica = 4, 50
+		var wg sync.WaitGroup
+		for replica := range replicas {
+			processor, err := matchdomain.NewUserMatchProcessor(repository, userStore, matchdomain.NewGaleShapleyStableMatcher())
+			require.NoError(t, err)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range usersPerReplica {
+					user := matchdomain.NewUser(fmt.Sprintf("user-%d-%d", replica, i), 25, gender.Unspecified,
+						matchmaking.DefaultPreferences())
+					assert.NoError(t, processor.ProcessMatchRequest(ctx, *user))
+					assert.NoError(t, processor.ProcessWaitingUsers(ctx))
+				}
+			}()
+		}
+		wg.Wait()
+
+		matchedUsers := make(map[string]int)
+		for _, match := range repository.saved() {
+			matchedUsers[match.Requester().ID()]++
+			matchedUsers[match.Candidate().ID()]++
+		}
+		for userID, count := range matchedUsers {
+			require.Equal(t, 1, count, "user %s matched %d times", userID, count)
+		}
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, replicas*usersPerReplica, len(matchedUsers)+len(waiting))
+		for _, user := range waiting {
+			require.NotContains(t, matchedUsers, user.ID())
+		}
+	})
+
+	t.Run("should release the claimed users when the match cannot be saved", func(t *testing.T) {
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		repository := &recordingMatchRepository{
+			MatchRepository: matchmakinginmemory.NewMatchRepository(),
+			err:             errors.New("unavailable"),
+		}
+		processor, err := matchdomain.NewUserMatchProcessor(repository, userStore, matchdomain.NewGaleShapleyStableMatcher())
+		require.NoError(t, err)
+
+		require.NoError(t, userStore.AddUser(ctx, *matchdomain.NewUser("alice", 25, gender.Unspecified, matchmaking.DefaultPreferences())))
+		require.NoError(t, userStore.AddUser(ctx, *matchdomain.NewUser("bob", 25, gender.Unspecified, matchmaking.DefaultPreferences())))
+
+		require.Error(t, processor.ProcessWaitingUsers(ctx))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 2)
+	})
+}
+
+func TestUserMatchProcessorMatchProposals(t *testing.T) {
+	ctx := context.Background()
+
+	newProcessor := func(t *testing.T, now *time.Time, opts ...matchdomain.UserMatchMakerOption) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore, *recordingMatchRepository) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		matchRepo := &recordingMatchRepository{MatchRepository: matchmakinginmemory.NewMatchRepository()}
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchRepo,
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+			append([]matchdomain.UserMatchMakerOption{
+				matchdomain.WithClock(func() time.Time { return *now }),
+				matchdomain.WithSkipCooldown(matchmakinginmemory.NewSkippedPairStore(), time.Minute),
+				matchdomain.WithMatchProposals(matchmakinginmemory.NewProposalStore(), 30*time.Second),
+			}, opts...)...,
+		)
+		require.NoError(t, err)
+		return processor, userStore, matchRepo
+	}
+
+	alice := *matchdomain.NewUser("alice", 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male))
+	bob := *matchdomain.NewUser("bob", 25, gender.Male, matchmaking.DefaultPreferences().WithGender(gender.Female))
+
+	propose := func(t *testing.T, processor *matchdomain.UserMatchProcessor, matchRepo *recordingMatchRepository) string {
+		t.Helper()
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, alice))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, bob))
+
+		saved := matchRepo.saved()
+		require.Len(t, saved, 1)
+		require.Equal(t, matchdomain.MatchProposed, saved[0].Status())
+		return saved[0].ID()
+	}
+
+	matchStatus := func(t *testing.T, matchRepo *recordingMatchRepository, matchID string) matchdomain.MatchStatus {
+		t.Helper()
+
+		match, err := matchRepo.FindByID(ctx, matchID)
+		require.NoError(t, err)
+		return match.Status()
+	}
+
+	waitingUsers := func(t *testing.T, userStore *matchmakinginmemory.UserStore) map[string]bool {
+		t.Helper()
+
+		users, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+
+		priorities := make(map[string]bool, len(users))
+		for _, user := range users {
+			priorities[user.ID()] = user.Priority()
+		}
+		return priorities
+	}
+
+	t.Run("should notify the match only once it is confirmed", func(t *testing.T) {
+		now := time.Now()
+		notifier := matchmakinginmemory.NewMatchNotifier()
+		matches, unsubscribe := notifier.Subscribe("alice")
+		defer unsubscribe()
+
+		processor, _, matchRepo := newProcessor(t, &now, matchdomain.WithNotificationsChannel(notifier))
+		matchID := propose(t, processor, matchRepo)
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "alice", true))
+		require.Empty(t, matches)
+
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "bob", true))
+		require.Len(t, matches, 1)
+		match := <-matches
+		require.Equal(t, matchID, match.ID())
+		require.Equal(t, matchdomain.MatchConfirmed, match.Status())
+	})
+
+	t.Run("should confirm the match once both users accept it", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "alice", true))
+		require.Equal(t, matchdomain.MatchProposed, matchStatus(t, matchRepo, matchID))
+
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "bob", true))
+		require.Equal(t, matchdomain.MatchConfirmed, matchStatus(t, matchRepo, matchID))
+		require.Empty(t, waitingUsers(t, userStore))
+
+		err := processor.RespondToMatch(ctx, matchID, "bob", false)
+		require.ErrorIs(t, err, matchdomain.ErrMatchNotProposed)
+	})
+
+	t.Run("should confirm the match when both users accept it at once", func(t *testing.T) {
+		now := time.Now()
+		processor, _, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for _, userID := range []string{"alice", "bob"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- processor.RespondToMatch(ctx, matchID, userID, true)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		require.Equal(t, matchdomain.MatchConfirmed, matchStatus(t, matchRepo, matchID))
+	})
+
+	t.Run("should only requeue the partner when an user declines", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "bob", false))
+		require.Equal(t, matchdomain.MatchCancelled, matchStatus(t, matchRepo, matchID))
+
+		require.Equal(t, map[string]bool{"alice": true}, waitingUsers(t, userStore))
+	})
+
+	t.Run("should keep the time the requeued user requested the match", func(t *testing.T) {
+		now := time.Now()
+		requestedAt := now
+		processor, userStore, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		now = now.Add(10 * time.Second)
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "bob", false))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 1)
+		require.True(t, waiting[0].Priority())
+		require.True(t, requestedAt.Equal(waiting[0].RequestedAt()), "alice waits since %s, not %s", requestedAt, waiting[0].RequestedAt())
+	})
+
+	t.Run("should only requeue the users that accepted an expired proposal", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		require.NoError(t, processor.RespondToMatch(ctx, matchID, "alice", true))
+
+		now = now.Add(time.Minute)
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+		require.Equal(t, matchdomain.MatchCancelled, matchStatus(t, matchRepo, matchID))
+		require.Equal(t, map[string]bool{"alice": true}, waitingUsers(t, userStore))
+
+		err := processor.RespondToMatch(ctx, matchID, "bob", true)
+		require.ErrorIs(t, err, matchdomain.ErrMatchNotProposed)
+	})
+
+	t.Run("should expire the proposal when the response arrives too late", func(t *testing.T) {
+		now := time.Now()
+		processor, userStore, matchRepo := newProcessor(t, &now)
+		matchID := propose(t, processor, matchRepo)
+
+		now = now.Add(time.Minute)
+		err := processor.RespondToMatch(ctx, matchID, "alice", true)
+		require.ErrorIs(t, err, matchdomain.ErrMatchProposalExpired)
+		require.Equal(t, matchdomain.MatchCancelled, matchStatus(t, matchRepo, matchID))
+		require.Empty(t, waitingUsers(t, userStore))
+	})
+}
+
+// recordingMatchRepository records the saved matches, or fails to save them when err is set.
+type recordingMatchRepository struct {
+	*matchmakinginmemory.MatchRepository
+
+	mu      sync.Mutex
+	matches []*matchdomain.Match
+	err     error
+}
+
+func (r *recordingMatchRepository) Save(ctx context.Context, match *matchdomain.Match) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.mu.Lock()
+	r.matches = append(r.matches, match)
+	r.mu.Unlock()
+	return r.MatchRepository.Save(ctx, match)
+}
+
+func (r *recordingMatchRepository) saved() []*matchdomain.Match {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*matchdomain.Match(nil), r.matches...)
+}
+
+func TestUserMatchProcessorCancelMatchRequest(t *testing.T) {
+	ctx := context.Background()
+
+	newProcessor := func(t *testing.T) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchmakinginmemory.NewMatchRepository(),
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+		)
+		require.NoError(t, err)
+		return processor, userStore
+	}
+
+	newMan := func(id string) matchdomain.User {
+		return *matchdomain.NewUser(id, 25, gender.Male, matchmaking.DefaultPreferences().WithGender(gender.Female))
+	}
+
+	t.Run("should never match a user that cancelled its request", func(t *testing.T) {
+		processor, userStore := newProcessor(t)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newMan("bob")))
+		require.NoError(t, processor.CancelMatchRequest(ctx, "bob"))
+
+		alice := matchdomain.NewUser("alice", 25, gender.Female, matchmaking.DefaultPreferences().WithGender(gender.Male))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, *alice))
+
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 1)
+		require.Equal(t, "alice", waiting[0].ID())
+	})
+
+	t.Run("should ignore users that are no longer waiting", func(t *testing.T) {
+		processor, _ := newProcessor(t)
+
+		require.NoError(t, processor.CancelMatchRequest(ctx, "bob"))
+	})
+}
+
+func TestUserMatchProcessorGroupMatching(t *testing.T) {
+	ctx := context.Background()
+
+	newProcessor := func(t *testing.T) (*matchdomain.UserMatchProcessor, *matchmakinginmemory.UserStore, *recordingMatchRepository) {
+		t.Helper()
+
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		matchRepo := &recordingMatchRepository{MatchRepository: matchmakinginmemory.NewMatchRepository()}
+		processor, err := matchdomain.NewUserMatchProcessor(
+			matchRepo,
+			userStore,
+			matchdomain.NewGaleShapleyStableMatcher(),
+		)
+		require.NoError(t, err)
+		return processor, userStore, matchRepo
+	}
+
+	newGroupUser := func(id string, size int32) matchdomain.User {
+		return *matchdomain.NewUser(id, 25, gender.Unspecified, matchmaking.DefaultPreferences().WithGroupSize(size))
+	}
+
+	t.Run("should open the room once the group is complete", func(t *testing.T) {
+		processor, userStore, matchRepo := newProcessor(t)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("alice", 3)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("bob", 3)))
+		require.Empty(t, matchRepo.saved(), "group users are never paired")
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("carol", 3)))
+
+		matches := matchRepo.saved()
+		require.Len(t, matches, 1)
+		require.True(t, matches[0].IsGroup())
+		require.Equal(t, matchdomain.MatchConfirmed, matches[0].Status())
+
+		var participantIDs []string
+		for _, participant := range matches[0].Participants() {
+			participantIDs = append(participantIDs, participant.ID())
+		}
+		require.ElementsMatch(t, []string{"alice", "bob", "carol"}, participantIDs)
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, waiting)
+	})
+
+	t.Run("should not mix users asking for different sizes", func(t *testing.T) {
+		processor, userStore, matchRepo := newProcessor(t)
+
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("alice", 3)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("bob", 4)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, newGroupUser("carol", 3)))
+		require.NoError(t, processor.ProcessMatchRequest(ctx, *matchdomain.NewUser("dave", 25, gender.Unspecified, matchmaking.DefaultPreferences())))
+		require.NoError(t, processor.ProcessWaitingUsers(ctx))
+
+		require.Empty(t, matchRepo.saved())
+		waiting, err := userStore.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, waiting, 4)
+	})
+}
+
+// roundMetrics records the matches of every batch round.
+type roundMetrics struct {
+	rounds []int
+}
+
+func (m *roundMetrics) RecordMatch(context.Context, matchdomain.MatchingMode, float64, ...time.Duration) {
+}
+
+func (m *roundMetrics) RecordRound(_ context.Context, _ matchdomain.MatchingMode, _, matches int, _ time.Duration) {
+	m.rounds = append(m.rounds, matches)
+}
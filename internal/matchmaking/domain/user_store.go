@@ -1,6 +1,11 @@
 package matchdomain
 
-import "context"
+import (
+	"context"
+
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
 
 // UserStore defines the behavior of a user store.
 type UserStore interface {
@@ -10,6 +15,16 @@ type UserStore interface {
 	// GetAll returns all users in the store.
 	GetAll(ctx context.Context) ([]*User, error)
 
+	// FindCandidates returns the users that may satisfy the preferences of a user located
+	// at origin, looked up by the CandidateQuery in the store indexes. The candidates still
+	// need to be checked against the preferences.
+	FindCandidates(ctx context.Context, prefs matchmaking.Preferences, origin *location.Location) ([]*User, error)
+
 	// RemoveUsers removes a user from the store.
 	RemoveUsers(ctx context.Context, userID ...string) error
+
+	// ClaimUsers atomically removes the users from the store and returns them, so no other
+	// matchmaker can match them. Either all the users are claimed or none of them, failing with
+	// ErrUserNotFound or ErrUserAlreadyClaimed when any of them is no longer waiting.
+	ClaimUsers(ctx context.Context, userIDs ...string) ([]*User, error)
 }
@@ -0,0 +1,23 @@
+package matchsessioncli
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+// dialMatchMakingService connects to the MatchMakingService gRPC API listening on grpcAddr.
+func dialMatchMakingService(grpcAddr *string) (matchpb.MatchMakingServiceClient, func(), error) {
+	conn, err := grpc.NewClient(*grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to matchmaking service at %s: %w", *grpcAddr, err)
+	}
+
+	closer := func() {
+		_ = conn.Close()
+	}
+	return matchpb.NewMatchMakingServiceClient(conn), closer, nil
+}
@@ -1,22 +1,90 @@
 package matchsessioncli
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/spf13/cobra"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
 )
 
-var NewMatchSessionCobraCommand = func() *cobra.Command {
-	return &cobra.Command{
-		Use:       "find-match",
-		Short:     "Try to match another User based on the preferences",
-		ValidArgs: []string{"userID"},
-		Args:      cobra.MinimumNArgs(3),
-		Run:       handleRootCmd(),
+var NewMatchSessionCobraCommand = func(grpcAddr *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find-match <user-id> <age> <gender>",
+		Short: "Try to match another User based on the preferences",
+		Long: "Request a match for the given user and wait until the matchmaker finds a partner.\n" +
+			"Gender must be one of: female, male, unspecified.",
+		ValidArgs: []string{"userID", "age", "gender"},
+		Args:      cobra.ExactArgs(3),
 	}
-}
 
-func handleRootCmd() func(*cobra.Command, []string) {
-	return func(cobraCmd *cobra.Command, _ []string) {
-		// TODO: to be implemented
-		cobraCmd.PrintErrln("not implemented yet")
+	prefGender := cmd.Flags().String("pref-gender", "unspecified", "preferred partner gender (female, male, unspecified)")
+	prefMinAge := cmd.Flags().Int32("pref-min-age", 0, "preferred partner minimum age")
+	prefMaxAge := cmd.Flags().Int32("pref-max-age", 0, "preferred partner maximum age")
+	prefInterests := cmd.Flags().StringSlice("pref-interests", nil, "preferred partner interests, comma separated")
+	timeout := cmd.Flags().Duration("timeout", time.Minute, "maximum time to wait for a match")
+
+	cmd.RunE = func(cobraCmd *cobra.Command, args []string) error {
+		age, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid age %q: %w", args[1], err)
+		}
+
+		userGender, err := parseGender(args[2])
+		if err != nil {
+			return err
+		}
+
+		preferredGender, err := parseGender(*prefGender)
+		if err != nil {
+			return err
+		}
+
+		client, closer, err := dialMatchMakingService(grpcAddr)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		ctx, cancel := contextWithOptionalTimeout(cobraCmd.Context(), *timeout)
+		defer cancel()
+
+		cobraCmd.Printf("waiting for a match for user %s...\n", args[0])
+		res, err := client.FindMatch(ctx, &matchpb.FindMatchRequest{
+			UserId:     args[0],
+			UserAge:    int32(age),
+			UserGender: userGender,
+			MatchPreferences: &matchpb.MatchPreferences{
+				Gender:    preferredGender,
+				MinAge:    *prefMinAge,
+				MaxAge:    *prefMaxAge,
+				Interests: *prefInterests,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("find match: %w", err)
+		}
+
+		if res.GetMatchId() == "" {
+			return errors.New("no match found yet, the user remains in the matchmaking pool")
+		}
+
+		match, err := client.GetMatch(ctx, &matchpb.GetMatchRequest{MatchId: res.GetMatchId()})
+		if err != nil {
+			return fmt.Errorf("get match %s: %w", res.GetMatchId(), err)
+		}
+
+		cobraCmd.Printf("match %s found\n", res.GetMatchId())
+		for _, participant := range match.GetParticipants() {
+			if participant.GetUserId() != args[0] {
+				printParticipant(cobraCmd, "matched user", participant)
+			}
+		}
+		return nil
 	}
+
+	return cmd
 }
@@ -0,0 +1,34 @@
+package matchsessioncli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+// NewGetMatchCobraCommand creates the command to retrieve a match by its ID.
+func NewGetMatchCobraCommand(grpcAddr *string) *cobra.Command {
+	return &cobra.Command{
+		Use:       "get-match <match-id>",
+		Short:     "Show the details of a match",
+		ValidArgs: []string{"matchID"},
+		Args:      cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, closer, err := dialMatchMakingService(grpcAddr)
+			if err != nil {
+				return err
+			}
+			defer closer()
+
+			res, err := client.GetMatch(cobraCmd.Context(), &matchpb.GetMatchRequest{MatchId: args[0]})
+			if err != nil {
+				return fmt.Errorf("get match %s: %w", args[0], err)
+			}
+
+			printMatch(cobraCmd, res.GetMatch(), res.GetParticipants())
+			return nil
+		},
+	}
+}
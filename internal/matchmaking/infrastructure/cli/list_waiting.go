@@ -0,0 +1,36 @@
+package matchsessioncli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+// NewListWaitingCobraCommand creates the command to list the users waiting for a match.
+func NewListWaitingCobraCommand(grpcAddr *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-waiting",
+		Short: "List the users waiting in the matchmaking pool",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			client, closer, err := dialMatchMakingService(grpcAddr)
+			if err != nil {
+				return err
+			}
+			defer closer()
+
+			res, err := client.ListWaitingUsers(cobraCmd.Context(), &matchpb.ListWaitingUsersRequest{})
+			if err != nil {
+				return fmt.Errorf("list waiting users: %w", err)
+			}
+
+			cobraCmd.Printf("%d users waiting\n", len(res.GetUsers()))
+			for _, user := range res.GetUsers() {
+				printParticipant(cobraCmd, "waiting user", user)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package matchsessioncli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+func printMatch(cobraCmd *cobra.Command, match *matchpb.Match, participants []*matchpb.MatchParticipant) {
+	cobraCmd.Printf("match %s created at %s\n", match.GetId(), match.GetCreatedAt().AsTime().Format(time.RFC3339))
+	for _, participant := range participants {
+		printParticipant(cobraCmd, "participant", participant)
+	}
+}
+
+func printParticipant(cobraCmd *cobra.Command, label string, participant *matchpb.MatchParticipant) {
+	prefs := participant.GetMatchPreferences()
+	cobraCmd.Printf("  %s: id=%s age=%d gender=%s prefs={gender=%s age=%d-%d interests=[%s]}\n",
+		label,
+		participant.GetUserId(),
+		participant.GetUserAge(),
+		formatGender(participant.GetUserGender()),
+		formatGender(prefs.GetGender()),
+		prefs.GetMinAge(),
+		prefs.GetMaxAge(),
+		strings.Join(prefs.GetInterests(), ","),
+	)
+}
+
+func formatGender(g matchpb.Gender) string {
+	return strings.ToLower(strings.TrimPrefix(g.String(), "GENDER_"))
+}
+
+func parseGender(s string) (matchpb.Gender, error) {
+	g, ok := matchpb.Gender_value["GENDER_"+strings.ToUpper(s)]
+	if !ok {
+		return matchpb.Gender_GENDER_UNSPECIFIED, fmt.Errorf("invalid gender %q", s)
+	}
+	return matchpb.Gender(g), nil
+}
+
+func contextWithOptionalTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,129 @@
+package matchsessioncli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	matchmakingconfig "github.com/xfrr/randomtalk/internal/matchmaking/config"
+	matchsimulation "github.com/xfrr/randomtalk/internal/matchmaking/simulation"
+)
+
+// NewSimulateCobraCommand creates the command to run a synthetic population through the matchmaker.
+func NewSimulateCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the matchmaking of a synthetic population",
+		Long: "Generate users arriving at the given rate, run them through the matchmaker with\n" +
+			"the in-memory stores and a fake clock, and report the match rate, the wait times,\n" +
+			"the blocking pairs and the fairness across the preference segments.",
+		Args: cobra.NoArgs,
+	}
+
+	defaults := matchmakingconfig.DefaultMatchmaker()
+	population := matchsimulation.DefaultPopulation()
+
+	arrivalRate := cmd.Flags().Float64("arrival-rate", 2, "mean number of users arriving per second")
+	duration := cmd.Flags().Duration("duration", 10*time.Minute, "how long the users keep arriving")
+	seed := cmd.Flags().Uint64("seed", 1, "seed of the random generator")
+
+	algorithm := cmd.Flags().String("algorithm", defaults.Algorithm,
+		"matching algorithm (gale_shapley, stable_roommates, max_cardinality, max_weight)")
+	mode := cmd.Flags().String("mode", defaults.Mode, "matching mode (immediate, batch)")
+	batchInterval := cmd.Flags().Duration("batch-interval", defaults.BatchInterval, "interval between the batch matching rounds")
+	batchPoolSize := cmd.Flags().Int("batch-pool-size", defaults.BatchPoolSize, "waiting users that trigger a batch matching round")
+	maxWaitTime := cmd.Flags().Duration("max-wait-time", defaults.MaxWaitTime, "how long the users wait for a match")
+	waitingInterval := cmd.Flags().Duration("waiting-users-interval", defaults.WaitingUsersInterval,
+		"how often the waiting users are released or matched again")
+	relaxationInterval := cmd.Flags().Duration("relaxation-interval", defaults.RelaxationInterval,
+		"waiting time between two relaxation steps")
+
+	ageMean := cmd.Flags().Float64("age-mean", population.Age.Mean, "mean age of the users")
+	ageStdDev := cmd.Flags().Float64("age-stddev", population.Age.StdDev, "standard deviation of the users age")
+	ageRange := cmd.Flags().Int32("age-range", population.AgeRange, "years younger or older than themselves the users accept, 0 for any age")
+	femaleShare := cmd.Flags().Float64("female-share", population.Genders.Female, "share of female users")
+	maleShare := cmd.Flags().Float64("male-share", population.Genders.Male, "share of male users")
+	unspecifiedShare := cmd.Flags().Float64("unspecified-share", population.Genders.Unspecified, "share of users of unspecified gender")
+	prefFemaleShare := cmd.Flags().Float64("pref-female-share", population.PreferredGenders.Female, "share of users looking for women")
+	prefMaleShare := cmd.Flags().Float64("pref-male-share", population.PreferredGenders.Male, "share of users looking for men")
+	prefAnyShare := cmd.Flags().Float64("pref-any-share", population.PreferredGenders.Unspecified, "share of users accepting any gender")
+	interests := cmd.Flags().StringSlice("interests", population.Interests, "interests of the users, the most popular first")
+	maxInterests := cmd.Flags().Int("max-interests", population.MaxInterests, "maximum number of interests per user, 0 to disable them")
+	maxDistanceKm := cmd.Flags().Float64("max-distance-km", population.MaxDistanceKm, "maximum distance the users accept, 0 for any")
+	noLocation := cmd.Flags().Bool("no-location", false, "generate users without location")
+
+	cmd.RunE = func(cobraCmd *cobra.Command, _ []string) error {
+		matchmaker := defaults
+		matchmaker.Algorithm = *algorithm
+		matchmaker.Mode = *mode
+		matchmaker.BatchInterval = *batchInterval
+		matchmaker.BatchPoolSize = *batchPoolSize
+		matchmaker.MaxWaitTime = *maxWaitTime
+		matchmaker.WaitingUsersInterval = *waitingInterval
+		matchmaker.RelaxationInterval = *relaxationInterval
+
+		population.Age = matchsimulation.AgeDistribution{Mean: *ageMean, StdDev: *ageStdDev}
+		population.AgeRange = *ageRange
+		population.Genders = matchsimulation.GenderShares{
+			Female:      *femaleShare,
+			Male:        *maleShare,
+			Unspecified: *unspecifiedShare,
+		}
+		population.PreferredGenders = matchsimulation.GenderShares{
+			Female:      *prefFemaleShare,
+			Male:        *prefMaleShare,
+			Unspecified: *prefAnyShare,
+		}
+		population.Interests = *interests
+		population.MaxInterests = *maxInterests
+		population.MaxDistanceKm = *maxDistanceKm
+		if *noLocation {
+			population.Cities = nil
+		}
+
+		cobraCmd.Printf("simulating %s of arrivals at %.2f users/s with %s matching (%s)...\n",
+			*duration, *arrivalRate, matchmaker.MatchingMode(), matchmaker.Algorithm)
+		report, err := matchsimulation.Run(cobraCmd.Context(), matchsimulation.Config{
+			Population:  population,
+			ArrivalRate: *arrivalRate,
+			Duration:    *duration,
+			Matchmaker:  matchmaker,
+			Seed:        *seed,
+		})
+		if err != nil {
+			return err
+		}
+
+		printSimulationReport(cobraCmd, report)
+		return nil
+	}
+	return cmd
+}
+
+func printSimulationReport(cobraCmd *cobra.Command, report matchsimulation.Report) {
+	cobraCmd.Printf("arrivals: %d\n", report.Arrivals)
+	cobraCmd.Printf("matched: %d (%.1f%%), released: %d, still waiting: %d\n",
+		report.Matched, 100*report.MatchRate, report.Released, report.Waiting)
+	cobraCmd.Printf("wait: %s\n", formatWaitStats(report.Wait))
+	cobraCmd.Printf("blocking pairs: %d of %d compatible pairs (%.2f%%)\n",
+		report.BlockingPairs, report.CompatiblePairs, 100*report.BlockingPairShare)
+	cobraCmd.Printf("fairness across segments: %.3f\n", report.Fairness)
+	for _, segment := range report.Segments {
+		cobraCmd.Printf("  segment %s: arrivals=%d matched=%d (%.1f%%) wait={%s}\n",
+			segment.Segment,
+			segment.Arrivals,
+			segment.Matched,
+			100*segment.MatchRate,
+			formatWaitStats(segment.Wait),
+		)
+	}
+}
+
+func formatWaitStats(stats matchsimulation.WaitStats) string {
+	round := func(d time.Duration) time.Duration { return d.Round(time.Millisecond) }
+	return "mean=" + round(stats.Mean).String() +
+		" p50=" + round(stats.P50).String() +
+		" p90=" + round(stats.P90).String() +
+		" p99=" + round(stats.P99).String() +
+		" max=" + round(stats.Max).String()
+}
@@ -0,0 +1,50 @@
+package matchsessioncli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+// NewWatchCobraCommand creates the command to follow the matches as they are created.
+func NewWatchCobraCommand(grpcAddr *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Print the matches as they are created until interrupted",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			client, closer, err := dialMatchMakingService(grpcAddr)
+			if err != nil {
+				return err
+			}
+			defer closer()
+
+			stream, err := client.WatchMatches(cobraCmd.Context(), &matchpb.WatchMatchesRequest{})
+			if err != nil {
+				return fmt.Errorf("watch matches: %w", err)
+			}
+
+			cobraCmd.Println("watching matches...")
+			for {
+				res, recvErr := stream.Recv()
+				switch {
+				case recvErr == nil:
+					printMatch(cobraCmd, res.GetMatch(), res.GetParticipants())
+				case errors.Is(recvErr, io.EOF),
+					errors.Is(cobraCmd.Context().Err(), context.Canceled),
+					status.Code(recvErr) == codes.Canceled:
+					return nil
+				default:
+					return fmt.Errorf("receive match: %w", recvErr)
+				}
+			}
+		},
+	}
+}
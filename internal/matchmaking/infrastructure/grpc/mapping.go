@@ -0,0 +1,105 @@
+package matchgrpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+func toGender(g matchpb.Gender) gender.Gender {
+	switch g {
+	case matchpb.Gender_GENDER_FEMALE:
+		return gender.Female
+	case matchpb.Gender_GENDER_MALE:
+		return gender.Male
+	default:
+		return gender.Unspecified
+	}
+}
+
+func toProtoGender(g gender.Gender) matchpb.Gender {
+	switch g {
+	case gender.Female:
+		return matchpb.Gender_GENDER_FEMALE
+	case gender.Male:
+		return matchpb.Gender_GENDER_MALE
+	default:
+		return matchpb.Gender_GENDER_UNSPECIFIED
+	}
+}
+
+func toPreferences(prefs *matchpb.MatchPreferences) matchmaking.Preferences {
+	return matchmaking.DefaultPreferences().
+		WithMinAge(prefs.GetMinAge()).
+		WithMaxAge(prefs.GetMaxAge()).
+		WithGender(toGender(prefs.GetGender())).
+		WithInterests(prefs.GetInterests()).
+		WithMaxDistanceKm(prefs.GetMaxDistanceKm()).
+		WithMaxWaitTimeSeconds(prefs.GetMaxWaitTimeSeconds()).
+		WithGroupSize(prefs.GetGroupSize())
+}
+
+func toLocation(latLng *matchpb.LatLng) *location.Location {
+	if latLng == nil {
+		return nil
+	}
+	loc := location.New(latLng.GetLatitude(), latLng.GetLongitude())
+	return &loc
+}
+
+func toProtoMatch(match *matchdomain.Match) *matchpb.Match {
+	createdAt := timestamppb.New(match.CreatedAt())
+	participantIDs := make([]string, 0, len(match.Participants()))
+	for _, participant := range match.Participants() {
+		participantIDs = append(participantIDs, participant.ID())
+	}
+
+	return &matchpb.Match{
+		Id:             match.ID(),
+		ParticipantIds: participantIDs,
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+}
+
+func toProtoUserMatch(entry matchdomain.MatchHistoryEntry) *matchpb.UserMatch {
+	return &matchpb.UserMatch{
+		MatchId:   entry.MatchID,
+		PartnerId: entry.PartnerID,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+		MemberIds: entry.MemberIDs,
+	}
+}
+
+func toProtoPreferences(prefs matchmaking.Preferences) *matchpb.MatchPreferences {
+	return &matchpb.MatchPreferences{
+		Gender:             toProtoGender(prefs.Gender),
+		MinAge:             prefs.MinAge,
+		MaxAge:             prefs.MaxAge,
+		Interests:          prefs.Interests,
+		MaxDistanceKm:      prefs.MaxDistanceKm,
+		MaxWaitTimeSeconds: prefs.MaxWaitTimeSeconds,
+		GroupSize:          prefs.GroupSize,
+	}
+}
+
+func toProtoMatchParticipant(user *matchdomain.User) *matchpb.MatchParticipant {
+	return &matchpb.MatchParticipant{
+		UserId:           user.ID(),
+		UserAge:          user.Age(),
+		UserGender:       toProtoGender(user.Gender()),
+		MatchPreferences: toProtoPreferences(user.Preferences()),
+	}
+}
+
+func toProtoMatchParticipants(match *matchdomain.Match) []*matchpb.MatchParticipant {
+	participants := make([]*matchpb.MatchParticipant, 0, len(match.Participants()))
+	for _, participant := range match.Participants() {
+		participants = append(participants, toProtoMatchParticipant(participant))
+	}
+	return participants
+}
@@ -0,0 +1,39 @@
+package matchgrpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+// NewGRPCServer creates a gRPC server with the MatchMakingService registered.
+func NewGRPCServer(srv matchpb.MatchMakingServiceServer, opts ...grpc.ServerOption) *grpc.Server {
+	grpcServer := grpc.NewServer(opts...)
+	matchpb.RegisterMatchMakingServiceServer(grpcServer, srv)
+	return grpcServer
+}
+
+// NewGatewayServer creates an HTTP server exposing the MatchMakingService
+// through its grpc-gateway bindings, proxying the requests to the gRPC server
+// listening on grpcAddr so that streaming calls are supported as well.
+func NewGatewayServer(ctx context.Context, addr, grpcAddr string) (*http.Server, error) {
+	mux := runtime.NewServeMux()
+	err := matchpb.RegisterMatchMakingServiceHandlerFromEndpoint(ctx, mux, grpcAddr, []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
@@ -0,0 +1,244 @@
+package matchgrpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/xfrr/go-cqrsify/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	matchcommands "github.com/xfrr/randomtalk/internal/matchmaking/application/commands"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+const defaultFindMatchTimeout = 30 * time.Second
+
+var _ matchpb.MatchMakingServiceServer = (*MatchMakingServer)(nil)
+
+// MatchSubscriber subscribes to the confirmed matches, proposed matches are only
+// received once both users accepted them.
+type MatchSubscriber interface {
+	// Subscribe subscribes to the matches confirmed for the given user.
+	Subscribe(userID string) (<-chan *matchdomain.Match, func())
+
+	// Watch subscribes to every match confirmed from now on.
+	Watch() (<-chan *matchdomain.Match, func())
+}
+
+// MatchMakingServer implements the MatchMakingService gRPC API
+// on top of the matchmaking command bus, match repository and match history.
+type MatchMakingServer struct {
+	matchpb.UnimplementedMatchMakingServiceServer
+
+	cmdbus           matchcommands.CommandBus
+	matchRepository  matchdomain.MatchRepository
+	matchHistory     matchdomain.MatchHistory
+	userStore        matchdomain.UserStore
+	matchSubscriber  MatchSubscriber
+	findMatchTimeout time.Duration
+	logger           *zerolog.Logger
+}
+
+// ServerOption defines a functional option to configure the MatchMakingServer.
+type ServerOption func(*MatchMakingServer)
+
+// WithLogger overrides the default zerolog.Logger.
+func WithLogger(logger *zerolog.Logger) ServerOption {
+	return func(s *MatchMakingServer) {
+		s.logger = logger
+	}
+}
+
+// WithFindMatchTimeout sets the maximum time FindMatch waits for a match.
+func WithFindMatchTimeout(timeout time.Duration) ServerOption {
+	return func(s *MatchMakingServer) {
+		if timeout > 0 {
+			s.findMatchTimeout = timeout
+		}
+	}
+}
+
+// NewMatchMakingServer initializes a new MatchMakingServer.
+func NewMatchMakingServer(
+	cmdbus matchcommands.CommandBus,
+	matchRepository matchdomain.MatchRepository,
+	matchHistory matchdomain.MatchHistory,
+	userStore matchdomain.UserStore,
+	matchSubscriber MatchSubscriber,
+	opts ...ServerOption,
+) *MatchMakingServer {
+	srv := &MatchMakingServer{
+		cmdbus:           cmdbus,
+		matchRepository:  matchRepository,
+		matchHistory:     matchHistory,
+		userStore:        userStore,
+		matchSubscriber:  matchSubscriber,
+		findMatchTimeout: defaultFindMatchTimeout,
+		logger:           &zerolog.Logger{},
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
+}
+
+// FindMatch enqueues a match request for the user and waits until a match is confirmed
+// or the find match timeout expires. When the timeout expires, an empty match ID is
+// returned and the request remains queued in the matchmaking pool.
+func (s *MatchMakingServer) FindMatch(ctx context.Context, req *matchpb.FindMatchRequest) (*matchpb.FindMatchResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	// subscribe before dispatching to avoid missing an immediate match
+	matches, unsubscribe := s.matchSubscriber.Subscribe(req.GetUserId())
+	defer unsubscribe()
+
+	cmd := matchcommands.NewMatchUserWithPreferencesCommand(
+		req.GetUserId(),
+		req.GetUserAge(),
+		toGender(req.GetUserGender()),
+		toLocation(req.GetUserLocation()),
+		toPreferences(req.GetMatchPreferences()),
+	)
+
+	if err := messaging.DispatchCommand(ctx, s.cmdbus, cmd); err != nil {
+		s.logger.Error().Err(err).Str("user_id", req.GetUserId()).Msg("failed to dispatch match request")
+		return nil, toStatusError(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.findMatchTimeout)
+	defer cancel()
+
+	select {
+	case match := <-matches:
+		return &matchpb.FindMatchResponse{MatchId: match.ID()}, nil
+	case <-waitCtx.Done():
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return &matchpb.FindMatchResponse{}, nil
+	}
+}
+
+// GetMatch retrieves a match by its ID.
+func (s *MatchMakingServer) GetMatch(ctx context.Context, req *matchpb.GetMatchRequest) (*matchpb.GetMatchResponse, error) {
+	if req.GetMatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "match_id is required")
+	}
+
+	match, err := s.matchRepository.FindByID(ctx, req.GetMatchId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &matchpb.GetMatchResponse{
+		Match:        toProtoMatch(match),
+		Participants: toProtoMatchParticipants(match),
+	}, nil
+}
+
+// ListWaitingUsers lists the users waiting in the matchmaking pool.
+func (s *MatchMakingServer) ListWaitingUsers(
+	ctx context.Context,
+	_ *matchpb.ListWaitingUsersRequest,
+) (*matchpb.ListWaitingUsersResponse, error) {
+	users, err := s.userStore.GetAll(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	res := &matchpb.ListWaitingUsersResponse{
+		Users: make([]*matchpb.MatchParticipant, 0, len(users)),
+	}
+	for _, user := range users {
+		res.Users = append(res.Users, toProtoMatchParticipant(user))
+	}
+	return res, nil
+}
+
+// ListUserMatches lists the matches of the user from the most recent one.
+func (s *MatchMakingServer) ListUserMatches(
+	ctx context.Context,
+	req *matchpb.ListUserMatchesRequest,
+) (*matchpb.ListUserMatchesResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	page, err := s.matchHistory.ListMatchesByUser(ctx, req.GetUserId(), req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	res := &matchpb.ListUserMatchesResponse{
+		Matches:    make([]*matchpb.UserMatch, 0, len(page.Entries)),
+		NextCursor: page.NextCursor,
+	}
+	for _, entry := range page.Entries {
+		res.Matches = append(res.Matches, toProtoUserMatch(entry))
+	}
+	return res, nil
+}
+
+// GetLastMatch retrieves the most recent match of the user.
+func (s *MatchMakingServer) GetLastMatch(ctx context.Context, req *matchpb.GetLastMatchRequest) (*matchpb.GetLastMatchResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	entry, err := s.matchHistory.GetLastMatch(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &matchpb.GetLastMatchResponse{Match: toProtoUserMatch(entry)}, nil
+}
+
+// WatchMatches streams the matches confirmed from now on until the client goes away.
+func (s *MatchMakingServer) WatchMatches(
+	_ *matchpb.WatchMatchesRequest,
+	stream matchpb.MatchMakingService_WatchMatchesServer,
+) error {
+	matches, unsubscribe := s.matchSubscriber.Watch()
+	defer unsubscribe()
+
+	for {
+		select {
+		case match := <-matches:
+			err := stream.Send(&matchpb.WatchMatchesResponse{
+				Match:        toProtoMatch(match),
+				Participants: toProtoMatchParticipants(match),
+			})
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, matchdomain.ErrMatchNotFound),
+		errors.Is(err, matchdomain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, matchdomain.ErrInvalidMatchHistoryCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, matchdomain.ErrMatchAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,147 @@
+package matchgrpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	matchcommands "github.com/xfrr/randomtalk/internal/matchmaking/application/commands"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchgrpc "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/grpc"
+	matchmakinginmemory "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+	matchpb "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/matchmaking/v1"
+)
+
+func newTestServer(
+	t *testing.T,
+	userStore matchdomain.UserStore,
+	opts ...matchgrpc.ServerOption,
+) *matchgrpc.MatchMakingServer {
+	t.Helper()
+
+	repo := matchmakinginmemory.NewMatchRepository()
+	notifier := matchmakinginmemory.NewMatchNotifier()
+	processor, err := matchdomain.NewUserMatchProcessor(
+		repo,
+		userStore,
+		matchdomain.NewGaleShapleyStableMatcher(),
+		matchdomain.WithNotificationsChannel(notifier),
+	)
+	require.NoError(t, err)
+
+	cmdbus, closer := matchcommands.InitCommandBus(context.Background(), processor)
+	t.Cleanup(closer)
+
+	opts = append([]matchgrpc.ServerOption{matchgrpc.WithFindMatchTimeout(time.Second)}, opts...)
+	return matchgrpc.NewMatchMakingServer(cmdbus, repo, matchmakinginmemory.NewMatchHistory(0), userStore, notifier, opts...)
+}
+
+func TestMatchMakingServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("find match requires a user id", func(t *testing.T) {
+		srv := newTestServer(t, matchmakinginmemory.NewUserStore(nil))
+
+		_, err := srv.FindMatch(ctx, &matchpb.FindMatchRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("find match returns an empty match id when nobody is waiting", func(t *testing.T) {
+		srv := newTestServer(
+			t,
+			matchmakinginmemory.NewUserStore(nil),
+			matchgrpc.WithFindMatchTimeout(50*time.Millisecond),
+		)
+
+		res, err := srv.FindMatch(ctx, &matchpb.FindMatchRequest{UserId: "A1", UserAge: 25})
+		require.NoError(t, err)
+		assert.Empty(t, res.GetMatchId())
+	})
+
+	t.Run("find match returns the match created for both users", func(t *testing.T) {
+		userStore := matchmakinginmemory.NewUserStore(nil)
+		srv := newTestServer(t, userStore)
+
+		waitingRes := make(chan *matchpb.FindMatchResponse, 1)
+		go func() {
+			res, err := srv.FindMatch(ctx, &matchpb.FindMatchRequest{UserId: "A1", UserAge: 25})
+			assert.NoError(t, err)
+			waitingRes <- res
+		}()
+
+		// wait until the first user is queued
+		require.Eventually(t, func() bool {
+			users, err := userStore.GetAll(ctx)
+			return err == nil && len(users) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		res, err := srv.FindMatch(ctx, &matchpb.FindMatchRequest{UserId: "B1", UserAge: 30})
+		require.NoError(t, err)
+		require.NotEmpty(t, res.GetMatchId())
+		assert.Equal(t, res.GetMatchId(), (<-waitingRes).GetMatchId())
+
+		got, err := srv.GetMatch(ctx, &matchpb.GetMatchRequest{MatchId: res.GetMatchId()})
+		require.NoError(t, err)
+		assert.Equal(t, res.GetMatchId(), got.GetMatch().GetId())
+		assert.ElementsMatch(t, []string{"A1", "B1"}, got.GetMatch().GetParticipantIds())
+	})
+
+	t.Run("get match returns not found for unknown matches", func(t *testing.T) {
+		srv := newTestServer(t, matchmakinginmemory.NewUserStore(nil))
+
+		_, err := srv.GetMatch(ctx, &matchpb.GetMatchRequest{MatchId: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestMatchMakingServerMatchHistory(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history := matchmakinginmemory.NewMatchHistory(0)
+	for i, matchID := range []string{"match-1", "match-2", "match-3"} {
+		entry := matchdomain.MatchHistoryEntry{
+			MatchID:   matchID,
+			PartnerID: "B1",
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		}
+		require.NoError(t, history.AddMatch(ctx, "A1", entry))
+	}
+
+	srv := matchgrpc.NewMatchMakingServer(nil, nil, history, nil, nil)
+
+	t.Run("list user matches pages from the most recent match", func(t *testing.T) {
+		res, err := srv.ListUserMatches(ctx, &matchpb.ListUserMatchesRequest{UserId: "A1", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, res.GetMatches(), 2)
+		assert.Equal(t, "match-3", res.GetMatches()[0].GetMatchId())
+		assert.Equal(t, "match-2", res.GetMatches()[1].GetMatchId())
+		assert.Equal(t, "match-2", res.GetNextCursor())
+
+		res, err = srv.ListUserMatches(ctx, &matchpb.ListUserMatchesRequest{UserId: "A1", Cursor: res.GetNextCursor()})
+		require.NoError(t, err)
+		require.Len(t, res.GetMatches(), 1)
+		assert.Equal(t, "match-1", res.GetMatches()[0].GetMatchId())
+		assert.Empty(t, res.GetNextCursor())
+	})
+
+	t.Run("list user matches rejects unknown cursors", func(t *testing.T) {
+		_, err := srv.ListUserMatches(ctx, &matchpb.ListUserMatchesRequest{UserId: "A1", Cursor: "unknown"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("get last match returns the most recent match", func(t *testing.T) {
+		res, err := srv.GetLastMatch(ctx, &matchpb.GetLastMatchRequest{UserId: "A1"})
+		require.NoError(t, err)
+		assert.Equal(t, "match-3", res.GetMatch().GetMatchId())
+		assert.Equal(t, "B1", res.GetMatch().GetPartnerId())
+
+		_, err = srv.GetLastMatch(ctx, &matchpb.GetLastMatchRequest{UserId: "B2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,59 @@
+package matchmakinghandlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/messaging"
+)
+
+// MatchCreatedEventHandler projects the created matches into the match history of their users.
+type MatchCreatedEventHandler struct {
+	logger       *zerolog.Logger
+	matchHistory matchdomain.MatchHistory
+}
+
+func NewMatchCreatedEventHandler(
+	matchHistory matchdomain.MatchHistory,
+	logger *zerolog.Logger,
+) *MatchCreatedEventHandler {
+	return &MatchCreatedEventHandler{
+		logger:       logger,
+		matchHistory: matchHistory,
+	}
+}
+
+func (h *MatchCreatedEventHandler) Handle(ctx context.Context, msg *messaging.Event) error {
+	h.logger.Debug().
+		Str("messaging_event_id", msg.ID()).
+		Str("messaging_event_type", msg.Type()).
+		Msg("match created event received")
+
+	evt := new(matchdomain.MatchCreatedEvent)
+	if err := json.Unmarshal(msg.Data(), evt); err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("unmarshal match created event: %w", err)
+	}
+
+	var errs []error
+	for userID, entry := range matchdomain.MatchHistoryEntries(evt, msg.Time()) {
+		if err := h.matchHistory.AddMatch(ctx, userID, entry); err != nil {
+			errs = append(errs, fmt.Errorf("add match to the history of user %s: %w", userID, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		// nack msg to retry, the matches already in the history are skipped
+		msg.Nack()
+		return err
+	}
+
+	// ack msg
+	msg.Ack()
+	return nil
+}
@@ -0,0 +1,57 @@
+package matchmakinghandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/messaging"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MatchRequestCancelledEventType is the type of the chat notifications sent when an user
+// stops waiting for a match.
+const MatchRequestCancelledEventType = "com.randomtalk.chat.notifications.match_request_cancelled"
+
+type MatchRequestCancelledNotificationHandler struct {
+	logger               *zerolog.Logger
+	matchmakingProcessor matchdomain.MatchmakingProcessor
+}
+
+func NewMatchRequestCancelledEventHandler(
+	matchmakingService matchdomain.MatchmakingProcessor,
+	logger *zerolog.Logger,
+) *MatchRequestCancelledNotificationHandler {
+	return &MatchRequestCancelledNotificationHandler{
+		logger:               logger,
+		matchmakingProcessor: matchmakingService,
+	}
+}
+
+func (h *MatchRequestCancelledNotificationHandler) Handle(ctx context.Context, msg *messaging.Event) error {
+	h.logger.Debug().
+		Str("messaging_event_id", msg.ID()).
+		Str("messaging_event_type", msg.Type()).
+		Msg("match request cancelled notification received")
+
+	notification := new(chatpbv1.MatchRequestCancelledNotification)
+	err := protojson.Unmarshal(msg.Data(), notification)
+	if err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("unmarshal match request cancelled notification: %w", err)
+	}
+
+	err = h.matchmakingProcessor.CancelMatchRequest(ctx, notification.GetUserId())
+	if err != nil {
+		// nack msg to retry
+		msg.Nack()
+		return fmt.Errorf("cancel match request: %w", err)
+	}
+
+	// ack msg
+	msg.Ack()
+	return nil
+}
@@ -0,0 +1,81 @@
+package matchmakinghandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/messaging"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MatchRespondedEventType is the type of the chat notifications sent when an user
+// accepts or declines a proposed match.
+const MatchRespondedEventType = "com.randomtalk.chat.notifications.match_responded"
+
+type MatchRespondedNotificationHandler struct {
+	logger               *zerolog.Logger
+	matchmakingProcessor matchdomain.MatchmakingProcessor
+}
+
+func NewMatchRespondedEventHandler(
+	matchmakingService matchdomain.MatchmakingProcessor,
+	logger *zerolog.Logger,
+) *MatchRespondedNotificationHandler {
+	return &MatchRespondedNotificationHandler{
+		logger:               logger,
+		matchmakingProcessor: matchmakingService,
+	}
+}
+
+func (h *MatchRespondedNotificationHandler) Handle(ctx context.Context, msg *messaging.Event) error {
+	h.logger.Debug().
+		Str("messaging_event_id", msg.ID()).
+		Str("messaging_event_type", msg.Type()).
+		Msg("match responded notification received")
+
+	notification := new(chatpbv1.MatchResponseNotification)
+	err := protojson.Unmarshal(msg.Data(), notification)
+	if err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("unmarshal match responded notification: %w", err)
+	}
+
+	err = h.matchmakingProcessor.RespondToMatch(
+		ctx,
+		notification.GetMatchId(),
+		notification.GetUserId(),
+		notification.GetAccepted(),
+	)
+	if isOutdatedResponse(err) {
+		// the proposal is already resolved, retrying does not help
+		h.logger.Warn().
+			Err(err).
+			Str("match_id", notification.GetMatchId()).
+			Str("user_id", notification.GetUserId()).
+			Msg("match response discarded")
+		msg.Ack()
+		return nil
+	}
+	if err != nil {
+		// nack msg to retry
+		msg.Nack()
+		return fmt.Errorf("respond to match: %w", err)
+	}
+
+	// ack msg
+	msg.Ack()
+	return nil
+}
+
+// isOutdatedResponse reports whether the response is for a match that no longer waits for it.
+func isOutdatedResponse(err error) bool {
+	return errors.Is(err, matchdomain.ErrMatchNotFound) ||
+		errors.Is(err, matchdomain.ErrMatchNotProposed) ||
+		errors.Is(err, matchdomain.ErrMatchProposalExpired) ||
+		errors.Is(err, matchdomain.ErrUserNotInMatch)
+}
@@ -0,0 +1,72 @@
+package matchmakinghandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+	"github.com/xfrr/randomtalk/internal/shared/messaging"
+	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// PartnerRatedEventType is the type of the chat notifications sent when an user
+// rates a partner it talked with.
+const PartnerRatedEventType = "com.randomtalk.chat.notifications.partner_rated"
+
+type PartnerRatedNotificationHandler struct {
+	logger          *zerolog.Logger
+	reputationStore matchdomain.ReputationStore
+}
+
+func NewPartnerRatedEventHandler(
+	reputationStore matchdomain.ReputationStore,
+	logger *zerolog.Logger,
+) *PartnerRatedNotificationHandler {
+	return &PartnerRatedNotificationHandler{
+		logger:          logger,
+		reputationStore: reputationStore,
+	}
+}
+
+func (h *PartnerRatedNotificationHandler) Handle(ctx context.Context, msg *messaging.Event) error {
+	h.logger.Debug().
+		Str("messaging_event_id", msg.ID()).
+		Str("messaging_event_type", msg.Type()).
+		Msg("partner rated notification received")
+
+	notification := new(chatpbv1.PartnerRatedNotification)
+	err := protojson.Unmarshal(msg.Data(), notification)
+	if err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("unmarshal partner rated notification: %w", err)
+	}
+
+	tags, err := matchmaking.ParseRatingTags(notification.GetTags()...)
+	if err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("parse rating tags: %w", err)
+	}
+
+	err = h.reputationStore.AddRating(ctx, matchdomain.Rating{
+		RaterUserID: notification.GetRaterUserId(),
+		RatedUserID: notification.GetRatedUserId(),
+		MatchID:     notification.GetMatchId(),
+		Liked:       notification.GetLiked(),
+		Tags:        tags,
+		RatedAt:     notification.GetOccurredAt().AsTime(),
+	})
+	if err != nil {
+		// nack msg to retry
+		msg.Nack()
+		return fmt.Errorf("add rating: %w", err)
+	}
+
+	// ack msg
+	msg.Ack()
+	return nil
+}
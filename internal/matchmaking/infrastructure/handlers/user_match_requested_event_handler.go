@@ -7,6 +7,8 @@ import (
 	"github.com/rs/zerolog"
 	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/language"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 	"github.com/xfrr/randomtalk/internal/shared/messaging"
 	chatpbv1 "github.com/xfrr/randomtalk/proto/gen/go/randomtalk/chat/v1"
@@ -16,15 +18,21 @@ import (
 type UserMatchRequestedNotificationHandler struct {
 	logger               *zerolog.Logger
 	matchmakingProcessor matchdomain.MatchmakingProcessor
+	reputationStore      matchdomain.ReputationStore
+	reputationPolicy     matchdomain.ReputationPolicy
 }
 
 func NewUserMatchRequestedEventHandler(
 	matchmakingService matchdomain.MatchmakingProcessor,
+	reputationStore matchdomain.ReputationStore,
+	reputationPolicy matchdomain.ReputationPolicy,
 	logger *zerolog.Logger,
 ) *UserMatchRequestedNotificationHandler {
 	return &UserMatchRequestedNotificationHandler{
 		logger:               logger,
 		matchmakingProcessor: matchmakingService,
+		reputationStore:      reputationStore,
+		reputationPolicy:     reputationPolicy,
 	}
 }
 
@@ -42,6 +50,20 @@ func (h *UserMatchRequestedNotificationHandler) Handle(ctx context.Context, msg
 		return fmt.Errorf("unmarshal user match requested notification: %w", err)
 	}
 
+	languages, err := language.ParseAll(notification.GetUserAttributes().GetLanguages()...)
+	if err != nil {
+		// discard message
+		msg.Nack()
+		return fmt.Errorf("parse user languages: %w", err)
+	}
+
+	reputation, err := h.reputationStore.Get(ctx, notification.GetUserAttributes().GetId())
+	if err != nil {
+		// nack msg to retry
+		msg.Nack()
+		return fmt.Errorf("get user reputation: %w", err)
+	}
+
 	// create user from notification
 	user := matchdomain.NewUser(
 		notification.GetUserAttributes().GetId(),
@@ -51,9 +73,27 @@ func (h *UserMatchRequestedNotificationHandler) Handle(ctx context.Context, msg
 			WithMinAge(notification.GetUserPreferences().GetMinAge()).
 			WithMaxAge(notification.GetUserPreferences().GetMaxAge()).
 			WithGender(toGender(notification.GetUserPreferences().GetGender())).
-			WithInterests(notification.GetUserPreferences().GetInterests()),
+			WithInterests(notification.GetUserPreferences().GetInterests()).
+			WithMaxDistanceKm(notification.GetUserPreferences().GetMaxDistanceKm()).
+			WithLocationScope(toLocationScope(notification.GetUserPreferences().GetLocationScope())).
+			WithMaxWaitTimeSeconds(notification.GetUserPreferences().GetMaxWaitTimeSeconds()).
+			WithRequireSharedLanguage(notification.GetUserPreferences().GetRequireSharedLanguage()).
+			WithGroupSize(notification.GetUserPreferences().GetGroupSize()),
+		matchdomain.WithLocation(toLocation(notification.GetUserAttributes().GetLocation())),
+		matchdomain.WithBlockedUsers(notification.GetBlockedUserIds()...),
+		matchdomain.WithLanguages(languages...),
+		matchdomain.WithReputation(reputation.Score(), reputation.IsQuarantined(h.reputationPolicy)),
 	)
 
+	// keep the user apart from the partner it has just skipped
+	if skippedUserID := notification.GetSkippedUserId(); skippedUserID != "" {
+		if err = h.matchmakingProcessor.SkipPair(ctx, user.ID(), skippedUserID); err != nil {
+			// nack msg to retry
+			msg.Nack()
+			return fmt.Errorf("skip pair: %w", err)
+		}
+	}
+
 	// attempt to match user with preferences
 	err = h.matchmakingProcessor.ProcessMatchRequest(ctx, *user)
 	if err != nil {
@@ -77,3 +117,24 @@ func toGender(g chatpbv1.Gender) gender.Gender {
 		return gender.Unspecified
 	}
 }
+
+func toLocation(l *chatpbv1.UserLocation) *location.Location {
+	if l == nil {
+		return nil
+	}
+	loc := location.New(l.GetLatitude(), l.GetLongitude()).
+		WithCountryCode(l.GetCountryCode()).
+		WithCityCode(l.GetCityCode())
+	return &loc
+}
+
+func toLocationScope(scope chatpbv1.LocationScope) matchmaking.LocationScope {
+	switch scope {
+	case chatpbv1.LocationScope_LOCATION_SCOPE_COUNTRY:
+		return matchmaking.LocationScopeCountry
+	case chatpbv1.LocationScope_LOCATION_SCOPE_CITY:
+		return matchmaking.LocationScopeCity
+	default:
+		return matchmaking.LocationScopeAny
+	}
+}
@@ -0,0 +1,55 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.MatchHistory = (*MatchHistory)(nil)
+
+// MatchHistory implements matchdomain.MatchHistory keeping the history of every user in memory.
+type MatchHistory struct {
+	mu         sync.RWMutex
+	histories  map[string]matchdomain.UserMatchHistory
+	maxEntries int
+}
+
+// NewMatchHistory initializes an in-memory match history keeping up to maxEntries matches per user.
+// A zero maxEntries keeps every match.
+func NewMatchHistory(maxEntries int) *MatchHistory {
+	return &MatchHistory{
+		histories:  make(map[string]matchdomain.UserMatchHistory),
+		maxEntries: maxEntries,
+	}
+}
+
+// AddMatch adds the match to the history of the user.
+func (h *MatchHistory) AddMatch(_ context.Context, userID string, entry matchdomain.MatchHistoryEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.histories[userID], _ = h.histories[userID].Add(entry, h.maxEntries)
+	return nil
+}
+
+// ListMatchesByUser lists the matches of the user from the most recent one.
+func (h *MatchHistory) ListMatchesByUser(
+	_ context.Context,
+	userID, cursor string,
+	limit int,
+) (matchdomain.MatchHistoryPage, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.histories[userID].Page(cursor, limit)
+}
+
+// GetLastMatch retrieves the most recent match of the user.
+func (h *MatchHistory) GetLastMatch(_ context.Context, userID string) (matchdomain.MatchHistoryEntry, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.histories[userID].Last()
+}
@@ -0,0 +1,103 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.MatchMetrics = (*MatchMetrics)(nil)
+
+// MatchMetricsSnapshot aggregates the recorded matches and rounds of a matching mode.
+type MatchMetricsSnapshot struct {
+	Matches      int
+	Rounds       int
+	AverageScore float64
+	AverageWait  time.Duration
+	MaxWait      time.Duration
+}
+
+// MatchMetrics implements matchdomain.MatchMetrics aggregating the recorded values in memory.
+type MatchMetrics struct {
+	mu     sync.Mutex
+	byMode map[matchdomain.MatchingMode]*matchModeMetrics
+}
+
+type matchModeMetrics struct {
+	matches    int
+	rounds     int
+	totalScore float64
+	waits      int
+	totalWait  time.Duration
+	maxWait    time.Duration
+}
+
+// NewMatchMetrics creates an empty in-memory match metrics aggregate.
+func NewMatchMetrics() *MatchMetrics {
+	return &MatchMetrics{
+		byMode: make(map[matchdomain.MatchingMode]*matchModeMetrics),
+	}
+}
+
+// RecordMatch records a created match, its score and the time each user waited for it.
+func (m *MatchMetrics) RecordMatch(
+	_ context.Context,
+	mode matchdomain.MatchingMode,
+	score float64,
+	waited ...time.Duration,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mm := m.mode(mode)
+	mm.matches++
+	mm.totalScore += score
+	for _, w := range waited {
+		mm.waits++
+		mm.totalWait += w
+		mm.maxWait = max(mm.maxWait, w)
+	}
+}
+
+// RecordRound records a batch matching round.
+func (m *MatchMetrics) RecordRound(_ context.Context, mode matchdomain.MatchingMode, _, _ int, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mode(mode).rounds++
+}
+
+// Snapshot returns the aggregated metrics of the given matching mode.
+func (m *MatchMetrics) Snapshot(mode matchdomain.MatchingMode) MatchMetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mm, ok := m.byMode[mode]
+	if !ok {
+		return MatchMetricsSnapshot{}
+	}
+
+	snapshot := MatchMetricsSnapshot{
+		Matches: mm.matches,
+		Rounds:  mm.rounds,
+		MaxWait: mm.maxWait,
+	}
+	if mm.matches > 0 {
+		snapshot.AverageScore = mm.totalScore / float64(mm.matches)
+	}
+	if mm.waits > 0 {
+		snapshot.AverageWait = mm.totalWait / time.Duration(mm.waits)
+	}
+	return snapshot
+}
+
+func (m *MatchMetrics) mode(mode matchdomain.MatchingMode) *matchModeMetrics {
+	mm, ok := m.byMode[mode]
+	if !ok {
+		mm = &matchModeMetrics{}
+		m.byMode[mode] = mm
+	}
+	return mm
+}
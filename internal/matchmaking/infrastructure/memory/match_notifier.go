@@ -0,0 +1,100 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.NotificationsChannel = (*MatchNotifier)(nil)
+
+// watcherBufferSize is the number of matches buffered for each watcher.
+// Matches are dropped for watchers that fall behind.
+const watcherBufferSize = 64
+
+// MatchNotifier implements matchdomain.NotificationsChannel by fanning out
+// the confirmed matches to the in-process subscribers waiting for a given user,
+// and to the watchers of every confirmed match.
+type MatchNotifier struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *matchdomain.Match]struct{}
+	watchers    map[chan *matchdomain.Match]struct{}
+}
+
+// NewMatchNotifier initializes an in-memory match notifier.
+func NewMatchNotifier() *MatchNotifier {
+	return &MatchNotifier{
+		subscribers: make(map[string]map[chan *matchdomain.Match]struct{}),
+		watchers:    make(map[chan *matchdomain.Match]struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives the matches confirmed for the given user
+// and a function to release the subscription.
+func (n *MatchNotifier) Subscribe(userID string) (<-chan *matchdomain.Match, func()) {
+	ch := make(chan *matchdomain.Match, 1)
+
+	n.mu.Lock()
+	if _, ok := n.subscribers[userID]; !ok {
+		n.subscribers[userID] = make(map[chan *matchdomain.Match]struct{})
+	}
+	n.subscribers[userID][ch] = struct{}{}
+	n.mu.Unlock()
+
+	unsubscribe := func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.subscribers[userID], ch)
+		if len(n.subscribers[userID]) == 0 {
+			delete(n.subscribers, userID)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Watch returns a channel that receives every match confirmed from now on
+// and a function to release the subscription.
+func (n *MatchNotifier) Watch() (<-chan *matchdomain.Match, func()) {
+	ch := make(chan *matchdomain.Match, watcherBufferSize)
+
+	n.mu.Lock()
+	n.watchers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	unsubscribe := func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.watchers, ch)
+	}
+	return ch, unsubscribe
+}
+
+// Notify delivers the match to every subscriber of the given user.
+// Subscribers that already have a pending match are skipped.
+//
+// Watchers are notified once per match, along with its requester.
+func (n *MatchNotifier) Notify(_ context.Context, userID string, match *matchdomain.Match) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers[userID] {
+		select {
+		case ch <- match:
+		default:
+		}
+	}
+
+	if match.Requester().ID() != userID {
+		return nil
+	}
+
+	for ch := range n.watchers {
+		select {
+		case ch <- match:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/xfrr/go-cqrsify/domain"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.MatchRepository = (*MatchRepository)(nil)
+
+// MatchRepository implements matchdomain.MatchRepository keeping the events of the matches in memory.
+// Every match found is restored from its events, so concurrent changes do not share it.
+type MatchRepository struct {
+	mu      sync.RWMutex
+	matches map[string][]domain.Event
+}
+
+// NewMatchRepository initializes an in-memory match repository.
+func NewMatchRepository() *MatchRepository {
+	return &MatchRepository{
+		matches: make(map[string][]domain.Event),
+	}
+}
+
+// Save appends the new events of the match, checking nobody saved the match since it was found.
+func (r *MatchRepository) Save(_ context.Context, match *matchdomain.Match) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history, exists := r.matches[match.ID()]
+	if len(history) != int(match.AggregateVersion()) {
+		if match.AggregateVersion() == 0 && exists {
+			return matchdomain.ErrMatchAlreadyExists
+		}
+		return matchdomain.ErrMatchVersionConflict
+	}
+
+	r.matches[match.ID()] = append(slices.Clip(history), match.AggregateEvents()...)
+	return nil
+}
+
+// FindByID retrieves a match by its ID.
+func (r *MatchRepository) FindByID(_ context.Context, id string) (*matchdomain.Match, error) {
+	r.mu.RLock()
+	history, ok := r.matches[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, matchdomain.ErrMatchNotFound
+	}
+	return matchdomain.NewMatchFromEvents(matchdomain.MatchID(id), history...)
+}
+
+// Exists checks if a match with the given ID exists.
+func (r *MatchRepository) Exists(_ context.Context, id string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.matches[id]
+	return ok, nil
+}
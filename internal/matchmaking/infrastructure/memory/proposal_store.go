@@ -0,0 +1,57 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.ProposalStore = (*ProposalStore)(nil)
+
+// ProposalStore implements matchdomain.ProposalStore keeping the proposals in memory.
+// The proposals are not shared between instances, so it is meant for tests and simulations.
+type ProposalStore struct {
+	mu        sync.Mutex
+	proposals map[string]time.Time
+}
+
+// NewProposalStore initializes an in-memory proposal store.
+func NewProposalStore() *ProposalStore {
+	return &ProposalStore{
+		proposals: make(map[string]time.Time),
+	}
+}
+
+// AddProposal stores the proposed match until the given expiration time.
+func (s *ProposalStore) AddProposal(_ context.Context, matchID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.proposals[matchID] = expiresAt
+	return nil
+}
+
+// RemoveProposal removes the proposed match.
+func (s *ProposalStore) RemoveProposal(_ context.Context, matchID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.proposals, matchID)
+	return nil
+}
+
+// ExpiredProposals returns the proposed matches expired at the given time.
+func (s *ProposalStore) ExpiredProposals(_ context.Context, at time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []string
+	for matchID, expiresAt := range s.proposals {
+		if !at.Before(expiresAt) {
+			expired = append(expired, matchID)
+		}
+	}
+	return expired, nil
+}
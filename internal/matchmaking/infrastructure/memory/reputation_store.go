@@ -0,0 +1,52 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.ReputationStore = (*ReputationStore)(nil)
+
+// ReputationStore implements matchdomain.ReputationStore keeping the reputations in memory.
+type ReputationStore struct {
+	mu          sync.RWMutex
+	reputations map[string]matchdomain.Reputation
+}
+
+// NewReputationStore initializes an in-memory reputation store.
+func NewReputationStore() *ReputationStore {
+	return &ReputationStore{
+		reputations: make(map[string]matchdomain.Reputation),
+	}
+}
+
+// AddRating implements matchdomain.ReputationStore.
+func (s *ReputationStore) AddRating(_ context.Context, rating matchdomain.Rating) error {
+	if err := rating.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reputation, ok := s.reputations[rating.RatedUserID]
+	if !ok {
+		reputation = matchdomain.NewReputation(rating.RatedUserID)
+	}
+	s.reputations[rating.RatedUserID], _ = reputation.Add(rating)
+	return nil
+}
+
+// Get implements matchdomain.ReputationStore.
+func (s *ReputationStore) Get(_ context.Context, userID string) (matchdomain.Reputation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reputation, ok := s.reputations[userID]
+	if !ok {
+		return matchdomain.NewReputation(userID), nil
+	}
+	return reputation, nil
+}
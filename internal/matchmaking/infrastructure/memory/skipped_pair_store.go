@@ -0,0 +1,64 @@
+package matchmakinginmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+var _ matchdomain.SkippedPairStore = (*SkippedPairStore)(nil)
+
+// SkippedPairStore implements matchdomain.SkippedPairStore keeping the pairs in memory.
+// Expired pairs are removed when they are looked up.
+// The pairs are lost on restart and not shared between instances, so it is meant for tests and simulations.
+type SkippedPairStore struct {
+	mu    sync.Mutex
+	pairs map[skippedPair]time.Time
+}
+
+type skippedPair struct {
+	a, b string
+}
+
+func newSkippedPair(userID, otherUserID string) skippedPair {
+	if userID > otherUserID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return skippedPair{a: userID, b: otherUserID}
+}
+
+// NewSkippedPairStore initializes an in-memory skipped pair store.
+func NewSkippedPairStore() *SkippedPairStore {
+	return &SkippedPairStore{
+		pairs: make(map[skippedPair]time.Time),
+	}
+}
+
+// AddPair stores the pair until the given expiration time.
+func (s *SkippedPairStore) AddPair(_ context.Context, userID, otherUserID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pairs[newSkippedPair(userID, otherUserID)] = expiresAt
+	return nil
+}
+
+// HasPair reports whether the pair is still cooling down at the given time.
+func (s *SkippedPairStore) HasPair(_ context.Context, userID, otherUserID string, at time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pair := newSkippedPair(userID, otherUserID)
+	expiresAt, ok := s.pairs[pair]
+	if !ok {
+		return false, nil
+	}
+
+	if !at.Before(expiresAt) {
+		delete(s.pairs, pair)
+		return false, nil
+	}
+	return true, nil
+}
@@ -6,29 +6,81 @@ import (
 
 	"github.com/rs/zerolog"
 	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
 var _ matchdomain.UserStore = (*UserStore)(nil)
 
 // UserStore implements matchdomain.UserStore using an in-memory concurrent implementation.
+// The users are also kept in secondary indexes by their index entries.
 type UserStore struct {
 	usersIndex sync.Map
 	logger     *zerolog.Logger
+
+	// mu guards the secondary indexes, kept in sync with usersIndex.
+	mu      sync.RWMutex
+	indexes map[string]map[string]struct{}
+	entries map[string][]string
 }
 
 // NewUserStore initializes an in-memory user store.
 func NewUserStore(logger *zerolog.Logger) *UserStore {
 	return &UserStore{
-		logger: logger,
+		logger:  logger,
+		indexes: make(map[string]map[string]struct{}),
+		entries: make(map[string][]string),
 	}
 }
 
 // AddUser adds a user to the in-memory store.
 func (us *UserStore) AddUser(_ context.Context, user matchdomain.User) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
 	us.usersIndex.Store(user.ID(), user)
+	us.unindex(user.ID())
+	us.index(user)
 	return nil
 }
 
+// FindCandidates retrieves the users having at least one index entry of every group of the
+// candidate query for the preferences.
+func (us *UserStore) FindCandidates(
+	ctx context.Context,
+	prefs matchmaking.Preferences,
+	origin *location.Location,
+) ([]*matchdomain.User, error) {
+	query := matchdomain.CandidateQuery(prefs, origin)
+	if len(query) == 0 {
+		return us.GetAll(ctx)
+	}
+
+	us.mu.RLock()
+	var candidateIDs map[string]struct{}
+	for _, group := range query {
+		groupIDs := make(map[string]struct{})
+		for _, entry := range group {
+			for userID := range us.indexes[entry] {
+				if _, ok := candidateIDs[userID]; candidateIDs == nil || ok {
+					groupIDs[userID] = struct{}{}
+				}
+			}
+		}
+		candidateIDs = groupIDs
+	}
+	us.mu.RUnlock()
+
+	users := make([]*matchdomain.User, 0, len(candidateIDs))
+	for userID := range candidateIDs {
+		if value, ok := us.usersIndex.Load(userID); ok {
+			u, _ := value.(matchdomain.User)
+			users = append(users, &u)
+		}
+	}
+	return users, nil
+}
+
 // FindByID finds a user by ID in the in-memory store.
 func (us *UserStore) FindByID(_ context.Context, userID string) (*matchdomain.User, error) {
 	if user, ok := us.usersIndex.Load(userID); ok {
@@ -49,12 +101,14 @@ func (us *UserStore) GetAll(_ context.Context) ([]*matchdomain.User, error) {
 	return users, nil
 }
 
-// FindCompatibleUser finds a compatible user in the in-memory store.
+// FindUserByPreferences finds a compatible user in the in-memory store.
 func (us *UserStore) FindUserByPreferences(_ context.Context, user *matchdomain.User) (*matchdomain.User, error) {
 	var compatibleUser matchdomain.User
 	us.usersIndex.Range(func(_, value interface{}) bool {
 		u, _ := value.(matchdomain.User)
-		if user.ID() != u.ID() && user.Preferences().IsSatisfiedBy(&u) {
+		if user.ID() != u.ID() &&
+			user.Preferences().IsSatisfiedBy(&u) &&
+			user.Preferences().IsWithinReach(user.Location(), u.Location()) {
 			compatibleUser = u
 			return false
 		}
@@ -69,19 +123,56 @@ func (us *UserStore) FindUserByPreferences(_ context.Context, user *matchdomain.
 	return &compatibleUser, nil
 }
 
-// RemoveUsers removes users from the in-memory store.
+// RemoveUsers removes users from the in-memory store, all of them or none.
 func (us *UserStore) RemoveUsers(ctx context.Context, userIDs ...string) error {
-	// check if users exist
+	_, err := us.ClaimUsers(ctx, userIDs...)
+	return err
+}
+
+// ClaimUsers atomically removes the users from the in-memory store and returns them.
+// When any of them is missing, the users already removed are put back.
+func (us *UserStore) ClaimUsers(_ context.Context, userIDs ...string) ([]*matchdomain.User, error) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	claimed := make([]*matchdomain.User, 0, len(userIDs))
 	for _, userID := range userIDs {
-		if _, err := us.FindByID(ctx, userID); err != nil {
-			return err
+		value, ok := us.usersIndex.LoadAndDelete(userID)
+		if !ok {
+			for _, user := range claimed {
+				if _, loaded := us.usersIndex.LoadOrStore(user.ID(), *user); !loaded {
+					us.index(*user)
+				}
+			}
+			return nil, matchdomain.ErrUserNotFound
 		}
+
+		u, _ := value.(matchdomain.User)
+		us.unindex(userID)
+		claimed = append(claimed, &u)
 	}
+	return claimed, nil
+}
 
-	// remove users
-	for _, userID := range userIDs {
-		us.usersIndex.Delete(userID)
+// index adds the user to the secondary indexes. It must be called with mu held.
+func (us *UserStore) index(user matchdomain.User) {
+	entries := user.IndexEntries()
+	for _, entry := range entries {
+		if us.indexes[entry] == nil {
+			us.indexes[entry] = make(map[string]struct{})
+		}
+		us.indexes[entry][user.ID()] = struct{}{}
 	}
+	us.entries[user.ID()] = entries
+}
 
-	return nil
+// unindex removes the user from the secondary indexes. It must be called with mu held.
+func (us *UserStore) unindex(userID string) {
+	for _, entry := range us.entries[userID] {
+		delete(us.indexes[entry], userID)
+		if len(us.indexes[entry]) == 0 {
+			delete(us.indexes, entry)
+		}
+	}
+	delete(us.entries, userID)
 }
@@ -0,0 +1,108 @@
+package matchmakingmetrics
+
+import (
+	"context"
+	"time"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "github.com/xfrr/randomtalk/internal/matchmaking"
+
+var _ matchdomain.MatchMetrics = (*OtelMatchMetrics)(nil)
+
+// OtelMatchMetrics implements matchdomain.MatchMetrics recording OpenTelemetry instruments,
+// labeled by matching mode.
+type OtelMatchMetrics struct {
+	matches       metric.Int64Counter
+	matchScore    metric.Float64Histogram
+	waitTime      metric.Float64Histogram
+	roundPoolSize metric.Int64Histogram
+	roundDuration metric.Float64Histogram
+}
+
+// NewOtelMatchMetrics creates the match metrics instruments from the given meter provider.
+func NewOtelMatchMetrics(provider metric.MeterProvider) (*OtelMatchMetrics, error) {
+	meter := provider.Meter(meterName)
+
+	matches, err := meter.Int64Counter("randomtalk.matchmaking.matches",
+		metric.WithDescription("Number of created matches."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	matchScore, err := meter.Float64Histogram("randomtalk.matchmaking.match.score",
+		metric.WithDescription("Mutual score of the matched users."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	waitTime, err := meter.Float64Histogram("randomtalk.matchmaking.match.wait_time",
+		metric.WithDescription("Time the matched users waited for their match."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roundPoolSize, err := meter.Int64Histogram("randomtalk.matchmaking.round.pool_size",
+		metric.WithDescription("Number of waiting users in a batch matching round."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roundDuration, err := meter.Float64Histogram("randomtalk.matchmaking.round.duration",
+		metric.WithDescription("Duration of a batch matching round."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OtelMatchMetrics{
+		matches:       matches,
+		matchScore:    matchScore,
+		waitTime:      waitTime,
+		roundPoolSize: roundPoolSize,
+		roundDuration: roundDuration,
+	}, nil
+}
+
+// RecordMatch records a created match, its score and the time each user waited for it.
+func (m *OtelMatchMetrics) RecordMatch(
+	ctx context.Context,
+	mode matchdomain.MatchingMode,
+	score float64,
+	waited ...time.Duration,
+) {
+	attrs := metric.WithAttributes(modeAttribute(mode))
+	m.matches.Add(ctx, 1, attrs)
+	m.matchScore.Record(ctx, score, attrs)
+	for _, w := range waited {
+		m.waitTime.Record(ctx, w.Seconds(), attrs)
+	}
+}
+
+// RecordRound records a batch matching round over the given pool size.
+func (m *OtelMatchMetrics) RecordRound(
+	ctx context.Context,
+	mode matchdomain.MatchingMode,
+	poolSize, matches int,
+	duration time.Duration,
+) {
+	attrs := metric.WithAttributes(
+		modeAttribute(mode),
+		attribute.Int("matches", matches),
+	)
+	m.roundPoolSize.Record(ctx, int64(poolSize), attrs)
+	m.roundDuration.Record(ctx, duration.Seconds(), attrs)
+}
+
+func modeAttribute(mode matchdomain.MatchingMode) attribute.KeyValue {
+	return attribute.String("matching_mode", mode.String())
+}
@@ -0,0 +1,111 @@
+package matchnats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+
+	"github.com/xfrr/randomtalk/internal/shared/eventstore"
+
+	matchdom "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+// ConfirmedMatchWatcher pushes to a notifications channel the matches confirmed by any
+// matchmaking instance: the matches created without a proposal, and the proposed matches
+// once both users accepted them.
+//
+// Only the matches confirmed while it watches are pushed.
+type ConfirmedMatchWatcher struct {
+	js            jetstream.JetStream
+	streamName    string
+	sourceName    string
+	matchRepo     matchdom.MatchRepository
+	notifications matchdom.NotificationsChannel
+	logger        *zerolog.Logger
+}
+
+// NewConfirmedMatchWatcher creates a new ConfirmedMatchWatcher over the given match events stream.
+func NewConfirmedMatchWatcher(
+	js jetstream.JetStream,
+	streamName string,
+	matchRepo matchdom.MatchRepository,
+	notifications matchdom.NotificationsChannel,
+	logger *zerolog.Logger,
+) *ConfirmedMatchWatcher {
+	return &ConfirmedMatchWatcher{
+		js:            js,
+		streamName:    streamName,
+		sourceName:    buildStreamSourceName(matchdom.EventSourceName, matchesStreamSuffix),
+		matchRepo:     matchRepo,
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+// Watch pushes the confirmed matches until the context is done.
+func (w *ConfirmedMatchWatcher) Watch(ctx context.Context) error {
+	consumer, err := w.js.OrderedConsumer(ctx, w.streamName, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{
+			createEventFilterKey(w.sourceName, "*", matchdom.MatchCreatedEvent{}.EventName()),
+			createEventFilterKey(w.sourceName, "*", matchdom.MatchConfirmedEvent{}.EventName()),
+		},
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("create confirmed matches consumer: %w", err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		if handleErr := w.handle(ctx, msg.Data()); handleErr != nil {
+			w.logger.Warn().
+				Err(handleErr).
+				Str("subject", msg.Subject()).
+				Msg("failed to push confirmed match")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("consume confirmed matches: %w", err)
+	}
+	defer consumeCtx.Stop()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (w *ConfirmedMatchWatcher) handle(ctx context.Context, data []byte) error {
+	ce := eventstore.NewEvent()
+	if err := ce.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("unmarshal match event: %w", err)
+	}
+
+	evt, err := eventFromCloudEvent(ce)
+	if err != nil {
+		return err
+	}
+
+	var match *matchdom.Match
+	switch e := evt.(type) {
+	case *matchdom.MatchCreatedEvent:
+		// the proposed matches are pushed once confirmed
+		if !e.ProposalExpiresAt.IsZero() {
+			return nil
+		}
+		match, err = matchdom.NewMatchFromEvents(matchdom.MatchID(e.MatchID), e)
+	case *matchdom.MatchConfirmedEvent:
+		match, err = w.matchRepo.FindByID(ctx, e.MatchID)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load match: %w", err)
+	}
+
+	for _, user := range match.Participants() {
+		if err = w.notifications.Notify(ctx, user.ID(), match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
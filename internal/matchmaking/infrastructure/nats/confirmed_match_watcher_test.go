@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package matchnats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchmakinginmemory "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+	matchnats "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/nats"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+	xnats "github.com/xfrr/randomtalk/internal/shared/nats"
+)
+
+func TestConfirmedMatchWatcher(t *testing.T) {
+	const streamName = "randomtalk_matchmaking_match_events_test"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	js := setupJetStream(t)
+	t.Cleanup(func() {
+		_ = js.DeleteStream(context.Background(), streamName)
+	})
+
+	repo, err := matchnats.NewMatchStreamRepository(ctx, js, xnats.NewStreamConfig(streamName, "randomtalk.matchmaking.matches.>"))
+	require.NoError(t, err)
+
+	notifier := matchmakinginmemory.NewMatchNotifier()
+	matches, unsubscribe := notifier.Subscribe("alice")
+	defer unsubscribe()
+
+	logger := zerolog.Nop()
+	watcher := matchnats.NewConfirmedMatchWatcher(js, streamName, repo, notifier, &logger)
+	go func() { _ = watcher.Watch(ctx) }()
+
+	require.Eventually(t, func() bool {
+		stream, err := js.Stream(ctx, streamName)
+		if err != nil {
+			return false
+		}
+		info, err := stream.Info(ctx)
+		return err == nil && info.State.Consumers == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	alice := *matchdomain.NewUser("alice", 25, gender.Female, matchmaking.DefaultPreferences())
+	bob := *matchdomain.NewUser("bob", 25, gender.Male, matchmaking.DefaultPreferences())
+
+	t.Run("should push the matches created without a proposal", func(t *testing.T) {
+		match, err := matchdomain.NewProposedMatch("match-1", alice, bob, time.Time{})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, match))
+
+		select {
+		case got := <-matches:
+			require.Equal(t, "match-1", got.ID())
+		case <-time.After(5 * time.Second):
+			t.Fatal("match not pushed")
+		}
+	})
+
+	t.Run("should push the proposed matches once confirmed", func(t *testing.T) {
+		match, err := matchdomain.NewProposedMatch("match-2", alice, bob, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, match))
+
+		select {
+		case <-matches:
+			t.Fatal("proposed match pushed")
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		match, err = repo.FindByID(ctx, "match-2")
+		require.NoError(t, err)
+		require.NoError(t, match.Accept("alice", time.Now()))
+		require.NoError(t, match.Accept("bob", time.Now()))
+		require.NoError(t, repo.Save(ctx, match))
+
+		select {
+		case got := <-matches:
+			require.Equal(t, "match-2", got.ID())
+			require.Equal(t, matchdomain.MatchConfirmed, got.Status())
+		case <-time.After(5 * time.Second):
+			t.Fatal("confirmed match not pushed")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package matchnats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+const (
+	matchHistoryBucketName = "randomtalk_matchmaking_match_history"
+	// maxMatchHistoryUpdateAttempts bounds the retries of the concurrent updates of the same history.
+	maxMatchHistoryUpdateAttempts = 5
+)
+
+var _ matchdomain.MatchHistory = (*MatchHistory)(nil)
+
+// MatchHistory implements matchdomain.MatchHistory using a NATS JetStream KeyValue bucket.
+// The history of every user is stored under "users.<user_id>", updated at the revision it was read.
+type MatchHistory struct {
+	kv         jetstream.KeyValue
+	maxEntries int
+}
+
+// NewMatchHistory creates a new MatchHistory keeping up to maxEntries matches per user.
+// A zero maxEntries keeps every match.
+func NewMatchHistory(ctx context.Context, js jetstream.JetStream, maxEntries int) (*MatchHistory, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  matchHistoryBucketName,
+		History: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MatchHistory{
+		kv:         kvstore,
+		maxEntries: maxEntries,
+	}, nil
+}
+
+// AddMatch implements matchdomain.MatchHistory.
+func (h *MatchHistory) AddMatch(ctx context.Context, userID string, entry matchdomain.MatchHistoryEntry) error {
+	for range maxMatchHistoryUpdateAttempts {
+		history, revision, err := h.get(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		history, added := history.Add(entry, h.maxEntries)
+		if !added {
+			return nil
+		}
+
+		body, err := json.Marshal(history)
+		if err != nil {
+			return fmt.Errorf("marshal match history: %w", err)
+		}
+
+		if revision == 0 {
+			_, err = h.kv.Create(ctx, matchHistoryKey(userID), body)
+		} else {
+			_, err = h.kv.Update(ctx, matchHistoryKey(userID), body, revision)
+		}
+		if isRevisionConflict(err) {
+			// the history changed since it was read
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("put match history: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("update match history of user %s: too many concurrent updates", userID)
+}
+
+// ListMatchesByUser implements matchdomain.MatchHistory.
+func (h *MatchHistory) ListMatchesByUser(
+	ctx context.Context,
+	userID, cursor string,
+	limit int,
+) (matchdomain.MatchHistoryPage, error) {
+	history, _, err := h.get(ctx, userID)
+	if err != nil {
+		return matchdomain.MatchHistoryPage{}, err
+	}
+	return history.Page(cursor, limit)
+}
+
+// GetLastMatch implements matchdomain.MatchHistory.
+func (h *MatchHistory) GetLastMatch(ctx context.Context, userID string) (matchdomain.MatchHistoryEntry, error) {
+	history, _, err := h.get(ctx, userID)
+	if err != nil {
+		return matchdomain.MatchHistoryEntry{}, err
+	}
+	return history.Last()
+}
+
+// get returns the history of the user and its revision, which is zero when the user has no history yet.
+func (h *MatchHistory) get(ctx context.Context, userID string) (matchdomain.UserMatchHistory, uint64, error) {
+	entry, err := h.kv.Get(ctx, matchHistoryKey(userID))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("get match history: %w", err)
+	}
+
+	var history matchdomain.UserMatchHistory
+	if err = json.Unmarshal(entry.Value(), &history); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal match history: %w", err)
+	}
+	return history, entry.Revision(), nil
+}
+
+// isRevisionConflict reports whether the key was written after it was read.
+func isRevisionConflict(err error) bool {
+	var apiErr *jetstream.APIError
+	return errors.Is(err, jetstream.ErrKeyExists) ||
+		(errors.As(err, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence)
+}
+
+func matchHistoryKey(userID string) string {
+	return "users." + userID
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/cloudevents/sdk-go/v2/types"
-	"github.com/google/uuid"
 	"github.com/nats-io/nats.go/jetstream"
 	"github.com/xfrr/go-cqrsify/domain"
 	"github.com/xfrr/randomtalk/internal/shared/eventstore"
@@ -44,20 +43,21 @@ func NewMatchStreamRepository(ctx context.Context, js jetstream.JetStream, strea
 	}, nil
 }
 
-// Save appends new events for a Match to the event store with optimistic concurrency checks.
+// Save appends the new events of a Match to the event store with optimistic concurrency checks.
+// The ID of every event is the match ID and its version, so JetStream rejects a second event
+// with the same version as a duplicate when the match was saved since it was found.
 func (r *MatchRepository) Save(ctx context.Context, match *matchdom.Match) error {
 	events, err := r.toCloudEvents(match.AggregateEvents())
 	if err != nil {
 		return fmt.Errorf("convert to cloud events: %w", err)
 	}
 
-	res, appendErr := r.stream.Append(ctx, events)
-	if appendErr != nil {
+	if _, appendErr := r.stream.Append(ctx, events); appendErr != nil {
 		if errors.Is(appendErr, eventstore.ErrSequenceMismatch) {
-			if res.LastSequence == 0 {
+			if match.AggregateVersion() == 0 {
 				return matchdom.ErrMatchAlreadyExists
 			}
-			return matchdom.ErrMatchAlreadyExists
+			return matchdom.ErrMatchVersionConflict
 		}
 		return fmt.Errorf("append match events: %w", appendErr)
 	}
@@ -100,49 +100,6 @@ func (r *MatchRepository) Exists(ctx context.Context, id string) (bool, error) {
 	return len(cloudEvents) > 0, nil
 }
 
-func (r *MatchRepository) FindLastByUserID(ctx context.Context, userID string) (*matchdom.Match, error) {
-	// 1. retrieve all match events
-	batchSize := 10
-	filter := createEventFilterKey(r.sourceName, matchdom.MatchAggregateName, "*", matchdom.MatchCreatedEvent{}.EventName())
-
-	// 2. iterate over events and filter by user ID
-	cloudEvents, err := r.stream.Fetch(ctx, batchSize, eventstore.FetchSubject(filter))
-	if err != nil {
-		if errors.Is(err, eventstore.ErrNoEventsFound) {
-			return nil, matchdom.ErrMatchNotFound
-		}
-
-		return nil, fmt.Errorf("fetch from stream: %w", err)
-	}
-
-	for cevents := range cloudEvents {
-		aggEvents, err := eventsFromCloudEvents(cevents)
-		if err != nil {
-			return nil, fmt.Errorf("convert from cloud events: %w", err)
-		}
-
-		if len(aggEvents) == 0 {
-			return nil, matchdom.ErrMatchNotFound
-		}
-
-		aggID, ok := aggEvents[0].AggregateRef().ID().(string)
-		if !ok {
-			return nil, errors.New("aggregate ID must be a string")
-		}
-
-		match, restoreErr := matchdom.NewMatchFromEvents(matchdom.MatchID(aggID), aggEvents...)
-		if restoreErr != nil {
-			return nil, fmt.Errorf("restore match from events: %w", restoreErr)
-		}
-
-		if match.Requester().ID() == userID || match.Candidate().ID() == userID {
-			return match, nil
-		}
-	}
-
-	return nil, matchdom.ErrMatchNotFound
-}
-
 func (r *MatchRepository) toCloudEvents(events []domain.Event) ([]eventstore.Event, error) {
 	cloudEvents := make([]eventstore.Event, 0, len(events))
 	for _, evt := range events {
@@ -151,16 +108,16 @@ func (r *MatchRepository) toCloudEvents(events []domain.Event) ([]eventstore.Eve
 			return nil, errors.New("aggregate ID must be a string")
 		}
 
-		eventID := uuid.New().String()
+		version := strconv.Itoa(int(evt.AggregateRef().Version()))
 		ce := eventstore.NewEvent()
-		ce.SetID(eventID)
+		ce.SetID(aggregateID + "." + version)
 		ce.SetType(evt.Name())
 		ce.SetSource(matchdom.EventSourceName)
 		ce.SetSubject(strings.Join([]string{"matches", aggregateID}, "."))
 		ce.SetTime(evt.Timestamp())
 		ce.SetDataSchema("schemas.randomtalk.com/matchmaking/match/events/" + evt.Name() + "/1.0")
 
-		if err := ce.Context.SetExtension(xnats.SubjectVersionHeaderKey, strconv.Itoa(int(evt.AggregateRef().Version()))); err != nil {
+		if err := ce.Context.SetExtension(xnats.SubjectVersionHeaderKey, version); err != nil {
 			return nil, fmt.Errorf("set extension: %w", err)
 		}
 		if dataErr := ce.SetData(string(eventstore.ContentTypeApplicationJSON), evt); dataErr != nil {
@@ -193,33 +150,49 @@ func eventFromCloudEvent(ce eventstore.Event) (domain.Event, error) {
 		return nil, fmt.Errorf("invalid event aggregate version: %w", err)
 	}
 
+	subjectSplit := strings.Split(ce.Subject(), ".")
+	if len(subjectSplit) < 2 {
+		return nil, errors.New("invalid subject format")
+	}
+	subjectID := subjectSplit[1]
+
+	baseEvent := domain.NewEvent(
+		ce.Type(),
+		domain.NewEventAggregateReference(
+			subjectID,
+			matchdom.MatchAggregateName,
+			domain.AggregateVersion(aggVersion),
+		),
+		domain.WithEventTimestamp(ce.Time()),
+	)
+
 	switch ce.Type() {
 	case matchdom.MatchCreatedEvent{}.EventName():
 		event := &matchdom.MatchCreatedEvent{}
-		if unmarshalErr := json.Unmarshal(ce.DataEncoded, event); unmarshalErr != nil {
-			return nil, fmt.Errorf("json unmarshal: %w", unmarshalErr)
+		if err = decodeMatchEvent(ce, subjectID, event, &event.MatchID); err != nil {
+			return nil, err
 		}
-
-		subjectSplit := strings.Split(ce.Subject(), ".")
-		if len(subjectSplit) < 2 {
-			return nil, errors.New("invalid subject format")
+		event.BaseEvent = baseEvent
+		return event, nil
+	case matchdom.MatchAcceptedEvent{}.EventName():
+		event := &matchdom.MatchAcceptedEvent{}
+		if err = decodeMatchEvent(ce, subjectID, event, &event.MatchID); err != nil {
+			return nil, err
 		}
-
-		subjectID := subjectSplit[1]
-		if event.MatchID != subjectID {
-			return nil, errors.New("subject ID and event payload ID mismatch")
+		event.BaseEvent = baseEvent
+		return event, nil
+	case matchdom.MatchConfirmedEvent{}.EventName():
+		event := &matchdom.MatchConfirmedEvent{}
+		if err = decodeMatchEvent(ce, subjectID, event, &event.MatchID); err != nil {
+			return nil, err
+		}
+		event.BaseEvent = baseEvent
+		return event, nil
+	case matchdom.MatchCancelledEvent{}.EventName():
+		event := &matchdom.MatchCancelledEvent{}
+		if err = decodeMatchEvent(ce, subjectID, event, &event.MatchID); err != nil {
+			return nil, err
 		}
-
-		baseEvent := domain.NewEvent(
-			ce.Type(),
-			domain.NewEventAggregateReference(
-				subjectID,
-				matchdom.MatchAggregateName,
-				domain.AggregateVersion(aggVersion),
-			),
-			domain.WithEventTimestamp(ce.Time()),
-		)
-
 		event.BaseEvent = baseEvent
 		return event, nil
 	default:
@@ -227,6 +200,18 @@ func eventFromCloudEvent(ce eventstore.Event) (domain.Event, error) {
 	}
 }
 
+// decodeMatchEvent unmarshals the event payload, checking it belongs to the match of the subject.
+func decodeMatchEvent(ce eventstore.Event, subjectID string, event any, matchID *string) error {
+	if unmarshalErr := json.Unmarshal(ce.DataEncoded, event); unmarshalErr != nil {
+		return fmt.Errorf("json unmarshal: %w", unmarshalErr)
+	}
+
+	if *matchID != subjectID {
+		return errors.New("subject ID and event payload ID mismatch")
+	}
+	return nil
+}
+
 func createEventFilterKey(sourceName, id string, eventType ...string) string {
 	base := sourceName + "." + id
 	if len(eventType) == 0 {
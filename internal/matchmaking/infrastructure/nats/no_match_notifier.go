@@ -0,0 +1,48 @@
+package matchnats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdom "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+// NoMatchFoundEventType is the CloudEvent type of the notifications sent
+// when a user wait deadline passes without a match.
+const NoMatchFoundEventType = "no_match_found"
+
+var _ matchdom.NoMatchNotifier = (*NoMatchNotifier)(nil)
+
+// NoMatchNotifier publishes the no match found notifications to the match events stream,
+// next to the created matches, so the chat service gets both from the same consumer.
+type NoMatchNotifier struct {
+	publisher notificationPublisher
+}
+
+// NewNoMatchNotifier creates a new NoMatchNotifier publishing to the given stream.
+func NewNoMatchNotifier(streamName string, js jetstream.JetStream) *NoMatchNotifier {
+	return &NoMatchNotifier{
+		publisher: notificationPublisher{
+			streamName:    streamName,
+			subjectPrefix: matchesStreamSuffix + ".requests",
+			js:            js,
+		},
+	}
+}
+
+type noMatchFoundData struct {
+	UserID        string    `json:"user_id"`
+	RequestedAt   time.Time `json:"requested_at"`
+	WaitedSeconds int64     `json:"waited_seconds"`
+}
+
+// NotifyNoMatchFound implements matchdom.NoMatchNotifier.
+func (n *NoMatchNotifier) NotifyNoMatchFound(ctx context.Context, user matchdom.User, waited time.Duration) error {
+	return n.publisher.publish(ctx, NoMatchFoundEventType, user.ID(), noMatchFoundData{
+		UserID:        user.ID(),
+		RequestedAt:   user.RequestedAt(),
+		WaitedSeconds: int64(waited.Seconds()),
+	})
+}
@@ -0,0 +1,55 @@
+package matchnats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdom "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/eventstore"
+)
+
+// notificationPublisher publishes the notifications sent to a user as CloudEvents,
+// on "<source>.<subject_prefix>.<user_id>.<event_type>" of the given stream.
+type notificationPublisher struct {
+	streamName    string
+	subjectPrefix string
+	js            jetstream.JetStream
+}
+
+func (p notificationPublisher) publish(ctx context.Context, eventType, userID string, data any) error {
+	eventID := uuid.New().String()
+
+	ce := eventstore.NewEvent()
+	ce.SetID(eventID)
+	ce.SetType(eventType)
+	ce.SetSource(matchdom.EventSourceName)
+	ce.SetSubject(p.subjectPrefix + "." + userID)
+	ce.SetTime(time.Now().UTC())
+	ce.SetDataSchema("schemas.randomtalk.com/matchmaking/notifications/" + eventType + "/1.0")
+	if err := ce.SetData(string(eventstore.ContentTypeApplicationJSON), data); err != nil {
+		return fmt.Errorf("set event data: %w", err)
+	}
+
+	body, err := ce.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshal %s cloudevent: %w", eventType, err)
+	}
+
+	_, err = p.js.PublishMsg(ctx, &nats.Msg{
+		Subject: strings.Join([]string{ce.Source(), ce.Subject(), ce.Type()}, "."),
+		Data:    body,
+	},
+		jetstream.WithExpectStream(p.streamName),
+		jetstream.WithMsgID(eventID),
+	)
+	if err != nil {
+		return fmt.Errorf("publish %s notification: %w", eventType, err)
+	}
+	return nil
+}
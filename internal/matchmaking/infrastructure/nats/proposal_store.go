@@ -0,0 +1,98 @@
+package matchnats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+const proposalBucketName = "randomtalk_matchmaking_proposals"
+
+var _ matchdomain.ProposalStore = (*ProposalStore)(nil)
+
+// ProposalStore implements matchdomain.ProposalStore using a NATS JetStream KeyValue bucket,
+// so every instance expires the proposals made by the others.
+// The expiration time of every proposal is stored under "matches.<match_id>".
+type ProposalStore struct {
+	kv jetstream.KeyValue
+}
+
+// NewProposalStore creates a new ProposalStore. The bucket TTL drops the proposals
+// left behind long after they expired, which must be longer than the proposal timeout.
+func NewProposalStore(ctx context.Context, js jetstream.JetStream, ttl time.Duration) (*ProposalStore, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  proposalBucketName,
+		History: 1,
+		TTL:     ttl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProposalStore{kv: kvstore}, nil
+}
+
+// AddProposal implements matchdomain.ProposalStore.
+func (s *ProposalStore) AddProposal(ctx context.Context, matchID string, expiresAt time.Time) error {
+	body, err := expiresAt.MarshalText()
+	if err != nil {
+		return fmt.Errorf("marshal proposal: %w", err)
+	}
+
+	if _, err = s.kv.Put(ctx, proposalKey(matchID), body); err != nil {
+		return fmt.Errorf("put proposal: %w", err)
+	}
+	return nil
+}
+
+// RemoveProposal implements matchdomain.ProposalStore.
+func (s *ProposalStore) RemoveProposal(ctx context.Context, matchID string) error {
+	err := s.kv.Delete(ctx, proposalKey(matchID))
+	if err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("delete proposal: %w", err)
+	}
+	return nil
+}
+
+// ExpiredProposals implements matchdomain.ProposalStore.
+func (s *ProposalStore) ExpiredProposals(ctx context.Context, at time.Time) ([]string, error) {
+	keys, err := s.kv.ListKeys(ctx)
+	if errors.Is(err, jetstream.ErrNoKeysFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list proposals: %w", err)
+	}
+	defer func() { _ = keys.Stop() }()
+
+	var expired []string
+	for key := range keys.Keys() {
+		entry, err := s.kv.Get(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// resolved while listing
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get proposal: %w", err)
+		}
+
+		var expiresAt time.Time
+		if err = expiresAt.UnmarshalText(entry.Value()); err != nil {
+			return nil, fmt.Errorf("unmarshal proposal: %w", err)
+		}
+		if !at.Before(expiresAt) {
+			expired = append(expired, strings.TrimPrefix(key, "matches."))
+		}
+	}
+	return expired, nil
+}
+
+func proposalKey(matchID string) string {
+	return "matches." + matchID
+}
@@ -0,0 +1,49 @@
+package matchnats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdom "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+// QueueStatusEventType is the CloudEvent type of the notifications sent
+// periodically to the users waiting for a match.
+const QueueStatusEventType = "queue_status"
+
+var _ matchdom.QueueStatusNotifier = (*QueueStatusNotifier)(nil)
+
+// QueueStatusNotifier publishes the queue status notifications to the queue statuses stream,
+// which only keeps the last status of every user for a few seconds.
+type QueueStatusNotifier struct {
+	publisher notificationPublisher
+}
+
+// NewQueueStatusNotifier creates a new QueueStatusNotifier publishing to the queue statuses stream.
+func NewQueueStatusNotifier(js jetstream.JetStream) *QueueStatusNotifier {
+	return &QueueStatusNotifier{
+		publisher: notificationPublisher{
+			streamName:    QueueStatusesStreamName,
+			subjectPrefix: queueStatusesSubjectPrefix,
+			js:            js,
+		},
+	}
+}
+
+type queueStatusData struct {
+	UserID               string `json:"user_id"`
+	CompatibleUsers      int    `json:"compatible_users"`
+	Position             int    `json:"position"`
+	EstimatedWaitSeconds int64  `json:"estimated_wait_seconds"`
+}
+
+// NotifyQueueStatus implements matchdom.QueueStatusNotifier.
+func (n *QueueStatusNotifier) NotifyQueueStatus(ctx context.Context, status matchdom.QueueStatus) error {
+	return n.publisher.publish(ctx, QueueStatusEventType, status.UserID, queueStatusData{
+		UserID:               status.UserID,
+		CompatibleUsers:      status.CompatibleUsers,
+		Position:             status.Position,
+		EstimatedWaitSeconds: int64(status.EstimatedWait.Seconds()),
+	})
+}
@@ -0,0 +1,105 @@
+package matchnats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+const (
+	reputationBucketName = "randomtalk_matchmaking_reputations"
+	// maxReputationUpdateAttempts bounds the retries of the concurrent ratings of the same user.
+	maxReputationUpdateAttempts = 5
+)
+
+var _ matchdomain.ReputationStore = (*ReputationStore)(nil)
+
+// ReputationStore implements matchdomain.ReputationStore using a NATS JetStream KeyValue bucket.
+// The reputation of every user is stored under "users.<user_id>", updated at the revision it was read.
+type ReputationStore struct {
+	kv jetstream.KeyValue
+}
+
+// NewReputationStore creates a new ReputationStore.
+func NewReputationStore(ctx context.Context, js jetstream.JetStream) (*ReputationStore, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  reputationBucketName,
+		History: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReputationStore{kv: kvstore}, nil
+}
+
+// AddRating implements matchdomain.ReputationStore.
+func (s *ReputationStore) AddRating(ctx context.Context, rating matchdomain.Rating) error {
+	if err := rating.Validate(); err != nil {
+		return err
+	}
+
+	for range maxReputationUpdateAttempts {
+		reputation, revision, err := s.get(ctx, rating.RatedUserID)
+		if err != nil {
+			return err
+		}
+
+		reputation, added := reputation.Add(rating)
+		if !added {
+			return nil
+		}
+
+		body, err := json.Marshal(reputation)
+		if err != nil {
+			return fmt.Errorf("marshal reputation: %w", err)
+		}
+
+		if revision == 0 {
+			_, err = s.kv.Create(ctx, reputationKey(rating.RatedUserID), body)
+		} else {
+			_, err = s.kv.Update(ctx, reputationKey(rating.RatedUserID), body, revision)
+		}
+		if isRevisionConflict(err) {
+			// the reputation changed since it was read
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("put reputation: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("update reputation of user %s: too many concurrent updates", rating.RatedUserID)
+}
+
+// Get implements matchdomain.ReputationStore.
+func (s *ReputationStore) Get(ctx context.Context, userID string) (matchdomain.Reputation, error) {
+	reputation, _, err := s.get(ctx, userID)
+	return reputation, err
+}
+
+// get returns the reputation of the user and its revision, which is zero when nobody rated the user yet.
+func (s *ReputationStore) get(ctx context.Context, userID string) (matchdomain.Reputation, uint64, error) {
+	entry, err := s.kv.Get(ctx, reputationKey(userID))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return matchdomain.NewReputation(userID), 0, nil
+	}
+	if err != nil {
+		return matchdomain.Reputation{}, 0, fmt.Errorf("get reputation: %w", err)
+	}
+
+	var reputation matchdomain.Reputation
+	if err = json.Unmarshal(entry.Value(), &reputation); err != nil {
+		return matchdomain.Reputation{}, 0, fmt.Errorf("unmarshal reputation: %w", err)
+	}
+	return reputation, entry.Revision(), nil
+}
+
+func reputationKey(userID string) string {
+	return "users." + userID
+}
@@ -23,3 +23,27 @@ func CreateMatchmakingUserMatchRequestsStream(ctx context.Context, js jetstream.
 
 	return nil
 }
+
+// QueueStatusesStreamName is the stream of the queue status notifications.
+const QueueStatusesStreamName = "randomtalk_matchmaking_queue_statuses"
+
+const queueStatusesSubjectPrefix = "queue_statuses"
+
+// CreateMatchmakingQueueStatusesStream creates a JetStream stream for the queue status notifications.
+// The statuses are sent again every few seconds, so only the last one of every user is kept, shortly.
+func CreateMatchmakingQueueStatusesStream(ctx context.Context, js jetstream.JetStream) error {
+	_, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:              QueueStatusesStreamName,
+		Subjects:          []string{"randomtalk.matchmaking." + queueStatusesSubjectPrefix + ".>"},
+		Storage:           jetstream.MemoryStorage,
+		Discard:           jetstream.DiscardOld,
+		MaxAge:            5 * time.Second,
+		MaxMsgsPerSubject: 1,
+		MaxMsgSize:        1024, // 1KB
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
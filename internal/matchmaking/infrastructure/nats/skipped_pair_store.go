@@ -0,0 +1,75 @@
+package matchnats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+const skippedPairBucketName = "randomtalk_matchmaking_skipped_pairs"
+
+var _ matchdomain.SkippedPairStore = (*SkippedPairStore)(nil)
+
+// SkippedPairStore implements matchdomain.SkippedPairStore using a NATS JetStream KeyValue bucket.
+// Every pair is stored under "pairs.<user_id>.<user_id>", with the ids sorted so the key is unordered,
+// and the bucket TTL drops the pairs once the cooldown passes.
+type SkippedPairStore struct {
+	kv jetstream.KeyValue
+}
+
+// NewSkippedPairStore creates a new SkippedPairStore keeping the pairs for the given cooldown.
+func NewSkippedPairStore(ctx context.Context, js jetstream.JetStream, cooldown time.Duration) (*SkippedPairStore, error) {
+	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  skippedPairBucketName,
+		History: 1,
+		TTL:     cooldown,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SkippedPairStore{kv: kvstore}, nil
+}
+
+// AddPair implements matchdomain.SkippedPairStore.
+func (s *SkippedPairStore) AddPair(ctx context.Context, userID, otherUserID string, expiresAt time.Time) error {
+	body, err := expiresAt.MarshalText()
+	if err != nil {
+		return fmt.Errorf("marshal skipped pair: %w", err)
+	}
+
+	if _, err = s.kv.Put(ctx, skippedPairKey(userID, otherUserID), body); err != nil {
+		return fmt.Errorf("put skipped pair: %w", err)
+	}
+	return nil
+}
+
+// HasPair implements matchdomain.SkippedPairStore.
+// The expiration time is checked too, as the bucket TTL only bounds how long the pairs are kept.
+func (s *SkippedPairStore) HasPair(ctx context.Context, userID, otherUserID string, at time.Time) (bool, error) {
+	entry, err := s.kv.Get(ctx, skippedPairKey(userID, otherUserID))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get skipped pair: %w", err)
+	}
+
+	var expiresAt time.Time
+	if err = expiresAt.UnmarshalText(entry.Value()); err != nil {
+		return false, fmt.Errorf("unmarshal skipped pair: %w", err)
+	}
+	return at.Before(expiresAt), nil
+}
+
+func skippedPairKey(userID, otherUserID string) string {
+	if userID > otherUserID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return "pairs." + userID + "." + otherUserID
+}
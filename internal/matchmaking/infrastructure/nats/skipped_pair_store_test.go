@@ -0,0 +1,45 @@
+//go:build integration
+// +build integration
+
+package matchnats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	matchnats "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/nats"
+)
+
+func TestSkippedPairStore(t *testing.T) {
+	ctx := context.Background()
+	js := setupJetStream(t)
+
+	store, err := matchnats.NewSkippedPairStore(ctx, js, time.Second)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, store.AddPair(ctx, "user-id-2", "user-id-1", now.Add(time.Second)))
+
+	t.Run("should find the pair in any order while cooling down", func(t *testing.T) {
+		skipped, err := store.HasPair(ctx, "user-id-1", "user-id-2", now)
+		require.NoError(t, err)
+		require.True(t, skipped)
+
+		skipped, err = store.HasPair(ctx, "user-id-2", "user-id-1", now)
+		require.NoError(t, err)
+		require.True(t, skipped)
+	})
+
+	t.Run("should forget the pair once the cooldown expires", func(t *testing.T) {
+		skipped, err := store.HasPair(ctx, "user-id-1", "user-id-2", now.Add(time.Second))
+		require.NoError(t, err)
+		require.False(t, skipped)
+
+		require.Eventually(t, func() bool {
+			skipped, err := store.HasPair(ctx, "user-id-1", "user-id-2", now)
+			return err == nil && !skipped
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
@@ -2,20 +2,26 @@ package matchnats
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go/jetstream"
 	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
 var _ matchdomain.UserStore = &UserStore{}
 
 // UserStore is the nats implementation of the UserStore interface
+// The secondary indexes are kept in a second bucket, as "<index entry>.<encoded user id>" keys.
 type UserStore struct {
-	js jetstream.JetStream
-	kv jetstream.KeyValue
+	js      jetstream.JetStream
+	kv      jetstream.KeyValue
+	indexKV jetstream.KeyValue
 }
 
 // AddUser implements matchdomain.UserStore.
@@ -26,13 +32,135 @@ func (u *UserStore) AddUser(ctx context.Context, user matchdomain.User) error {
 		return err
 	}
 
-	// 2. upsert the user
+	// 2. drop the index entries of the previous version of the user, if any
+	previous, err := u.kv.Get(ctx, user.ID())
+	if err == nil && previous.Operation() == jetstream.KeyValuePut {
+		var previousUser matchdomain.User
+		if previousUser.UnmarshalJSON(previous.Value()) == nil {
+			u.unindex(ctx, previousUser)
+		}
+	}
+
+	// 3. upsert the user
 	_, err = u.kv.Put(ctx, user.ID(), body)
 	if err != nil {
 		return err
 	}
 
-	return err
+	// 4. index the user
+	return u.index(ctx, user)
+}
+
+// FindCandidates implements matchdomain.UserStore.
+// The ids of the candidates are listed from the index bucket, and only those users are loaded.
+func (u *UserStore) FindCandidates(
+	ctx context.Context,
+	prefs matchmaking.Preferences,
+	origin *location.Location,
+) ([]*matchdomain.User, error) {
+	query := matchdomain.CandidateQuery(prefs, origin)
+	if len(query) == 0 {
+		return u.GetAll(ctx)
+	}
+
+	var candidateIDs map[string]struct{}
+	for _, group := range query {
+		groupIDs, err := u.findIndexed(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		if candidateIDs != nil {
+			for userID := range groupIDs {
+				if _, ok := candidateIDs[userID]; !ok {
+					delete(groupIDs, userID)
+				}
+			}
+		}
+		candidateIDs = groupIDs
+		if len(candidateIDs) == 0 {
+			return []*matchdomain.User{}, nil
+		}
+	}
+
+	users := make([]*matchdomain.User, 0, len(candidateIDs))
+	for userID := range candidateIDs {
+		entry, err := u.kv.Get(ctx, userID)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// stale index entry
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var user matchdomain.User
+		if err = user.UnmarshalJSON(entry.Value()); err != nil {
+			continue
+		}
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
+// findIndexed returns the ids of the users having any of the index entries.
+func (u *UserStore) findIndexed(ctx context.Context, entries []string) (map[string]struct{}, error) {
+	userIDs := make(map[string]struct{})
+	if len(entries) == 0 {
+		return userIDs, nil
+	}
+
+	filters := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		filters = append(filters, entry+".>")
+	}
+
+	lister, err := u.indexKV.ListKeysFiltered(ctx, filters...)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoKeysFound) {
+			return userIDs, nil
+		}
+		return nil, fmt.Errorf("failed to list index keys: %w", err)
+	}
+	defer func() { _ = lister.Stop() }()
+
+	for key := range lister.Keys() {
+		// index entries are made of two tokens, followed by the encoded user id
+		parts := strings.SplitN(key, ".", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		userID, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			continue
+		}
+		userIDs[string(userID)] = struct{}{}
+	}
+	return userIDs, nil
+}
+
+// index puts the index entries of the user.
+func (u *UserStore) index(ctx context.Context, user matchdomain.User) error {
+	for _, entry := range user.IndexEntries() {
+		if _, err := u.indexKV.Put(ctx, indexKey(entry, user.ID()), nil); err != nil {
+			return fmt.Errorf("failed to index user %s: %w", user.ID(), err)
+		}
+	}
+	return nil
+}
+
+// unindex deletes the index entries of the user. Stale entries are skipped
+// when the candidates are loaded, so failures are ignored.
+func (u *UserStore) unindex(ctx context.Context, user matchdomain.User) {
+	for _, entry := range user.IndexEntries() {
+		_ = u.indexKV.Purge(ctx, indexKey(entry, user.ID()))
+	}
+}
+
+// indexKey returns the index key of the user for the entry. The user id is encoded
+// as a single token, since it may contain dots or other characters not allowed in keys.
+func indexKey(entry, userID string) string {
+	return entry + "." + base64.RawURLEncoding.EncodeToString([]byte(userID))
 }
 
 // GetAll implements matchdomain.UserStore.
@@ -73,47 +201,115 @@ func (u *UserStore) GetAll(ctx context.Context) ([]*matchdomain.User, error) {
 }
 
 // RemoveUsers implements matchdomain.UserStore.
+// The users are claimed, so either all of them are removed or none.
 func (u *UserStore) RemoveUsers(ctx context.Context, userID ...string) error {
-	revisions := make([]uint64, 0, len(userID))
-	for _, id := range userID {
-		user, err := u.kv.Get(ctx, id)
+	_, err := u.ClaimUsers(ctx, userID...)
+	return err
+}
+
+// ClaimUsers implements matchdomain.UserStore.
+// Every user is deleted only if its revision did not change since it was read, so
+// concurrent matchmakers cannot claim the same user. When any of the users cannot be
+// claimed, the ones already deleted are restored.
+func (u *UserStore) ClaimUsers(ctx context.Context, userIDs ...string) ([]*matchdomain.User, error) {
+	claimed := make([]jetstream.KeyValueEntry, 0, len(userIDs))
+	for _, id := range userIDs {
+		entry, err := u.claim(ctx, id)
 		if err != nil {
-			switch {
-			case errors.Is(err, jetstream.ErrKeyNotFound):
-				return matchdomain.ErrUserNotFound
-			default:
-				return err
-			}
+			return nil, errors.Join(err, u.restore(ctx, claimed))
 		}
+		claimed = append(claimed, entry)
+	}
 
-		revisions = append(revisions, user.Revision())
+	users := make([]*matchdomain.User, 0, len(claimed))
+	for _, entry := range claimed {
+		var user matchdomain.User
+		if err := user.UnmarshalJSON(entry.Value()); err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("failed to unmarshal claimed user %s: %w", entry.Key(), err),
+				u.restore(ctx, claimed),
+			)
+		}
+		users = append(users, &user)
 	}
 
-	for i, id := range userID {
-		rev := revisions[i]
-		err := u.kv.Delete(ctx, id, jetstream.LastRevision(rev))
-		if err != nil {
-			return err
+	for _, user := range users {
+		u.unindex(ctx, *user)
+	}
+	return users, nil
+}
+
+// claim deletes the user at the revision it was read.
+func (u *UserStore) claim(ctx context.Context, id string) (jetstream.KeyValueEntry, error) {
+	entry, err := u.kv.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, jetstream.ErrKeyNotFound):
+			return nil, matchdomain.ErrUserNotFound
+		default:
+			return nil, err
 		}
 	}
 
-	return nil
+	err = u.kv.Delete(ctx, id, jetstream.LastRevision(entry.Revision()))
+	if err != nil {
+		var apiErr *jetstream.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence {
+			return nil, matchdomain.ErrUserAlreadyClaimed
+		}
+		return nil, err
+	}
+	return entry, nil
 }
 
-// NewUserStore creates a new UserStore
-func NewUserStore(ctx context.Context, js jetstream.JetStream) (*UserStore, error) {
+// restore puts back and indexes the claimed users, unless they were added again in the meantime.
+func (u *UserStore) restore(ctx context.Context, claimed []jetstream.KeyValueEntry) error {
+	var errs []error
+	for _, entry := range claimed {
+		_, err := u.kv.Create(ctx, entry.Key(), entry.Value())
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore claimed user %s: %w", entry.Key(), err))
+			continue
+		}
+
+		// the user may have been unindexed already
+		var user matchdomain.User
+		if user.UnmarshalJSON(entry.Value()) == nil {
+			errs = append(errs, u.index(ctx, user))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewUserStore creates a new UserStore.
+// The ttl only evicts the users left behind, the matchmaker releases the users
+// once their wait deadline passes. A zero ttl keeps the users until removed.
+func NewUserStore(ctx context.Context, js jetstream.JetStream, ttl time.Duration) (*UserStore, error) {
 	// create a new kv store
 	kvstore, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
 		Bucket:  "randomtalk_matchmaking_user_store",
 		History: 1,
-		TTL:     1 * time.Minute,
+		TTL:     ttl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	indexKV, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  "randomtalk_matchmaking_user_index",
+		History: 1,
+		TTL:     ttl,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &UserStore{
-		js: js,
-		kv: kvstore,
+		js:      js,
+		kv:      kvstore,
+		indexKV: indexKV,
 	}, nil
 }
@@ -5,6 +5,9 @@ package matchnats_test
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,7 +24,7 @@ import (
 func TestUserStore_AddUser(t *testing.T) {
 	ctx := context.Background()
 	js := setupJetStream(t)
-	store, err := matchnats.NewUserStore(ctx, js)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
 	require.NoError(t, err)
 
 	user := matchdomain.NewUser("user-id-1", 25, gender.Unspecified, matchmaking.DefaultPreferences())
@@ -38,7 +41,7 @@ func TestUserStore_AddUser(t *testing.T) {
 func TestUserStore_GetAll(t *testing.T) {
 	ctx := context.Background()
 	js := setupJetStream(t)
-	store, err := matchnats.NewUserStore(ctx, js)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
 	require.NoError(t, err)
 
 	user1 := matchdomain.NewUser("user-id-1", 25, gender.Unspecified, matchmaking.DefaultPreferences())
@@ -57,7 +60,7 @@ func TestUserStore_GetAll(t *testing.T) {
 func TestUserStore_RemoveUsers(t *testing.T) {
 	ctx := context.Background()
 	js := setupJetStream(t)
-	store, err := matchnats.NewUserStore(ctx, js)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
 	require.NoError(t, err)
 
 	user := matchdomain.NewUser("user-id-1", 25, gender.Unspecified, matchmaking.DefaultPreferences())
@@ -72,6 +75,86 @@ func TestUserStore_RemoveUsers(t *testing.T) {
 	assert.Empty(t, users)
 }
 
+func TestUserStore_ClaimUsers(t *testing.T) {
+	ctx := context.Background()
+	js := setupJetStream(t)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
+	require.NoError(t, err)
+
+	alice := matchdomain.NewUser("alice", 25, gender.Unspecified, matchmaking.DefaultPreferences())
+	bob := matchdomain.NewUser("bob", 25, gender.Unspecified, matchmaking.DefaultPreferences())
+	require.NoError(t, store.AddUser(ctx, *alice))
+	require.NoError(t, store.AddUser(ctx, *bob))
+
+	// only one of the concurrent claims of alice succeeds
+	var wg sync.WaitGroup
+	var claims atomic.Int32
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, claimErr := store.ClaimUsers(ctx, alice.ID()); claimErr == nil {
+				claims.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), claims.Load())
+
+	// bob is restored when alice cannot be claimed
+	_, err = store.ClaimUsers(ctx, bob.ID(), alice.ID())
+	require.ErrorIs(t, err, matchdomain.ErrUserNotFound)
+
+	users, err := store.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, bob.ID(), users[0].ID())
+}
+
+func TestUserStore_FindCandidates(t *testing.T) {
+	ctx := context.Background()
+	js := setupJetStream(t)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
+	require.NoError(t, err)
+
+	alice := matchdomain.NewUser("alice", 25, gender.Female, matchmaking.DefaultPreferences())
+	bob := matchdomain.NewUser("bob", 25, gender.Male, matchmaking.DefaultPreferences())
+	carol := matchdomain.NewUser("carol", 60, gender.Female, matchmaking.DefaultPreferences())
+	for _, user := range []*matchdomain.User{alice, bob, carol} {
+		require.NoError(t, store.AddUser(ctx, *user))
+	}
+
+	prefs := matchmaking.DefaultPreferences().WithGender(gender.Female).WithMinAge(20).WithMaxAge(30)
+	users, err := store.FindCandidates(ctx, prefs, nil)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, alice.ID(), users[0].ID())
+
+	// claimed users are no longer candidates
+	_, err = store.ClaimUsers(ctx, alice.ID())
+	require.NoError(t, err)
+	users, err = store.FindCandidates(ctx, prefs, nil)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestUserStore_FindCandidates_DottedUserID(t *testing.T) {
+	ctx := context.Background()
+	js := setupJetStream(t)
+	store, err := matchnats.NewUserStore(ctx, js, time.Minute)
+	require.NoError(t, err)
+
+	// user ids come from the subject of the tokens, which may contain dots
+	user := matchdomain.NewUser("alice.smith", 25, gender.Female, matchmaking.DefaultPreferences())
+	require.NoError(t, store.AddUser(ctx, *user))
+
+	prefs := matchmaking.DefaultPreferences().WithGender(gender.Female)
+	users, err := store.FindCandidates(ctx, prefs, nil)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, user.ID(), users[0].ID())
+}
+
 func setupJetStream(t *testing.T) jetstream.JetStream {
 	t.Helper()
 
@@ -85,8 +168,16 @@ func setupJetStream(t *testing.T) jetstream.JetStream {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		err = js.DeleteKeyValue(ctx, "randomtalk_matchmaking_user_store")
-		require.NoError(t, err)
+		for _, bucket := range []string{
+			"randomtalk_matchmaking_user_store",
+			"randomtalk_matchmaking_user_index",
+			"randomtalk_matchmaking_skipped_pairs",
+		} {
+			err := js.DeleteKeyValue(ctx, bucket)
+			if !errors.Is(err, jetstream.ErrBucketNotFound) {
+				require.NoError(t, err)
+			}
+		}
 
 		nc.Close()
 	})
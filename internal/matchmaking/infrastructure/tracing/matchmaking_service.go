@@ -50,3 +50,102 @@ func (s *TraceableMatchmakingService) ProcessMatchRequest(ctx context.Context, u
 
 	return err
 }
+
+// SkipPair prevents the users from being matched again during the skip cooldown.
+func (s *TraceableMatchmakingService) SkipPair(ctx context.Context, userID, skippedUserID string) error {
+	ctx, span := s.tracer.Start(
+		ctx, "SkipPair",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(time.Now()),
+		trace.WithAttributes(
+			attribute.String("user_id", userID),
+			attribute.String("skipped_user_id", skippedUserID),
+		))
+	defer span.End()
+
+	err := s.service.SkipPair(ctx, userID, skippedUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// RespondToMatch records whether the user accepts the proposed match.
+func (s *TraceableMatchmakingService) RespondToMatch(ctx context.Context, matchID, userID string, accepted bool) error {
+	ctx, span := s.tracer.Start(
+		ctx, "RespondToMatch",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(time.Now()),
+		trace.WithAttributes(
+			attribute.String("match_id", matchID),
+			attribute.String("user_id", userID),
+			attribute.Bool("accepted", accepted),
+		))
+	defer span.End()
+
+	err := s.service.RespondToMatch(ctx, matchID, userID, accepted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// CancelMatchRequest removes the user from the waiting users.
+func (s *TraceableMatchmakingService) CancelMatchRequest(ctx context.Context, userID string) error {
+	ctx, span := s.tracer.Start(
+		ctx, "CancelMatchRequest",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(time.Now()),
+		trace.WithAttributes(
+			attribute.String("user_id", userID),
+		))
+	defer span.End()
+
+	err := s.service.CancelMatchRequest(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// ProcessWaitingUsers releases the users whose wait deadline passed and retries matching the rest.
+func (s *TraceableMatchmakingService) ProcessWaitingUsers(ctx context.Context) error {
+	ctx, span := s.tracer.Start(
+		ctx, "ProcessWaitingUsers",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithTimestamp(time.Now()),
+	)
+	defer span.End()
+
+	err := s.service.ProcessWaitingUsers(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// NotifyQueueStatus tells every waiting user its queue status.
+func (s *TraceableMatchmakingService) NotifyQueueStatus(ctx context.Context) error {
+	ctx, span := s.tracer.Start(
+		ctx, "NotifyQueueStatus",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithTimestamp(time.Now()),
+	)
+	defer span.End()
+
+	err := s.service.NotifyQueueStatus(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
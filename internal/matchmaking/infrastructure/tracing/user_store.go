@@ -4,6 +4,8 @@ import (
 	"context"
 
 	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -55,6 +57,38 @@ func (us *TraceableUserStore) GetAll(ctx context.Context) ([]*matchdomain.User,
 	return users, err
 }
 
+// FindCandidates retrieves the plausible candidates for the preferences from the matchmaking queue.
+func (us *TraceableUserStore) FindCandidates(
+	ctx context.Context,
+	prefs matchmaking.Preferences,
+	origin *location.Location,
+) ([]*matchdomain.User, error) {
+	ctx, span := us.tracer.Start(ctx, "randomtalk/matchmaking/user_store/FindCandidates")
+	defer span.End()
+
+	users, err := us.store.FindCandidates(ctx, prefs, origin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to find candidates")
+	}
+
+	return users, err
+}
+
+// ClaimUsers atomically claims the users from the matchmaking queue.
+func (us *TraceableUserStore) ClaimUsers(ctx context.Context, userIDs ...string) ([]*matchdomain.User, error) {
+	ctx, span := us.tracer.Start(ctx, "randomtalk/matchmaking/user_store/ClaimUsers")
+	defer span.End()
+
+	users, err := us.store.ClaimUsers(ctx, userIDs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to claim users")
+	}
+
+	return users, err
+}
+
 func (us *TraceableUserStore) RemoveUsers(ctx context.Context, userIDs ...string) error {
 	ctx, span := us.tracer.Start(ctx, "randomtalk/matchmaking/user_store/RemoveUsers")
 	defer span.End()
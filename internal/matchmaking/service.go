@@ -3,11 +3,14 @@ package matchmaking
 import (
 	"context"
 	"errors"
+	"net"
+	"net/http"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/xfrr/randomtalk/internal/shared/env"
@@ -18,8 +21,10 @@ import (
 	commands "github.com/xfrr/randomtalk/internal/matchmaking/application/commands"
 	config "github.com/xfrr/randomtalk/internal/matchmaking/config"
 	domain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	grpcAdapter "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/grpc"
 	handlers "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/handlers"
 	inMemoryAdapter "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+	metrics "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/metrics"
 	natsAdapter "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/nats"
 	tracing "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/tracing"
 	xotel "github.com/xfrr/randomtalk/internal/shared/otel"
@@ -29,6 +34,9 @@ var (
 	ErrEmptyConfig = errors.New("service config is empty, please provide a valid config")
 )
 
+// matchEventsStreamName is the stream of the match events, also carrying the match notifications.
+const matchEventsStreamName = "randomtalk_matchmaking_match_events"
+
 // Service defines the dependencies that can be overridden
 // when initializing a new matchmaking service.
 type Service struct {
@@ -38,6 +46,11 @@ type Service struct {
 	logger             *zerolog.Logger
 	natsConnection     *nats.Conn
 	matchmakingService domain.MatchmakingProcessor
+	matchRepository    domain.MatchRepository
+	matchHistory       domain.MatchHistory
+	reputationStore    domain.ReputationStore
+	userStore          domain.UserStore
+	matchNotifier      *inMemoryAdapter.MatchNotifier
 	cmdbus             commands.CommandBus
 	closers            []func()
 }
@@ -106,15 +119,31 @@ func NewService(opts ...InitOption) (*Service, error) {
 		return nil, err
 	}
 
-	matchRepository, err := svc.initMatchRepository(ctx, js)
+	err = natsAdapter.CreateMatchmakingQueueStatusesStream(ctx, js)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.matchRepository, err = svc.initMatchRepository(ctx, js)
+	if err != nil {
+		return svc, err
+	}
+
+	svc.matchHistory, err = natsAdapter.NewMatchHistory(ctx, js, svc.config.MatchHistory.MaxEntries)
 	if err != nil {
 		return svc, err
 	}
 
+	svc.reputationStore, err = natsAdapter.NewReputationStore(ctx, js)
+	if err != nil {
+		return svc, err
+	}
+
+	svc.matchNotifier = inMemoryAdapter.NewMatchNotifier()
 	svc.matchmakingService, err = svc.initMatchmakerService(
 		ctx,
 		js,
-		matchRepository,
+		svc.matchRepository,
 	)
 	if err != nil {
 		return svc, err
@@ -136,6 +165,62 @@ func (s *Service) Shutdown() {
 
 func (s *Service) start(ctx context.Context) {
 	go s.startChatNotificationConsumer(ctx, s.matchmakingService)
+	go s.startWaitingUsersProcessor(ctx, s.matchmakingService)
+	go s.startQueueStatusNotifier(ctx, s.matchmakingService)
+	go s.startMatchHistoryProjector(ctx)
+	go s.startConfirmedMatchWatcher(ctx)
+	s.startGrpcAPIServer(ctx)
+}
+
+func (s *Service) startGrpcAPIServer(ctx context.Context) {
+	apiServer := grpcAdapter.NewMatchMakingServer(
+		s.cmdbus,
+		s.matchRepository,
+		s.matchHistory,
+		s.userStore,
+		s.matchNotifier,
+		grpcAdapter.WithLogger(s.logger),
+		grpcAdapter.WithFindMatchTimeout(time.Duration(s.config.GrpcAPIServer.FindMatchTimeoutSeconds)*time.Second),
+	)
+
+	lis, err := net.Listen("tcp", s.config.GrpcAPIServer.Addr)
+	if err != nil {
+		s.logger.Fatal().Err(err).Str("address", s.config.GrpcAPIServer.Addr).Msg("failed to listen for gRPC API server")
+		return
+	}
+
+	grpcServer := grpcAdapter.NewGRPCServer(apiServer)
+	s.registerCloser(grpcServer.GracefulStop)
+
+	go func() {
+		s.logger.Info().Str("address", s.config.GrpcAPIServer.Addr).Msg("starting gRPC API server")
+		if serveErr := grpcServer.Serve(lis); serveErr != nil {
+			s.logger.Error().Err(serveErr).Msg("gRPC API server stopped")
+		}
+	}()
+
+	gatewayServer, err := grpcAdapter.NewGatewayServer(
+		ctx,
+		s.config.GrpcAPIServer.GatewayAddr,
+		s.config.GrpcAPIServer.Addr,
+	)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("failed to initialize gRPC gateway server")
+		return
+	}
+
+	s.registerCloser(func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = gatewayServer.Shutdown(shutdownCtx)
+	})
+
+	go func() {
+		s.logger.Info().Str("address", s.config.GrpcAPIServer.GatewayAddr).Msg("starting gRPC gateway server")
+		if serveErr := gatewayServer.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			s.logger.Error().Err(serveErr).Msg("gRPC gateway server stopped")
+		}
+	}()
 }
 
 func (s *Service) startChatNotificationConsumer(ctx context.Context, mp domain.MatchmakingProcessor) {
@@ -145,8 +230,16 @@ func (s *Service) startChatNotificationConsumer(ctx context.Context, mp domain.M
 		return
 	}
 
-	// create user match request event handler
-	userMatchRequestHandler := handlers.NewUserMatchRequestedEventHandler(mp, s.logger)
+	// create user match request, match request cancellation, match response and partner rating event handlers
+	userMatchRequestHandler := handlers.NewUserMatchRequestedEventHandler(
+		mp,
+		s.reputationStore,
+		s.config.Reputation.Policy(),
+		s.logger,
+	)
+	matchRequestCancelledHandler := handlers.NewMatchRequestCancelledEventHandler(mp, s.logger)
+	matchRespondedHandler := handlers.NewMatchRespondedEventHandler(mp, s.logger)
+	partnerRatedHandler := handlers.NewPartnerRatedEventHandler(s.reputationStore, s.logger)
 
 	s.logger.Debug().
 		Str("consumer_name", s.config.ChatNotificationsConsumerConfig.Name).
@@ -156,12 +249,119 @@ func (s *Service) startChatNotificationConsumer(ctx context.Context, mp domain.M
 		ctx,
 		s.logger,
 		consumer,
-		userMatchRequestHandler.Handle,
+		func(ctx context.Context, evt *messaging.Event) error {
+			switch evt.Type() {
+			case handlers.MatchRespondedEventType:
+				return matchRespondedHandler.Handle(ctx, evt)
+			case handlers.MatchRequestCancelledEventType:
+				return matchRequestCancelledHandler.Handle(ctx, evt)
+			case handlers.PartnerRatedEventType:
+				return partnerRatedHandler.Handle(ctx, evt)
+			default:
+				return userMatchRequestHandler.Handle(ctx, evt)
+			}
+		},
 	); err != nil {
 		s.logger.Error().Err(err).Msg("failed to start chat notification event handler")
 	}
 }
 
+// startMatchHistoryProjector projects the created matches into the match history of their users.
+func (s *Service) startMatchHistoryProjector(ctx context.Context) {
+	consumer, err := xnats.CreateMessagingEventConsumer(
+		ctx,
+		s.natsConnection,
+		s.logger,
+		matchEventsStreamName,
+		jetstream.ConsumerConfig{
+			Name:           s.config.MatchHistory.ConsumerName,
+			Durable:        s.config.MatchHistory.ConsumerName,
+			AckPolicy:      jetstream.AckExplicitPolicy,
+			DeliverPolicy:  jetstream.DeliverAllPolicy,
+			AckWait:        30 * time.Second,
+			MaxDeliver:     5,
+			MaxAckPending:  50,
+			FilterSubjects: []string{"randomtalk.matchmaking.matches.*." + domain.MatchCreatedEvent{}.EventName()},
+			BackOff: []time.Duration{
+				500 * time.Millisecond,
+				1 * time.Second,
+			},
+		},
+	)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("failed to initialize match history consumer")
+		return
+	}
+
+	matchCreatedHandler := handlers.NewMatchCreatedEventHandler(s.matchHistory, s.logger)
+	if err = messaging.HandleEvents(ctx, s.logger, consumer, matchCreatedHandler.Handle); err != nil {
+		s.logger.Error().Err(err).Msg("failed to start match history projector")
+	}
+}
+
+// startConfirmedMatchWatcher pushes the matches confirmed by any matchmaking instance
+// to the gRPC API server, so every instance can answer the users waiting for a match.
+func (s *Service) startConfirmedMatchWatcher(ctx context.Context) {
+	js, err := jetstream.New(s.natsConnection)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("failed to initialize confirmed match watcher")
+		return
+	}
+
+	watcher := natsAdapter.NewConfirmedMatchWatcher(js, matchEventsStreamName, s.matchRepository, s.matchNotifier, s.logger)
+	if err = watcher.Watch(ctx); err != nil {
+		s.logger.Error().Err(err).Msg("failed to watch confirmed matches")
+	}
+}
+
+// startWaitingUsersProcessor periodically releases the users that waited too long
+// and retries matching the rest with their relaxed preferences.
+// In batch mode, every tick runs a matching round over the whole waiting pool.
+func (s *Service) startWaitingUsersProcessor(ctx context.Context, mp domain.MatchmakingProcessor) {
+	interval := s.config.Matchmaker.WaitingUsersProcessingInterval()
+	if interval <= 0 {
+		s.logger.Warn().Msg("waiting users processor disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := mp.ProcessWaitingUsers(ctx); err != nil {
+				s.logger.Error().Err(err).Msg("failed to process waiting users")
+			}
+		}
+	}
+}
+
+// startQueueStatusNotifier periodically tells the waiting users their queue status.
+func (s *Service) startQueueStatusNotifier(ctx context.Context, mp domain.MatchmakingProcessor) {
+	interval := s.config.Matchmaker.QueueStatusInterval
+	if interval <= 0 {
+		s.logger.Warn().Msg("queue status notifier disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := mp.NotifyQueueStatus(ctx); err != nil {
+				s.logger.Error().Err(err).Msg("failed to notify queue status")
+			}
+		}
+	}
+}
+
 func (s *Service) initChatNotificationConsumer(ctx context.Context) (*xnats.MessagingEventConsumer, error) {
 	chatNotificationConsumer, err := xnats.CreateMessagingEventConsumer(
 		ctx,
@@ -199,19 +399,56 @@ func (s *Service) initMatchmakerService(
 	js jetstream.JetStream,
 	matchRepo domain.MatchRepository,
 ) (domain.MatchmakingProcessor, error) {
-	userStore, err := natsAdapter.NewUserStore(ctx, js)
+	userStore, err := natsAdapter.NewUserStore(ctx, js, s.config.Matchmaker.UserStoreTTL())
 	if err != nil {
 		return nil, err
 	}
+	s.userStore = tracing.WrapUserStore(userStore, s.traceProvider)
+
+	scorer := domain.NewWeightedScorer(s.config.Matchmaker.ScoreWeights())
+	stableMatcher := s.config.Matchmaker.StableMatcher(scorer)
 
-	stableMatcher := domain.NewGaleShapleyStableMatcher()
+	matchMetrics, err := metrics.NewOtelMatchMetrics(otel.GetMeterProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []domain.UserMatchMakerOption{
+		domain.WithLogger(s.logger),
+		domain.WithMaxWaitTime(s.config.Matchmaker.MaxWaitTime),
+		domain.WithRelaxationPolicy(s.config.Matchmaker.RelaxationPolicy()),
+		domain.WithNoMatchNotifier(natsAdapter.NewNoMatchNotifier(matchEventsStreamName, js)),
+		domain.WithMatchMetrics(matchMetrics, scorer),
+		domain.WithQueueStatus(
+			natsAdapter.NewQueueStatusNotifier(js),
+			domain.NewMatchRates(s.config.Matchmaker.MatchRateWindow),
+		),
+	}
+	if s.config.Matchmaker.SkipCooldown > 0 {
+		skippedPairs, err := natsAdapter.NewSkippedPairStore(ctx, js, s.config.Matchmaker.SkipCooldown)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, domain.WithSkipCooldown(skippedPairs, s.config.Matchmaker.SkipCooldown))
+	}
+	if s.config.Matchmaker.ProposalTimeout > 0 {
+		// the proposals are expired by the matchmaker, the TTL only drops the ones left behind
+		proposals, err := natsAdapter.NewProposalStore(ctx, js, 10*s.config.Matchmaker.ProposalTimeout)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, domain.WithMatchProposals(proposals, s.config.Matchmaker.ProposalTimeout))
+	}
+	if s.config.Matchmaker.MatchingMode() == domain.BatchMatching {
+		opts = append(opts, domain.WithBatchMatching(s.config.Matchmaker.BatchPoolSize))
+	}
 
 	var matchService domain.MatchmakingProcessor
 	matchService, err = domain.NewUserMatchProcessor(
 		matchRepo,
-		userStore,
+		s.userStore,
 		stableMatcher,
-		domain.WithLogger(s.logger),
+		opts...,
 	)
 
 	matchService = tracing.WrapMatchmakingService(
@@ -275,7 +512,7 @@ func (s *Service) initMatchRepository(ctx context.Context, js jetstream.JetStrea
 	var matchRepo domain.MatchRepository
 	matchRepo, err := natsAdapter.NewMatchStreamRepository(
 		ctx, js, xnats.
-			NewStreamConfig("randomtalk_matchmaking_match_events", "randomtalk.matchmaking.matches.>").
+			NewStreamConfig(matchEventsStreamName, "randomtalk.matchmaking.matches.>").
 			WithDenyDelete().
 			// WithDenyPurge(), // TODO: Adjust based on environment settings
 			WithReplicas(1). // TODO: Adjust based on environment settings
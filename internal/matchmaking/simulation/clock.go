@@ -0,0 +1,34 @@
+package matchsimulation
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a fake clock moved forward by the simulation, so the matchmaker
+// sees the simulated time instead of the wall clock.
+type Clock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewClock creates a Clock stopped at the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the current simulated time.
+func (c *Clock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Set moves the clock to the given time. Times before the current one are ignored.
+func (c *Clock) Set(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if at.After(c.now) {
+		c.now = at
+	}
+}
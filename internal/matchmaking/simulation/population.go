@@ -0,0 +1,175 @@
+package matchsimulation
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
+	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
+)
+
+// earthRadiusKm converts the distances to degrees when scattering the users around a city.
+const earthRadiusKm = 6371.0
+
+// AgeDistribution is a normal distribution of the users age, clamped to the allowed ages.
+type AgeDistribution struct {
+	Mean   float64
+	StdDev float64
+}
+
+// GenderShares are the relative weights of every gender. They do not need to add up to 1.
+type GenderShares struct {
+	Female      float64
+	Male        float64
+	Unspecified float64
+}
+
+// City is a place the users connect from.
+type City struct {
+	CountryCode string
+	CityCode    string
+	Latitude    float64
+	Longitude   float64
+	// Weight is the relative share of the users connecting from the city.
+	Weight float64
+	// RadiusKm scatters the users around the city center.
+	RadiusKm float64
+}
+
+// Population describes the synthetic users arriving to the matchmaker.
+type Population struct {
+	Age AgeDistribution
+	// Genders are the shares of the users genders.
+	Genders GenderShares
+	// PreferredGenders are the shares of the genders the users look for,
+	// where Unspecified stands for the users accepting any gender.
+	PreferredGenders GenderShares
+	// AgeRange is how many years younger or older than themselves the users accept.
+	// Zero accepts every age.
+	AgeRange int32
+	// Interests are the interests the users pick from, the most popular first.
+	Interests []string
+	// MaxInterests is the maximum number of interests of every user. Zero disables the interests.
+	MaxInterests int
+	// Cities are the places the users connect from. Users have no location without cities.
+	Cities []City
+	// MaxDistanceKm is the maximum distance the users accept. Zero accepts any distance.
+	MaxDistanceKm float64
+}
+
+// DefaultPopulation returns a population of young adults from a few Spanish cities.
+func DefaultPopulation() Population {
+	return Population{
+		Age:              AgeDistribution{Mean: 28, StdDev: 7},
+		Genders:          GenderShares{Female: 0.45, Male: 0.5, Unspecified: 0.05},
+		PreferredGenders: GenderShares{Female: 0.45, Male: 0.3, Unspecified: 0.25},
+		AgeRange:         8,
+		Interests:        []string{"music", "movies", "travel", "sports", "games", "books", "food", "art"},
+		MaxInterests:     3,
+		Cities: []City{
+			{CountryCode: "ES", CityCode: "MAD", Latitude: 40.4168, Longitude: -3.7038, Weight: 0.4, RadiusKm: 15},
+			{CountryCode: "ES", CityCode: "BCN", Latitude: 41.3874, Longitude: 2.1686, Weight: 0.35, RadiusKm: 10},
+			{CountryCode: "ES", CityCode: "VLC", Latitude: 39.4699, Longitude: -0.3763, Weight: 0.25, RadiusKm: 8},
+		},
+	}
+}
+
+// NewUser generates a user of the population.
+func (p Population) NewUser(rnd *rand.Rand, id string) *matchdomain.User {
+	age := p.age(rnd)
+
+	prefs := matchmaking.DefaultPreferences().
+		WithGender(pickGender(rnd, p.PreferredGenders)).
+		WithInterests(p.interests(rnd)).
+		WithMaxDistanceKm(p.MaxDistanceKm)
+	if p.AgeRange > 0 {
+		prefs = prefs.WithMinAge(age - p.AgeRange).WithMaxAge(age + p.AgeRange)
+	}
+
+	var opts []matchdomain.UserOption
+	if loc := p.location(rnd); loc != nil {
+		opts = append(opts, matchdomain.WithLocation(loc))
+	}
+	return matchdomain.NewUser(id, age, pickGender(rnd, p.Genders), prefs, opts...)
+}
+
+func (p Population) age(rnd *rand.Rand) int32 {
+	age := int32(math.Round(p.Age.Mean + rnd.NormFloat64()*p.Age.StdDev))
+	return min(max(age, matchmaking.MinAllowedAge), matchmaking.MaxAllowedAge)
+}
+
+// interests picks the interests of a user, each with a probability inversely
+// proportional to its popularity rank, so the first interests are the most shared.
+func (p Population) interests(rnd *rand.Rand) []string {
+	if p.MaxInterests <= 0 || len(p.Interests) == 0 {
+		return nil
+	}
+
+	weights := make([]float64, len(p.Interests))
+	for rank := range weights {
+		weights[rank] = 1 / float64(rank+1)
+	}
+
+	count := 1 + rnd.IntN(min(p.MaxInterests, len(p.Interests)))
+	picked := make([]string, 0, count)
+	for len(picked) < count {
+		idx := pickWeighted(rnd, weights)
+		weights[idx] = 0
+		picked = append(picked, p.Interests[idx])
+	}
+	slices.Sort(picked)
+	return picked
+}
+
+func (p Population) location(rnd *rand.Rand) *location.Location {
+	if len(p.Cities) == 0 {
+		return nil
+	}
+
+	weights := make([]float64, len(p.Cities))
+	for i, city := range p.Cities {
+		weights[i] = city.Weight
+	}
+	city := p.Cities[pickWeighted(rnd, weights)]
+
+	// scatter the users uniformly over the city disk
+	distanceKm := city.RadiusKm * math.Sqrt(rnd.Float64())
+	bearing := 2 * math.Pi * rnd.Float64()
+	latOffset := distanceKm / earthRadiusKm * 180 / math.Pi
+	lonOffset := latOffset / math.Cos(city.Latitude*math.Pi/180)
+
+	loc := location.New(
+		city.Latitude+latOffset*math.Cos(bearing),
+		city.Longitude+lonOffset*math.Sin(bearing),
+	).WithCountryCode(city.CountryCode).WithCityCode(city.CityCode)
+	return &loc
+}
+
+func pickGender(rnd *rand.Rand, shares GenderShares) gender.Gender {
+	genders := []gender.Gender{gender.Female, gender.Male, gender.Unspecified}
+	return genders[pickWeighted(rnd, []float64{shares.Female, shares.Male, shares.Unspecified})]
+}
+
+// pickWeighted returns the index of a weight picked with a probability proportional to it.
+// It returns the last index when every weight is zero.
+func pickWeighted(rnd *rand.Rand, weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += max(w, 0)
+	}
+	if total <= 0 {
+		return len(weights) - 1
+	}
+
+	target := rnd.Float64() * total
+	for i, w := range weights {
+		target -= max(w, 0)
+		if target < 0 {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
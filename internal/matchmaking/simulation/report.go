@@ -0,0 +1,224 @@
+package matchsimulation
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+)
+
+// Report summarizes how the simulated users were matched.
+type Report struct {
+	Arrivals int
+	Matched  int
+	// Released are the users whose wait deadline passed without a match.
+	Released int
+	// Waiting are the users still waiting when the simulation ended.
+	Waiting int
+	// MatchRate is the share of the arrived users that were matched.
+	MatchRate float64
+	// Wait is the distribution of the time the matched users waited.
+	Wait WaitStats
+
+	// CompatiblePairs are the pairs of users that waited at the same time and could be matched.
+	CompatiblePairs int
+	// BlockingPairs are the compatible pairs whose users both prefer each other to the partner
+	// they got, or got none.
+	BlockingPairs int
+	// BlockingPairShare is the share of the compatible pairs that are blocking pairs.
+	BlockingPairShare float64
+
+	// Segments are the results of every preference segment, sorted by name.
+	Segments []SegmentReport
+	// Fairness is the Jain's index of the segments match rates, from 1/segments,
+	// when a single segment gets every match, to 1, when all of them get matched alike.
+	Fairness float64
+}
+
+// SegmentReport summarizes how the users of a preference segment were matched.
+type SegmentReport struct {
+	Segment   string
+	Arrivals  int
+	Matched   int
+	MatchRate float64
+	Wait      WaitStats
+}
+
+// WaitStats is the distribution of the time the users waited for a match.
+type WaitStats struct {
+	Mean time.Duration
+	P50  time.Duration
+	P90  time.Duration
+	P99  time.Duration
+	Max  time.Duration
+}
+
+// simulatedUser is a user of the simulation with its outcome.
+type simulatedUser struct {
+	*matchdomain.User
+	arrivedAt time.Time
+	// leftAt is when the user was matched, released or the simulation ended.
+	leftAt    time.Time
+	matched   bool
+	partnerID string
+}
+
+func newReport(
+	arrivals []arrival,
+	outcomes *outcomeRecorder,
+	waiting int,
+	end time.Time,
+	scorer matchdomain.MatchScorer,
+) Report {
+	users := make([]simulatedUser, 0, len(arrivals))
+	for _, a := range arrivals {
+		user := simulatedUser{User: a.user, arrivedAt: a.at, leftAt: end}
+		if matchedAt, ok := outcomes.matched[a.user.ID()]; ok {
+			user.leftAt, user.matched = matchedAt, true
+			user.partnerID = outcomes.partners[a.user.ID()]
+		} else if releasedAt, ok := outcomes.released[a.user.ID()]; ok {
+			user.leftAt = releasedAt
+		}
+		users = append(users, user)
+	}
+
+	report := Report{
+		Arrivals: len(users),
+		Released: len(outcomes.released),
+		Waiting:  waiting,
+	}
+	report.Matched, report.MatchRate, report.Wait = matchStats(users)
+	report.CompatiblePairs, report.BlockingPairs = countBlockingPairs(users, scorer)
+	if report.CompatiblePairs > 0 {
+		report.BlockingPairShare = float64(report.BlockingPairs) / float64(report.CompatiblePairs)
+	}
+	report.Segments = segmentReports(users)
+
+	rates := make([]float64, 0, len(report.Segments))
+	for _, segment := range report.Segments {
+		rates = append(rates, segment.MatchRate)
+	}
+	report.Fairness = jainIndex(rates)
+	return report
+}
+
+// matchStats returns the number of matched users, their share and how long they waited.
+func matchStats(users []simulatedUser) (int, float64, WaitStats) {
+	var waits []time.Duration
+	for _, user := range users {
+		if user.matched {
+			waits = append(waits, user.leftAt.Sub(user.arrivedAt))
+		}
+	}
+
+	rate := 0.0
+	if len(users) > 0 {
+		rate = float64(len(waits)) / float64(len(users))
+	}
+	return len(waits), rate, newWaitStats(waits)
+}
+
+func segmentReports(users []simulatedUser) []SegmentReport {
+	bySegment := make(map[string][]simulatedUser)
+	for _, user := range users {
+		segment := matchdomain.PreferenceSegment(user.User)
+		bySegment[segment] = append(bySegment[segment], user)
+	}
+
+	reports := make([]SegmentReport, 0, len(bySegment))
+	for segment, segmentUsers := range bySegment {
+		report := SegmentReport{Segment: segment, Arrivals: len(segmentUsers)}
+		report.Matched, report.MatchRate, report.Wait = matchStats(segmentUsers)
+		reports = append(reports, report)
+	}
+
+	slices.SortFunc(reports, func(a, b SegmentReport) int {
+		switch {
+		case a.Segment < b.Segment:
+			return -1
+		case a.Segment > b.Segment:
+			return 1
+		}
+		return 0
+	})
+	return reports
+}
+
+// countBlockingPairs returns the number of compatible pairs of users that waited at the
+// same time and how many of them are blocking pairs. The users must be sorted by arrival.
+func countBlockingPairs(users []simulatedUser, scorer matchdomain.MatchScorer) (int, int) {
+	byID := make(map[string]*simulatedUser, len(users))
+	for i := range users {
+		byID[users[i].ID()] = &users[i]
+	}
+
+	prefers := func(user, other *simulatedUser) bool {
+		partner, ok := byID[user.partnerID]
+		if !user.matched || !ok {
+			return true
+		}
+		return scorer.Score(user.User, other.User) > scorer.Score(user.User, partner.User)
+	}
+
+	compatible, blocking := 0, 0
+	for i := range users {
+		user := &users[i]
+		for j := i + 1; j < len(users) && users[j].arrivedAt.Before(user.leftAt); j++ {
+			other := &users[j]
+			if !other.leftAt.After(other.arrivedAt) || user.partnerID == other.ID() {
+				continue
+			}
+			if !matchdomain.AreCompatible(user.User, other.User) {
+				continue
+			}
+
+			compatible++
+			if prefers(user, other) && prefers(other, user) {
+				blocking++
+			}
+		}
+	}
+	return compatible, blocking
+}
+
+func newWaitStats(waits []time.Duration) WaitStats {
+	if len(waits) == 0 {
+		return WaitStats{}
+	}
+
+	sorted := slices.Clone(waits)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, wait := range sorted {
+		total += wait
+	}
+
+	return WaitStats{
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 0.5),
+		P90:  percentile(sorted, 0.9),
+		P99:  percentile(sorted, 0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// jainIndex returns the Jain's fairness index of the values, 1 when there are none.
+func jainIndex(values []float64) float64 {
+	sum, squares := 0.0, 0.0
+	for _, v := range values {
+		sum += v
+		squares += v * v
+	}
+	if squares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(values)) * squares)
+}
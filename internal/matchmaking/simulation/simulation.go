@@ -0,0 +1,178 @@
+package matchsimulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	matchmakingconfig "github.com/xfrr/randomtalk/internal/matchmaking/config"
+	matchdomain "github.com/xfrr/randomtalk/internal/matchmaking/domain"
+	matchmakinginmemory "github.com/xfrr/randomtalk/internal/matchmaking/infrastructure/memory"
+)
+
+var ErrInvalidArrivalRate = errors.New("arrival rate must be positive")
+
+// start is the simulated time the arrivals start at.
+var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Config holds the parameters of a simulation.
+type Config struct {
+	Population Population
+	// ArrivalRate is the mean number of users arriving per second, as a Poisson process.
+	ArrivalRate float64
+	// Duration is how long the users keep arriving. The simulation then runs for the
+	// matchmaker max wait time, so the last users are matched or released.
+	Duration time.Duration
+	// Matchmaker is the configuration of the processor, as the matchmaking service uses it.
+	Matchmaker matchmakingconfig.Matchmaker
+	// Seed makes the simulations reproducible.
+	Seed uint64
+}
+
+// arrival is a user arriving to the matchmaker.
+type arrival struct {
+	user *matchdomain.User
+	at   time.Time
+}
+
+// Run runs the synthetic arrivals through the real UserMatchProcessor, backed by the
+// in-memory stores and a fake clock, and reports how the users were matched.
+func Run(ctx context.Context, cfg Config) (Report, error) {
+	if cfg.ArrivalRate <= 0 {
+		return Report{}, ErrInvalidArrivalRate
+	}
+
+	clock := NewClock(start)
+	outcomes := newOutcomeRecorder(clock)
+	userStore := matchmakinginmemory.NewUserStore(nil)
+
+	scorer := matchdomain.NewWeightedScorer(cfg.Matchmaker.ScoreWeights(), matchdomain.WithScorerClock(clock.Now))
+	opts := []matchdomain.UserMatchMakerOption{
+		matchdomain.WithClock(clock.Now),
+		matchdomain.WithNotificationsChannel(outcomes),
+		matchdomain.WithNoMatchNotifier(outcomes),
+		matchdomain.WithMaxWaitTime(cfg.Matchmaker.MaxWaitTime),
+		matchdomain.WithRelaxationPolicy(cfg.Matchmaker.RelaxationPolicy()),
+	}
+	if cfg.Matchmaker.MatchingMode() == matchdomain.BatchMatching {
+		opts = append(opts, matchdomain.WithBatchMatching(cfg.Matchmaker.BatchPoolSize))
+	}
+
+	processor, err := matchdomain.NewUserMatchProcessor(
+		matchmakinginmemory.NewMatchRepository(),
+		userStore,
+		cfg.Matchmaker.StableMatcher(scorer),
+		opts...,
+	)
+	if err != nil {
+		return Report{}, fmt.Errorf("create processor: %w", err)
+	}
+
+	arrivals := generateArrivals(cfg)
+	end := start.Add(cfg.Duration + max(cfg.Matchmaker.MaxWaitTime, 0))
+
+	interval := cfg.Matchmaker.WaitingUsersProcessingInterval()
+	nextTick := start.Add(interval)
+	tickUntil := func(at time.Time) error {
+		for interval > 0 && !nextTick.After(at) {
+			clock.Set(nextTick)
+			if err := processor.ProcessWaitingUsers(ctx); err != nil {
+				return fmt.Errorf("process waiting users: %w", err)
+			}
+			nextTick = nextTick.Add(interval)
+		}
+		return nil
+	}
+
+	for _, a := range arrivals {
+		if err = ctx.Err(); err != nil {
+			return Report{}, err
+		}
+		if err = tickUntil(a.at); err != nil {
+			return Report{}, err
+		}
+
+		clock.Set(a.at)
+		if err = processor.ProcessMatchRequest(ctx, *a.user); err != nil {
+			return Report{}, fmt.Errorf("process match request: %w", err)
+		}
+	}
+
+	if err = tickUntil(end); err != nil {
+		return Report{}, err
+	}
+	clock.Set(end)
+
+	waiting, err := userStore.GetAll(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("get waiting users: %w", err)
+	}
+
+	// the blocking pairs are judged on the static criteria, the wait time changes as the users wait
+	weights := cfg.Matchmaker.ScoreWeights()
+	weights.WaitTime = 0
+	return newReport(arrivals, outcomes, len(waiting), end, matchdomain.NewWeightedScorer(weights)), nil
+}
+
+// generateArrivals returns the users arriving during the simulation, in arrival order.
+func generateArrivals(cfg Config) []arrival {
+	rnd := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+
+	var arrivals []arrival
+	at := start
+	for {
+		at = at.Add(time.Duration(rnd.ExpFloat64() / cfg.ArrivalRate * float64(time.Second)))
+		if at.Sub(start) > cfg.Duration {
+			return arrivals
+		}
+
+		user := cfg.Population.NewUser(rnd, "user-"+strconv.Itoa(len(arrivals)+1))
+		arrivals = append(arrivals, arrival{user: user, at: at})
+	}
+}
+
+// outcomeRecorder records when the users are matched or released by the processor.
+type outcomeRecorder struct {
+	clock *Clock
+
+	mu       sync.Mutex
+	matched  map[string]time.Time
+	partners map[string]string
+	released map[string]time.Time
+}
+
+func newOutcomeRecorder(clock *Clock) *outcomeRecorder {
+	return &outcomeRecorder{
+		clock:    clock,
+		matched:  make(map[string]time.Time),
+		partners: make(map[string]string),
+		released: make(map[string]time.Time),
+	}
+}
+
+// Notify implements matchdomain.NotificationsChannel.
+func (r *outcomeRecorder) Notify(_ context.Context, userID string, match *matchdomain.Match) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.matched[userID] = r.clock.Now()
+	for _, participant := range match.Participants() {
+		if participant.ID() != userID {
+			r.partners[userID] = participant.ID()
+		}
+	}
+	return nil
+}
+
+// NotifyNoMatchFound implements matchdomain.NoMatchNotifier.
+func (r *outcomeRecorder) NotifyNoMatchFound(_ context.Context, user matchdomain.User, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.released[user.ID()] = r.clock.Now()
+	return nil
+}
@@ -0,0 +1,88 @@
+package matchsimulation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	matchmakingconfig "github.com/xfrr/randomtalk/internal/matchmaking/config"
+	matchsimulation "github.com/xfrr/randomtalk/internal/matchmaking/simulation"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	newConfig := func(mode string) matchsimulation.Config {
+		matchmaker := matchmakingconfig.DefaultMatchmaker()
+		matchmaker.Mode = mode
+		return matchsimulation.Config{
+			Population:  matchsimulation.DefaultPopulation(),
+			ArrivalRate: 2,
+			Duration:    5 * time.Minute,
+			Matchmaker:  matchmaker,
+			Seed:        42,
+		}
+	}
+
+	t.Run("should account for every arrived user", func(t *testing.T) {
+		for _, mode := range []string{"immediate", "batch"} {
+			report, err := matchsimulation.Run(ctx, newConfig(mode))
+			require.NoError(t, err)
+
+			require.NotZero(t, report.Arrivals, mode)
+			require.NotZero(t, report.Matched, mode)
+			require.Zero(t, report.Matched%2, "users are matched in pairs")
+			require.Equal(t, report.Arrivals, report.Matched+report.Released+report.Waiting, mode)
+			require.InDelta(t, float64(report.Matched)/float64(report.Arrivals), report.MatchRate, 1e-9)
+
+			require.LessOrEqual(t, report.Wait.P50, report.Wait.P90)
+			require.LessOrEqual(t, report.Wait.P90, report.Wait.P99)
+			require.LessOrEqual(t, report.Wait.P99, report.Wait.Max)
+			require.LessOrEqual(t, report.Wait.Max, newConfig(mode).Matchmaker.MaxWaitTime)
+
+			require.LessOrEqual(t, report.BlockingPairs, report.CompatiblePairs)
+			require.Greater(t, report.Fairness, 0.0)
+			require.LessOrEqual(t, report.Fairness, 1.0)
+
+			arrivals := 0
+			for _, segment := range report.Segments {
+				arrivals += segment.Arrivals
+			}
+			require.Equal(t, report.Arrivals, arrivals)
+		}
+	})
+
+	t.Run("should be reproducible with the same seed", func(t *testing.T) {
+		first, err := matchsimulation.Run(ctx, newConfig("immediate"))
+		require.NoError(t, err)
+
+		second, err := matchsimulation.Run(ctx, newConfig("immediate"))
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+	})
+
+	t.Run("should match everyone in an open population", func(t *testing.T) {
+		cfg := newConfig("immediate")
+		cfg.Population = matchsimulation.Population{
+			Age:     matchsimulation.AgeDistribution{Mean: 30, StdDev: 5},
+			Genders: matchsimulation.GenderShares{Unspecified: 1},
+		}
+
+		report, err := matchsimulation.Run(ctx, cfg)
+		require.NoError(t, err)
+		require.LessOrEqual(t, report.Arrivals-report.Matched, 1)
+		require.Zero(t, report.BlockingPairs)
+		require.Len(t, report.Segments, 1)
+		require.InDelta(t, 1, report.Fairness, 1e-9)
+	})
+
+	t.Run("should fail without arrivals", func(t *testing.T) {
+		cfg := newConfig("immediate")
+		cfg.ArrivalRate = 0
+
+		_, err := matchsimulation.Run(ctx, cfg)
+		require.ErrorIs(t, err, matchsimulation.ErrInvalidArrivalRate)
+	})
+}
@@ -49,6 +49,9 @@ type AppendResult struct {
 	// StreamName is the name of the stream.
 	StreamName string
 
+	// FirstSequence is the sequence number of the first event.
+	FirstSequence uint64
+
 	// LastSequence is the sequence number of the last event.
 	LastSequence uint64
 
@@ -0,0 +1,65 @@
+// Package language validates and compares the languages spoken by the users,
+// identified by their BCP-47 tags.
+package language
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	xlanguage "golang.org/x/text/language"
+)
+
+// ErrInvalidLanguage is returned when a language code is not a valid BCP-47 tag.
+var ErrInvalidLanguage = errors.New("invalid language")
+
+// Parse validates a BCP-47 language code, such as "en" or "pt-BR", and returns its canonical form.
+func Parse(code string) (string, error) {
+	tag, err := xlanguage.Parse(code)
+	if err != nil || tag == xlanguage.Und {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguage, code)
+	}
+	return tag.String(), nil
+}
+
+// ParseAll validates the language codes and returns their canonical form, without duplicates.
+func ParseAll(codes ...string) ([]string, error) {
+	languages := make([]string, 0, len(codes))
+	for _, code := range codes {
+		lang, err := Parse(code)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+	return languages, nil
+}
+
+// ShareAny reports whether any of the languages of a is also in b. The regional variants
+// of a language are understood by each other, so "en-US" and "en-GB" share a language.
+func ShareAny(a, b []string) bool {
+	for _, l1 := range a {
+		base1, ok := base(l1)
+		if !ok {
+			continue
+		}
+		for _, l2 := range b {
+			if base2, ok := base(l2); ok && base1 == base2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// base returns the base language of the code, such as "en" for "en-US".
+func base(code string) (xlanguage.Base, bool) {
+	tag, err := xlanguage.Parse(code)
+	if err != nil {
+		return xlanguage.Base{}, false
+	}
+	b, confidence := tag.Base()
+	return b, confidence != xlanguage.No
+}
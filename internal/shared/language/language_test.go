@@ -0,0 +1,43 @@
+package language_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/randomtalk/internal/shared/language"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should return the canonical form of valid codes", func(t *testing.T) {
+		for code, want := range map[string]string{
+			"en":         "en",
+			"pt-BR":      "pt-BR",
+			"zh-hant-tw": "zh-Hant-TW",
+			"es-419":     "es-419",
+		} {
+			got, err := language.Parse(code)
+			require.NoError(t, err, code)
+			require.Equal(t, want, got)
+		}
+	})
+
+	t.Run("should fail with invalid codes", func(t *testing.T) {
+		for _, code := range []string{"", "und", "english", "e", "en-", "12"} {
+			_, err := language.Parse(code)
+			require.ErrorIs(t, err, language.ErrInvalidLanguage, code)
+		}
+	})
+
+	t.Run("should drop the duplicated codes", func(t *testing.T) {
+		got, err := language.ParseAll("en", "EN", "es")
+		require.NoError(t, err)
+		require.Equal(t, []string{"en", "es"}, got)
+	})
+}
+
+func TestShareAny(t *testing.T) {
+	require.True(t, language.ShareAny([]string{"es", "en-US"}, []string{"en-GB"}))
+	require.False(t, language.ShareAny([]string{"es"}, []string{"pt-BR"}))
+	require.False(t, language.ShareAny(nil, []string{"en"}))
+}
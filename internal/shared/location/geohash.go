@@ -0,0 +1,94 @@
+package location
+
+import (
+	"math"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// kmPerDegree is the approximate length of a degree of latitude.
+const kmPerDegree = 111.32
+
+// Geohash returns the geohash of the coordinates with the given number of characters.
+func (c Coordinates) Geohash(precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+	hash.Grow(precision)
+
+	bits, ch, evenBit := 0, 0, true
+	for hash.Len() < precision {
+		// even bits refine the longitude, odd bits the latitude
+		value, interval := c.Latitude, &latRange
+		if evenBit {
+			value, interval = c.Longitude, &lonRange
+		}
+
+		mid := (interval[0] + interval[1]) / 2
+		ch <<= 1
+		if value >= mid {
+			ch |= 1
+			interval[0] = mid
+		} else {
+			interval[1] = mid
+		}
+
+		evenBit = !evenBit
+		if bits++; bits == 5 {
+			hash.WriteByte(geohashAlphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// GeohashCellsWithin returns the geohash cells of the given precision that cover every point
+// up to radiusKm away from the center. It returns nil when more than maxCells are needed.
+func GeohashCellsWithin(center Coordinates, radiusKm float64, precision, maxCells int) []string {
+	lonBits := (5*precision + 1) / 2
+	latBits := 5 * precision / 2
+	latCells, lonCells := 1<<latBits, 1<<lonBits
+	cellHeight := 180 / float64(latCells)
+	cellWidth := 360 / float64(lonCells)
+
+	latDelta := radiusKm / kmPerDegree
+	minLat := math.Max(center.Latitude-latDelta, -90)
+	maxLat := math.Min(center.Latitude+latDelta, 90)
+
+	// the longitude degrees are the shortest at the latitude farthest from the equator
+	cos := math.Cos(degToRad(math.Max(math.Abs(minLat), math.Abs(maxLat))))
+	lonDelta := 180.0
+	if cos > 0 {
+		lonDelta = math.Min(radiusKm/(kmPerDegree*cos), 180)
+	}
+
+	cellIndex := func(value, origin, size float64, cells int) int {
+		return min(max(int(math.Floor((value-origin)/size)), 0), cells-1)
+	}
+
+	firstRow := cellIndex(minLat, -90, cellHeight, latCells)
+	lastRow := cellIndex(maxLat, -90, cellHeight, latCells)
+	firstCol := int(math.Floor((center.Longitude - lonDelta + 180) / cellWidth))
+	lastCol := int(math.Floor((center.Longitude + lonDelta + 180) / cellWidth))
+	cols := min(lastCol-firstCol+1, lonCells)
+
+	if (lastRow-firstRow+1)*cols > maxCells {
+		return nil
+	}
+
+	cells := make([]string, 0, (lastRow-firstRow+1)*cols)
+	for row := firstRow; row <= lastRow; row++ {
+		for col := firstCol; col < firstCol+cols; col++ {
+			// wrap around the antimeridian
+			wrapped := ((col % lonCells) + lonCells) % lonCells
+			cell := Coordinates{
+				Latitude:  -90 + (float64(row)+0.5)*cellHeight,
+				Longitude: -180 + (float64(wrapped)+0.5)*cellWidth,
+			}
+			cells = append(cells, cell.Geohash(precision))
+		}
+	}
+	return cells
+}
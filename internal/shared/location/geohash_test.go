@@ -0,0 +1,58 @@
+package location_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/randomtalk/internal/shared/location"
+)
+
+func TestGeohash(t *testing.T) {
+	madrid := location.Coordinates{Latitude: 40.4168, Longitude: -3.7038}
+	assert.Equal(t, "ezjmgtw", madrid.Geohash(7))
+	assert.Equal(t, "ezjm", madrid.Geohash(4))
+
+	sydney := location.Coordinates{Latitude: -33.8688, Longitude: 151.2093}
+	assert.Equal(t, "r3gx2f", sydney.Geohash(6))
+}
+
+func TestGeohashCellsWithin(t *testing.T) {
+	t.Run("should cover every point within the radius", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(1, 2))
+		for range 200 {
+			center := location.New(rnd.Float64()*160-80, rnd.Float64()*360-180)
+			radius := 1 + rnd.Float64()*60
+			cells := location.GeohashCellsWithin(center.Coordinates, radius, 4, 1024)
+			require.NotNil(t, cells)
+
+			for range 20 {
+				point := location.New(
+					center.Coordinates.Latitude+(rnd.Float64()*2-1)*radius/111,
+					center.Coordinates.Longitude+(rnd.Float64()*2-1)*2,
+				)
+				if point.Coordinates.Longitude >= 180 {
+					point.Coordinates.Longitude -= 360
+				}
+				if point.Coordinates.Longitude < -180 {
+					point.Coordinates.Longitude += 360
+				}
+
+				distance, err := center.DistanceTo(point)
+				if err != nil || distance > radius {
+					continue
+				}
+				assert.True(t, slices.Contains(cells, point.Coordinates.Geohash(4)),
+					"point %s is %.1fkm away from %s", point, distance, center)
+			}
+		}
+	})
+
+	t.Run("should return nil when too many cells are needed", func(t *testing.T) {
+		center := location.Coordinates{Latitude: 40.4168, Longitude: -3.7038}
+		assert.Nil(t, location.GeohashCellsWithin(center, 2000, 4, 64))
+	})
+}
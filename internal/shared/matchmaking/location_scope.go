@@ -0,0 +1,40 @@
+package matchmaking
+
+import "strings"
+
+// LocationScope restricts matches to users sharing the same country or city.
+type LocationScope string
+
+const (
+	// LocationScopeAny does not restrict matches by country or city.
+	LocationScopeAny LocationScope = ""
+	// LocationScopeCountry only matches users located in the same country.
+	LocationScopeCountry LocationScope = "country"
+	// LocationScopeCity only matches users located in the same city.
+	LocationScopeCity LocationScope = "city"
+)
+
+// ParseLocationScope returns the LocationScope for a given string, defaulting to LocationScopeAny.
+func ParseLocationScope(str string) LocationScope {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "country":
+		return LocationScopeCountry
+	case "city":
+		return LocationScopeCity
+	default:
+		return LocationScopeAny
+	}
+}
+
+// IsAny reports whether the scope does not restrict matches.
+func (s LocationScope) IsAny() bool {
+	return s == LocationScopeAny
+}
+
+// String implements fmt.Stringer.
+func (s LocationScope) String() string {
+	if s.IsAny() {
+		return "any"
+	}
+	return string(s)
+}
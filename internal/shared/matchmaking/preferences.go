@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/language"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 )
 
 // ErrInvalidPreferences is returned when JSON unmarshalling fails.
@@ -15,14 +18,28 @@ var ErrInvalidPreferences = errors.New("invalid match preferences")
 const (
 	MinAllowedAge int32 = 18
 	MaxAllowedAge int32 = 99
+
+	// MinGroupSize is the minimum number of participants of a group room.
+	MinGroupSize int32 = 3
+	// MaxGroupSize is the maximum number of participants of a group room.
+	MaxGroupSize int32 = 8
 )
 
 // Preferences holds criteria for matching users.
 type Preferences struct {
-	MinAge    int32         `json:"min_age"`
-	MaxAge    int32         `json:"max_age"`
-	Gender    gender.Gender `json:"gender,omitempty"`
-	Interests []string      `json:"interests,omitempty"`
+	MinAge        int32         `json:"min_age"`
+	MaxAge        int32         `json:"max_age"`
+	Gender        gender.Gender `json:"gender,omitempty"`
+	Interests     []string      `json:"interests,omitempty"`
+	MaxDistanceKm float64       `json:"max_distance_km,omitempty"`
+	LocationScope LocationScope `json:"location_scope,omitempty"`
+	// MaxWaitTimeSeconds is how long the user is willing to wait for a match. Zero means no limit.
+	MaxWaitTimeSeconds int32 `json:"max_wait_time_seconds,omitempty"`
+	// RequireSharedLanguage only matches users that speak at least one language of the user.
+	RequireSharedLanguage bool `json:"require_shared_language,omitempty"`
+	// GroupSize is the number of participants of the group room the user asks for.
+	// Zero means a one-to-one match.
+	GroupSize int32 `json:"group_size,omitempty"`
 }
 
 // DefaultPreferences returns a Preferences with sane defaults.
@@ -72,6 +89,63 @@ func (p Preferences) WithInterests(interests []string) Preferences {
 	return p
 }
 
+// WithMaxDistanceKm returns a copy with MaxDistanceKm set (ignores non-positive values).
+func (p Preferences) WithMaxDistanceKm(km float64) Preferences {
+	if km > 0 {
+		p.MaxDistanceKm = km
+	}
+	return p
+}
+
+// WithLocationScope returns a copy with LocationScope set.
+func (p Preferences) WithLocationScope(scope LocationScope) Preferences {
+	p.LocationScope = scope
+	return p
+}
+
+// WithMaxWaitTimeSeconds returns a copy with MaxWaitTimeSeconds set (ignores non-positive values).
+func (p Preferences) WithMaxWaitTimeSeconds(seconds int32) Preferences {
+	if seconds > 0 {
+		p.MaxWaitTimeSeconds = seconds
+	}
+	return p
+}
+
+// WithRequireSharedLanguage returns a copy with RequireSharedLanguage set.
+func (p Preferences) WithRequireSharedLanguage(require bool) Preferences {
+	p.RequireSharedLanguage = require
+	return p
+}
+
+// WithGroupSize returns a copy with GroupSize set (ignores sizes out of [MinGroupSize, MaxGroupSize]).
+func (p Preferences) WithGroupSize(size int32) Preferences {
+	if size >= MinGroupSize && size <= MaxGroupSize {
+		p.GroupSize = size
+	}
+	return p
+}
+
+// IsValidGroupSize reports whether the size is zero, for one-to-one matches,
+// or in [MinGroupSize, MaxGroupSize].
+func IsValidGroupSize(size int32) bool {
+	return size == 0 || (size >= MinGroupSize && size <= MaxGroupSize)
+}
+
+// IsGroup reports whether the preferences ask for a group room instead of a one-to-one match.
+func (p Preferences) IsGroup() bool {
+	return p.GroupSize > 0
+}
+
+// MaxWaitTime returns MaxWaitTimeSeconds as a time.Duration.
+func (p Preferences) MaxWaitTime() time.Duration {
+	return time.Duration(p.MaxWaitTimeSeconds) * time.Second
+}
+
+// HasLocationConstraint reports whether the preferences restrict matches by location.
+func (p Preferences) HasLocationConstraint() bool {
+	return p.MaxDistanceKm > 0 || !p.LocationScope.IsAny()
+}
+
 // UnmarshalJSON applies defaults when fields are missing or zero.
 func (p *Preferences) UnmarshalJSON(data []byte) error {
 	type alias Preferences
@@ -102,6 +176,21 @@ func (p Preferences) String() string {
 	if len(p.Interests) > 0 {
 		parts = append(parts, "Interests: ["+strings.Join(p.Interests, ", ")+"]")
 	}
+	if p.MaxDistanceKm > 0 {
+		parts = append(parts, fmt.Sprintf("MaxDistanceKm: %g", p.MaxDistanceKm))
+	}
+	if !p.LocationScope.IsAny() {
+		parts = append(parts, "LocationScope: "+p.LocationScope.String())
+	}
+	if p.MaxWaitTimeSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("MaxWaitTimeSeconds: %d", p.MaxWaitTimeSeconds))
+	}
+	if p.RequireSharedLanguage {
+		parts = append(parts, "RequireSharedLanguage: true")
+	}
+	if p.IsGroup() {
+		parts = append(parts, fmt.Sprintf("GroupSize: %d", p.GroupSize))
+	}
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
@@ -137,3 +226,50 @@ func (p Preferences) IsSatisfiedBy(u User) bool {
 	}
 	return true
 }
+
+// IsWithinReach reports whether a user located at `to` meets the location criteria
+// of a user located at `from`. A missing location never satisfies a location constraint.
+func (p Preferences) IsWithinReach(from, to *location.Location) bool {
+	if !p.HasLocationConstraint() {
+		return true
+	}
+	if from == nil || to == nil {
+		return false
+	}
+
+	switch p.LocationScope {
+	case LocationScopeCountry:
+		if !sameCode(from.CountryCode, to.CountryCode) {
+			return false
+		}
+	case LocationScopeCity:
+		if !sameCode(from.CityCode, to.CityCode) {
+			return false
+		}
+		// city codes are not unique across countries
+		if from.CountryCode != "" && to.CountryCode != "" && !sameCode(from.CountryCode, to.CountryCode) {
+			return false
+		}
+	}
+
+	if p.MaxDistanceKm > 0 {
+		if from.IsEmpty() || to.IsEmpty() {
+			return false
+		}
+		distance, err := from.DistanceTo(*to)
+		if err != nil || distance > p.MaxDistanceKm {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSpokenBy reports whether a user speaking the `other` languages meets the language
+// criteria of a user speaking the `own` languages.
+func (p Preferences) IsSpokenBy(own, other []string) bool {
+	return !p.RequireSharedLanguage || language.ShareAny(own, other)
+}
+
+func sameCode(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/xfrr/randomtalk/internal/shared/gender"
+	"github.com/xfrr/randomtalk/internal/shared/location"
 	"github.com/xfrr/randomtalk/internal/shared/matchmaking"
 )
 
@@ -75,6 +76,23 @@ func TestWithInterests(t *testing.T) {
 	assert.Equal(t, p1.Interests, p2.Interests)
 }
 
+func TestWithGroupSize(t *testing.T) {
+	p := matchmaking.DefaultPreferences()
+	assert.False(t, p.IsGroup())
+
+	p1 := p.WithGroupSize(4)
+	assert.Equal(t, int32(4), p1.GroupSize)
+	assert.True(t, p1.IsGroup())
+
+	// sizes out of range leave unchanged
+	assert.Equal(t, int32(4), p1.WithGroupSize(2).GroupSize)
+	assert.Equal(t, int32(4), p1.WithGroupSize(9).GroupSize)
+
+	assert.True(t, matchmaking.IsValidGroupSize(0))
+	assert.True(t, matchmaking.IsValidGroupSize(8))
+	assert.False(t, matchmaking.IsValidGroupSize(2))
+}
+
 func TestJSONRoundTripAndDefaults(t *testing.T) {
 	// Marshal omits zero-value fields
 	p := matchmaking.DefaultPreferences().
@@ -134,3 +152,64 @@ func TestIsSatisfiedBy(t *testing.T) {
 	u5.prefs = matchmaking.DefaultPreferences()
 	assert.False(t, basePrefs.IsSatisfiedBy(u5))
 }
+
+func TestIsWithinReach(t *testing.T) {
+	madrid := location.New(40.4168, -3.7038).WithCountryCode("ES").WithCityCode("MAD")
+	toledo := location.New(39.8628, -4.0273).WithCountryCode("ES").WithCityCode("TOL")
+	lisbon := location.New(38.7223, -9.1393).WithCountryCode("PT").WithCityCode("LIS")
+
+	t.Run("should accept any location without constraints", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences()
+		assert.True(t, p.IsWithinReach(&madrid, &lisbon))
+		assert.True(t, p.IsWithinReach(nil, nil))
+	})
+
+	t.Run("should enforce the max distance", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences().WithMaxDistanceKm(100)
+		assert.True(t, p.IsWithinReach(&madrid, &toledo))
+		assert.False(t, p.IsWithinReach(&madrid, &lisbon))
+	})
+
+	t.Run("should reject unknown locations when constrained", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences().WithMaxDistanceKm(100)
+		assert.False(t, p.IsWithinReach(&madrid, nil))
+		assert.False(t, p.IsWithinReach(nil, &madrid))
+
+		withoutCoordinates := location.Location{CountryCode: "ES"}
+		assert.False(t, p.IsWithinReach(&madrid, &withoutCoordinates))
+	})
+
+	t.Run("should only match the same country", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences().WithLocationScope(matchmaking.LocationScopeCountry)
+		assert.True(t, p.IsWithinReach(&madrid, &toledo))
+		assert.False(t, p.IsWithinReach(&madrid, &lisbon))
+
+		countryOnly := location.Location{CountryCode: "es"}
+		assert.True(t, p.IsWithinReach(&madrid, &countryOnly))
+	})
+
+	t.Run("should only match the same city", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences().WithLocationScope(matchmaking.LocationScopeCity)
+		neighbour := location.New(40.42, -3.70).WithCountryCode("ES").WithCityCode("MAD")
+		assert.True(t, p.IsWithinReach(&madrid, &neighbour))
+		assert.False(t, p.IsWithinReach(&madrid, &toledo))
+
+		sameCodeOtherCountry := location.Location{CountryCode: "PT", CityCode: "MAD"}
+		assert.False(t, p.IsWithinReach(&madrid, &sameCodeOtherCountry))
+	})
+}
+
+func TestIsSpokenBy(t *testing.T) {
+	t.Run("should accept any language without constraints", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences()
+		assert.True(t, p.IsSpokenBy([]string{"es"}, []string{"ja"}))
+		assert.True(t, p.IsSpokenBy(nil, nil))
+	})
+
+	t.Run("should require at least one shared language", func(t *testing.T) {
+		p := matchmaking.DefaultPreferences().WithRequireSharedLanguage(true)
+		assert.True(t, p.IsSpokenBy([]string{"es", "en-US"}, []string{"en-GB"}))
+		assert.False(t, p.IsSpokenBy([]string{"es"}, []string{"ja"}))
+		assert.False(t, p.IsSpokenBy([]string{"es"}, nil))
+	})
+}
@@ -0,0 +1,59 @@
+package matchmaking
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidRatingTag is returned when parsing an unknown rating tag.
+var ErrInvalidRatingTag = errors.New("invalid rating tag")
+
+// RatingTag qualifies the rating a user gives to a partner.
+type RatingTag string
+
+const (
+	// RatingTagFriendly praises a friendly partner.
+	RatingTagFriendly RatingTag = "friendly"
+	// RatingTagRude reports a rude partner.
+	RatingTagRude RatingTag = "rude"
+	// RatingTagSpam reports a partner sending spam.
+	RatingTagSpam RatingTag = "spam"
+	// RatingTagInappropriate reports a partner sending inappropriate content.
+	RatingTagInappropriate RatingTag = "inappropriate"
+)
+
+// ParseRatingTag returns the RatingTag for a given string.
+func ParseRatingTag(str string) (RatingTag, error) {
+	switch tag := RatingTag(strings.ToLower(strings.TrimSpace(str))); tag {
+	case RatingTagFriendly, RatingTagRude, RatingTagSpam, RatingTagInappropriate:
+		return tag, nil
+	default:
+		return "", ErrInvalidRatingTag
+	}
+}
+
+// ParseRatingTags parses every tag, dropping the duplicates.
+func ParseRatingTags(strs ...string) ([]RatingTag, error) {
+	tags := make([]RatingTag, 0, len(strs))
+	for _, str := range strs {
+		tag, err := ParseRatingTag(str)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// IsReport reports whether the tag reports a misbehaving partner.
+func (t RatingTag) IsReport() bool {
+	return t == RatingTagRude || t == RatingTagSpam || t == RatingTagInappropriate
+}
+
+// String implements fmt.Stringer.
+func (t RatingTag) String() string {
+	return string(t)
+}
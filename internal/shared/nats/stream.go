@@ -42,6 +42,9 @@ func (s *Stream) Name() string {
 }
 
 // Append publishes the provided events to the stream.
+// The last sequence of every subject is read right before publishing, which only keeps
+// the events of a subject in order. Aggregates checking for concurrent changes since
+// they were loaded use AppendToSubject.
 func (s *Stream) Append(ctx context.Context, events []event.Event) (eventstore.AppendResult, error) {
 	res := eventstore.AppendResult{
 		StreamName: s.streamConfig.Name,
@@ -50,67 +53,175 @@ func (s *Stream) Append(ctx context.Context, events []event.Event) (eventstore.A
 		return res, nil
 	}
 
-	// Determine last sequence for concurrency checks (if needed).
-	firstEvent := events[0]
-	natsSubject := s.makeSubjectFromEvent(firstEvent)
-
-	var err error
-	res.LastEventID, res.LastSequence, err = s.getLastSequence(ctx, natsSubject)
-	if err != nil {
-		return res, err
-	}
+	// Last sequence of each subject, used for the concurrency checks.
+	// Checking the subject instead of the whole stream allows appending
+	// events of different aggregates concurrently.
+	subjectSequences := make(map[string]uint64, len(events))
 
 	for _, e := range events {
-		encoded, encodingError := e.MarshalJSON()
-		if encodingError != nil {
-			return res, encodingError
+		publishSubject := s.makeSubjectFromEvent(e)
+		lastSubjectSequence, ok := subjectSequences[publishSubject]
+		if !ok {
+			var err error
+			_, lastSubjectSequence, err = s.getLastSequence(ctx, publishSubject)
+			if err != nil {
+				return res, err
+			}
 		}
 
-		// Basic publish options, including a message ID for deduplication & retries.
-		publishOpts := []jetstream.PublishOpt{
-			jetstream.WithMsgID(e.ID()),
-			jetstream.WithRetryAttempts(3),
-			jetstream.WithRetryWait(200 * time.Millisecond),
-			jetstream.WithExpectStream(s.streamConfig.Name),
-			jetstream.WithExpectLastMsgID(res.LastEventID),
+		sequence, err := s.publish(ctx, publishSubject, e, lastSubjectSequence)
+		if err != nil {
+			return res, err
 		}
 
-		// Retrieve aggregate version from CloudEvents extensions, if present.
-		aggregateID, extErr := types.ToString(SubjectVersionFromMap(e.Extensions()))
-		if extErr != nil {
-			// If no aggregate version is found, you could skip or treat this as a zero version.
-			// For now, we'll return an error to preserve existing behavior.
-			return res, extErr
+		// Update last sequence and event ID after a successful publish.
+		subjectSequences[publishSubject] = sequence
+		addAppendedEvent(&res, e, sequence)
+	}
+
+	return res, nil
+}
+
+// AppendToSubject publishes the events to a single subject, as long as the last event
+// of the subject is still the one at the expected sequence, zero for an empty subject.
+// Every event expects the previous one, so the events of a concurrent writer are never
+// interleaved, and the writer that loses fails with eventstore.ErrSequenceMismatch.
+func (s *Stream) AppendToSubject(ctx context.Context, subject string, expectedSequence uint64, events []event.Event) (eventstore.AppendResult, error) {
+	res := eventstore.AppendResult{
+		StreamName: s.streamConfig.Name,
+	}
+
+	lastSequence := expectedSequence
+	for _, e := range events {
+		sequence, err := s.publish(ctx, subject, e, lastSequence)
+		if err != nil {
+			return res, err
 		}
 
-		publishSubject := s.makeSubjectFromEvent(e)
-		natsMsg := &nats.Msg{
-			Subject: publishSubject,
-			Data:    encoded,
-			Header: nats.Header{
-				"Content-Type":          []string{"application/cloudevents+json"},
-				SubjectVersionHeaderKey: []string{aggregateID},
-			},
+		lastSequence = sequence
+		addAppendedEvent(&res, e, sequence)
+	}
+
+	return res, nil
+}
+
+// StoredEvent is an event read from the stream along with its sequence.
+type StoredEvent struct {
+	eventstore.Event
+
+	// Sequence is the position of the event in the stream.
+	Sequence uint64
+}
+
+// PullSubject returns every event of the subject in order, along with their sequences.
+func (s *Stream) PullSubject(ctx context.Context, subject string) ([]StoredEvent, error) {
+	consumer, err := s.newOrderedConsumer(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := consumer.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if info.NumPending == 0 {
+		return nil, nil
+	}
+
+	messages, err := consumer.FetchNoWait(int(info.NumPending))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]StoredEvent, 0, info.NumPending)
+	for msg := range messages.Messages() {
+		metadata, metaErr := msg.Metadata()
+		if metaErr != nil {
+			return nil, metaErr
 		}
 
-		puback, pubErr := s.js.PublishMsg(ctx, natsMsg, publishOpts...)
-		if pubErr != nil {
-			switch {
-			case errors.Is(pubErr, jetstream.ErrKeyExists):
-				// KeyExists indicates a message with this ID already exists (sequence mismatch).
-				return res, eventstore.ErrSequenceMismatch
-			default:
-				return res, pubErr
-			}
+		e, decodeErr := decodeEvent(msg.Data())
+		if decodeErr != nil {
+			return nil, decodeErr
 		}
+		events = append(events, StoredEvent{Event: *e, Sequence: metadata.Sequence.Stream})
+	}
+	if err = messages.Error(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
 
-		// Update last sequence and event ID after a successful publish.
-		res.LastSequence = puback.Sequence
-		res.LastEventID = e.ID()
-		res.NumEvents++
+// PurgeSubject removes the events of the subject stored before the given sequence.
+func (s *Stream) PurgeSubject(ctx context.Context, subject string, beforeSequence uint64) error {
+	return s.stream.Purge(ctx,
+		jetstream.WithPurgeSubject(subject),
+		jetstream.WithPurgeSequence(beforeSequence),
+	)
+}
+
+// addAppendedEvent records the event in the result of the append.
+func addAppendedEvent(res *eventstore.AppendResult, e event.Event, sequence uint64) {
+	if res.NumEvents == 0 {
+		res.FirstSequence = sequence
 	}
+	res.LastSequence = sequence
+	res.LastEventID = e.ID()
+	res.NumEvents++
+}
 
-	return res, nil
+// publish appends the event to the subject, expecting the last event of the subject
+// at the given sequence, and returns the sequence of the event.
+func (s *Stream) publish(ctx context.Context, subject string, e event.Event, lastSubjectSequence uint64) (uint64, error) {
+	encoded, encodingError := e.MarshalJSON()
+	if encodingError != nil {
+		return 0, encodingError
+	}
+
+	// Basic publish options, including a message ID for deduplication & retries.
+	publishOpts := []jetstream.PublishOpt{
+		jetstream.WithMsgID(e.ID()),
+		jetstream.WithRetryAttempts(3),
+		jetstream.WithRetryWait(200 * time.Millisecond),
+		jetstream.WithExpectStream(s.streamConfig.Name),
+		jetstream.WithExpectLastSequencePerSubject(lastSubjectSequence),
+	}
+
+	// Retrieve aggregate version from CloudEvents extensions, if present.
+	aggregateID, extErr := types.ToString(SubjectVersionFromMap(e.Extensions()))
+	if extErr != nil {
+		// If no aggregate version is found, you could skip or treat this as a zero version.
+		// For now, we'll return an error to preserve existing behavior.
+		return 0, extErr
+	}
+
+	natsMsg := &nats.Msg{
+		Subject: subject,
+		Data:    encoded,
+		Header: nats.Header{
+			"Content-Type":          []string{"application/cloudevents+json"},
+			SubjectVersionHeaderKey: []string{aggregateID},
+		},
+	}
+
+	puback, pubErr := s.js.PublishMsg(ctx, natsMsg, publishOpts...)
+	if pubErr != nil {
+		var apiErr *jetstream.APIError
+		switch {
+		case errors.Is(pubErr, jetstream.ErrKeyExists),
+			errors.As(pubErr, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence:
+			// another event was appended to the subject since its last sequence was read.
+			return 0, eventstore.ErrSequenceMismatch
+		default:
+			return 0, pubErr
+		}
+	}
+	if puback.Duplicate {
+		// an event with the same ID was already appended, the event ID identifies
+		// the aggregate version when the stream is used for optimistic concurrency.
+		return 0, eventstore.ErrSequenceMismatch
+	}
+	return puback.Sequence, nil
 }
 
 // Pull fetches a batch of events from a new ephemeral consumer, returning them as a slice.
@@ -26,26 +26,34 @@ type NotificationMessage_Type int32
 
 const (
 	NotificationMessage_TYPE_UNSPECIFIED      NotificationMessage_Type = 0
-	NotificationMessage_TYPE_NEW_MATCH        NotificationMessage_Type = 1 // New match notification
-	NotificationMessage_TYPE_NEW_MESSAGE      NotificationMessage_Type = 2 // New message notification
-	NotificationMessage_TYPE_USER_JOINED      NotificationMessage_Type = 3 // User joined notification
-	NotificationMessage_TYPE_USER_LEFT        NotificationMessage_Type = 4 // User left notification
-	NotificationMessage_TYPE_USER_TYPING      NotificationMessage_Type = 5 // User typing notification
-	NotificationMessage_TYPE_USER_STOP_TYPING NotificationMessage_Type = 6 // User stopped typing notification
-	NotificationMessage_TYPE_USER_STATUS      NotificationMessage_Type = 7 // User status notification
+	NotificationMessage_TYPE_NEW_MATCH        NotificationMessage_Type = 1  // New match notification
+	NotificationMessage_TYPE_NEW_MESSAGE      NotificationMessage_Type = 2  // New message notification
+	NotificationMessage_TYPE_USER_JOINED      NotificationMessage_Type = 3  // User joined notification
+	NotificationMessage_TYPE_USER_LEFT        NotificationMessage_Type = 4  // User left notification
+	NotificationMessage_TYPE_USER_TYPING      NotificationMessage_Type = 5  // User typing notification
+	NotificationMessage_TYPE_USER_STOP_TYPING NotificationMessage_Type = 6  // User stopped typing notification
+	NotificationMessage_TYPE_USER_STATUS      NotificationMessage_Type = 7  // User status notification
+	NotificationMessage_TYPE_NO_MATCH_FOUND   NotificationMessage_Type = 8  // No match found before the user wait deadline
+	NotificationMessage_TYPE_MATCH_PROPOSED   NotificationMessage_Type = 9  // Match proposed, waiting for both users to accept it
+	NotificationMessage_TYPE_MATCH_CANCELLED  NotificationMessage_Type = 10 // Match proposal declined or not accepted in time
+	NotificationMessage_TYPE_QUEUE_STATUS     NotificationMessage_Type = 11 // Compatible users waiting, position and estimated wait of a waiting user
 )
 
 // Enum value maps for NotificationMessage_Type.
 var (
 	NotificationMessage_Type_name = map[int32]string{
-		0: "TYPE_UNSPECIFIED",
-		1: "TYPE_NEW_MATCH",
-		2: "TYPE_NEW_MESSAGE",
-		3: "TYPE_USER_JOINED",
-		4: "TYPE_USER_LEFT",
-		5: "TYPE_USER_TYPING",
-		6: "TYPE_USER_STOP_TYPING",
-		7: "TYPE_USER_STATUS",
+		0:  "TYPE_UNSPECIFIED",
+		1:  "TYPE_NEW_MATCH",
+		2:  "TYPE_NEW_MESSAGE",
+		3:  "TYPE_USER_JOINED",
+		4:  "TYPE_USER_LEFT",
+		5:  "TYPE_USER_TYPING",
+		6:  "TYPE_USER_STOP_TYPING",
+		7:  "TYPE_USER_STATUS",
+		8:  "TYPE_NO_MATCH_FOUND",
+		9:  "TYPE_MATCH_PROPOSED",
+		10: "TYPE_MATCH_CANCELLED",
+		11: "TYPE_QUEUE_STATUS",
 	}
 	NotificationMessage_Type_value = map[string]int32{
 		"TYPE_UNSPECIFIED":      0,
@@ -56,6 +64,10 @@ var (
 		"TYPE_USER_TYPING":      5,
 		"TYPE_USER_STOP_TYPING": 6,
 		"TYPE_USER_STATUS":      7,
+		"TYPE_NO_MATCH_FOUND":   8,
+		"TYPE_MATCH_PROPOSED":   9,
+		"TYPE_MATCH_CANCELLED":  10,
+		"TYPE_QUEUE_STATUS":     11,
 	}
 )
 
@@ -161,7 +173,7 @@ var file_randomtalk_chat_v1_notification_message_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xfe, 0x02, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x74, 0x6f, 0x22, 0xe1, 0x03, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x04, 0x74, 0x79,
 	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f,
 	0x6d, 0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f,
@@ -173,7 +185,7 @@ var file_randomtalk_chat_v1_notification_message_proto_rawDesc = []byte{
 	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xb7, 0x01, 0x0a, 0x04, 0x54, 0x79,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x9a, 0x02, 0x0a, 0x04, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
 	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x59, 0x50, 0x45,
 	0x5f, 0x4e, 0x45, 0x57, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
@@ -185,10 +197,16 @@ var file_randomtalk_chat_v1_notification_message_proto_rawDesc = []byte{
 	0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f,
 	0x53, 0x54, 0x4f, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x06, 0x12, 0x14, 0x0a,
 	0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
-	0x53, 0x10, 0x07, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x78, 0x66, 0x72, 0x72, 0x2f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c,
-	0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x53, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x5f, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x08, 0x12, 0x17, 0x0a, 0x13,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x4f,
+	0x53, 0x45, 0x44, 0x10, 0x09, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x0a, 0x12,
+	0x15, 0x0a, 0x11, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x10, 0x0b, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x66, 0x72, 0x72, 0x2f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x74, 0x61, 0x6c, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68,
+	0x61, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -21,6 +21,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LocationScope restricts matches to users located in the same country or city.
+type LocationScope int32
+
+const (
+	LocationScope_LOCATION_SCOPE_UNSPECIFIED LocationScope = 0
+	LocationScope_LOCATION_SCOPE_COUNTRY     LocationScope = 1
+	LocationScope_LOCATION_SCOPE_CITY        LocationScope = 2
+)
+
+// Enum value maps for LocationScope.
+var (
+	LocationScope_name = map[int32]string{
+		0: "LOCATION_SCOPE_UNSPECIFIED",
+		1: "LOCATION_SCOPE_COUNTRY",
+		2: "LOCATION_SCOPE_CITY",
+	}
+	LocationScope_value = map[string]int32{
+		"LOCATION_SCOPE_UNSPECIFIED": 0,
+		"LOCATION_SCOPE_COUNTRY":     1,
+		"LOCATION_SCOPE_CITY":        2,
+	}
+)
+
+func (x LocationScope) Enum() *LocationScope {
+	p := new(LocationScope)
+	*p = x
+	return p
+}
+
+func (x LocationScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocationScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[0].Descriptor()
+}
+
+func (LocationScope) Type() protoreflect.EnumType {
+	return &file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[0]
+}
+
+func (x LocationScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocationScope.Descriptor instead.
+func (LocationScope) EnumDescriptor() ([]byte, []int) {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{0}
+}
+
 // Gender is an enum that represents the user gender.
 type Gender int32
 
@@ -55,11 +105,11 @@ func (x Gender) String() string {
 }
 
 func (Gender) Descriptor() protoreflect.EnumDescriptor {
-	return file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[0].Descriptor()
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[1].Descriptor()
 }
 
 func (Gender) Type() protoreflect.EnumType {
-	return &file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[0]
+	return &file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes[1]
 }
 
 func (x Gender) Number() protoreflect.EnumNumber {
@@ -68,7 +118,7 @@ func (x Gender) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Gender.Descriptor instead.
 func (Gender) EnumDescriptor() ([]byte, []int) {
-	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{0}
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{1}
 }
 
 // UserMatchRequestedNotification is a message (event) sent when an user connects to the chat
@@ -83,6 +133,12 @@ type UserMatchRequestedNotification struct {
 	UserAttributes  *UserAttributes        `protobuf:"bytes,3,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
 	UserPreferences *UserPreferences       `protobuf:"bytes,4,opt,name=user_preferences,json=userPreferences,proto3" json:"user_preferences,omitempty"`
 	OccurredAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// skipped_user_id is the partner the user has just skipped, if any.
+	// The matchmaker avoids pairing them again during the skip cooldown.
+	SkippedUserId string `protobuf:"bytes,6,opt,name=skipped_user_id,json=skippedUserId,proto3" json:"skipped_user_id,omitempty"`
+	// blocked_user_ids are the users blocked by the user or that blocked the user.
+	// The matchmaker never pairs them.
+	BlockedUserIds []string `protobuf:"bytes,7,rep,name=blocked_user_ids,json=blockedUserIds,proto3" json:"blocked_user_ids,omitempty"`
 }
 
 func (x *UserMatchRequestedNotification) Reset() {
@@ -150,6 +206,281 @@ func (x *UserMatchRequestedNotification) GetOccurredAt() *timestamppb.Timestamp
 	return nil
 }
 
+func (x *UserMatchRequestedNotification) GetSkippedUserId() string {
+	if x != nil {
+		return x.SkippedUserId
+	}
+	return ""
+}
+
+func (x *UserMatchRequestedNotification) GetBlockedUserIds() []string {
+	if x != nil {
+		return x.BlockedUserIds
+	}
+	return nil
+}
+
+// MatchResponseNotification is a message (event) sent when an user accepts or declines
+// a match proposed by the matchmaker.
+type MatchResponseNotification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	ChatSessionId  string                 `protobuf:"bytes,2,opt,name=chat_session_id,json=chatSessionId,proto3" json:"chat_session_id,omitempty"`
+	MatchId        string                 `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Accepted       bool                   `protobuf:"varint,5,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (x *MatchResponseNotification) Reset() {
+	*x = MatchResponseNotification{}
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResponseNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResponseNotification) ProtoMessage() {}
+
+func (x *MatchResponseNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResponseNotification.ProtoReflect.Descriptor instead.
+func (*MatchResponseNotification) Descriptor() ([]byte, []int) {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatchResponseNotification) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *MatchResponseNotification) GetChatSessionId() string {
+	if x != nil {
+		return x.ChatSessionId
+	}
+	return ""
+}
+
+func (x *MatchResponseNotification) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchResponseNotification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MatchResponseNotification) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *MatchResponseNotification) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// MatchRequestCancelledNotification is a message (event) sent when an user stops
+// waiting for a match, so the matchmaker removes it from the waiting users.
+type MatchRequestCancelledNotification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	ChatSessionId  string                 `protobuf:"bytes,2,opt,name=chat_session_id,json=chatSessionId,proto3" json:"chat_session_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (x *MatchRequestCancelledNotification) Reset() {
+	*x = MatchRequestCancelledNotification{}
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRequestCancelledNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRequestCancelledNotification) ProtoMessage() {}
+
+func (x *MatchRequestCancelledNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRequestCancelledNotification.ProtoReflect.Descriptor instead.
+func (*MatchRequestCancelledNotification) Descriptor() ([]byte, []int) {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MatchRequestCancelledNotification) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *MatchRequestCancelledNotification) GetChatSessionId() string {
+	if x != nil {
+		return x.ChatSessionId
+	}
+	return ""
+}
+
+func (x *MatchRequestCancelledNotification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MatchRequestCancelledNotification) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// PartnerRatedNotification is a message (event) sent when an user rates a partner
+// it talked with, feeding the partner reputation.
+type PartnerRatedNotification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	ChatSessionId  string                 `protobuf:"bytes,2,opt,name=chat_session_id,json=chatSessionId,proto3" json:"chat_session_id,omitempty"`
+	RaterUserId    string                 `protobuf:"bytes,3,opt,name=rater_user_id,json=raterUserId,proto3" json:"rater_user_id,omitempty"`
+	RatedUserId    string                 `protobuf:"bytes,4,opt,name=rated_user_id,json=ratedUserId,proto3" json:"rated_user_id,omitempty"`
+	MatchId        string                 `protobuf:"bytes,5,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Liked          bool                   `protobuf:"varint,6,opt,name=liked,proto3" json:"liked,omitempty"`
+	Tags           []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (x *PartnerRatedNotification) Reset() {
+	*x = PartnerRatedNotification{}
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartnerRatedNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartnerRatedNotification) ProtoMessage() {}
+
+func (x *PartnerRatedNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartnerRatedNotification.ProtoReflect.Descriptor instead.
+func (*PartnerRatedNotification) Descriptor() ([]byte, []int) {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PartnerRatedNotification) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *PartnerRatedNotification) GetChatSessionId() string {
+	if x != nil {
+		return x.ChatSessionId
+	}
+	return ""
+}
+
+func (x *PartnerRatedNotification) GetRaterUserId() string {
+	if x != nil {
+		return x.RaterUserId
+	}
+	return ""
+}
+
+func (x *PartnerRatedNotification) GetRatedUserId() string {
+	if x != nil {
+		return x.RatedUserId
+	}
+	return ""
+}
+
+func (x *PartnerRatedNotification) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *PartnerRatedNotification) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *PartnerRatedNotification) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PartnerRatedNotification) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
 // UserAttributes contains the user attributes for the chat.
 type UserAttributes struct {
 	state         protoimpl.MessageState
@@ -159,11 +490,15 @@ type UserAttributes struct {
 	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Age    int32  `protobuf:"varint,2,opt,name=age,proto3" json:"age,omitempty"`
 	Gender Gender `protobuf:"varint,3,opt,name=gender,proto3,enum=randomtalk.chat.v1.Gender" json:"gender,omitempty"`
+	// location is the user location, if shared.
+	Location *UserLocation `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// languages are the BCP-47 tags of the languages spoken by the user, such as "en" or "pt-BR".
+	Languages []string `protobuf:"bytes,5,rep,name=languages,proto3" json:"languages,omitempty"`
 }
 
 func (x *UserAttributes) Reset() {
 	*x = UserAttributes{}
-	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[1]
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -175,7 +510,7 @@ func (x *UserAttributes) String() string {
 func (*UserAttributes) ProtoMessage() {}
 
 func (x *UserAttributes) ProtoReflect() protoreflect.Message {
-	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[1]
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -188,7 +523,7 @@ func (x *UserAttributes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserAttributes.ProtoReflect.Descriptor instead.
 func (*UserAttributes) Descriptor() ([]byte, []int) {
-	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{1}
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UserAttributes) GetId() string {
@@ -212,6 +547,90 @@ func (x *UserAttributes) GetGender() Gender {
 	return Gender_GENDER_UNSPECIFIED
 }
 
+func (x *UserAttributes) GetLocation() *UserLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *UserAttributes) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+// UserLocation contains the user coordinates and, optionally, its ISO country and city codes.
+type UserLocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude    float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude   float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	CountryCode string  `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	CityCode    string  `protobuf:"bytes,4,opt,name=city_code,json=cityCode,proto3" json:"city_code,omitempty"`
+}
+
+func (x *UserLocation) Reset() {
+	*x = UserLocation{}
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserLocation) ProtoMessage() {}
+
+func (x *UserLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserLocation.ProtoReflect.Descriptor instead.
+func (*UserLocation) Descriptor() ([]byte, []int) {
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserLocation) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UserLocation) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *UserLocation) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *UserLocation) GetCityCode() string {
+	if x != nil {
+		return x.CityCode
+	}
+	return ""
+}
+
 // UserPreferences contains the user preferences for the chat.
 type UserPreferences struct {
 	state         protoimpl.MessageState
@@ -222,11 +641,22 @@ type UserPreferences struct {
 	MaxAge    int32    `protobuf:"varint,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
 	Gender    Gender   `protobuf:"varint,3,opt,name=gender,proto3,enum=randomtalk.chat.v1.Gender" json:"gender,omitempty"`
 	Interests []string `protobuf:"bytes,4,rep,name=interests,proto3" json:"interests,omitempty"`
+	// max_distance_km limits matches to users within the given distance. Zero means unlimited.
+	MaxDistanceKm float64       `protobuf:"fixed64,5,opt,name=max_distance_km,json=maxDistanceKm,proto3" json:"max_distance_km,omitempty"`
+	LocationScope LocationScope `protobuf:"varint,6,opt,name=location_scope,json=locationScope,proto3,enum=randomtalk.chat.v1.LocationScope" json:"location_scope,omitempty"`
+	// max_wait_time_seconds is how long the user is willing to wait for a match. Zero means
+	// the matchmaker default.
+	MaxWaitTimeSeconds int32 `protobuf:"varint,7,opt,name=max_wait_time_seconds,json=maxWaitTimeSeconds,proto3" json:"max_wait_time_seconds,omitempty"`
+	// require_shared_language only matches users that speak at least one language of the user.
+	RequireSharedLanguage bool `protobuf:"varint,8,opt,name=require_shared_language,json=requireSharedLanguage,proto3" json:"require_shared_language,omitempty"`
+	// group_size asks for a group room of the given number of participants, from 3 to 8.
+	// Zero means a one-to-one match.
+	GroupSize int32 `protobuf:"varint,9,opt,name=group_size,json=groupSize,proto3" json:"group_size,omitempty"`
 }
 
 func (x *UserPreferences) Reset() {
 	*x = UserPreferences{}
-	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[2]
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +668,7 @@ func (x *UserPreferences) String() string {
 func (*UserPreferences) ProtoMessage() {}
 
 func (x *UserPreferences) ProtoReflect() protoreflect.Message {
-	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[2]
+	mi := &file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +681,7 @@ func (x *UserPreferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserPreferences.ProtoReflect.Descriptor instead.
 func (*UserPreferences) Descriptor() ([]byte, []int) {
-	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{2}
+	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UserPreferences) GetMinAge() int32 {
@@ -282,6 +712,41 @@ func (x *UserPreferences) GetInterests() []string {
 	return nil
 }
 
+func (x *UserPreferences) GetMaxDistanceKm() float64 {
+	if x != nil {
+		return x.MaxDistanceKm
+	}
+	return 0
+}
+
+func (x *UserPreferences) GetLocationScope() LocationScope {
+	if x != nil {
+		return x.LocationScope
+	}
+	return LocationScope_LOCATION_SCOPE_UNSPECIFIED
+}
+
+func (x *UserPreferences) GetMaxWaitTimeSeconds() int32 {
+	if x != nil {
+		return x.MaxWaitTimeSeconds
+	}
+	return 0
+}
+
+func (x *UserPreferences) GetRequireSharedLanguage() bool {
+	if x != nil {
+		return x.RequireSharedLanguage
+	}
+	return false
+}
+
+func (x *UserPreferences) GetGroupSize() int32 {
+	if x != nil {
+		return x.GroupSize
+	}
+	return 0
+}
+
 var File_randomtalk_chat_v1_user_match_requested_notification_proto protoreflect.FileDescriptor
 
 var file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDesc = []byte{
@@ -292,7 +757,7 @@ var file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDesc = []
 	0x6e, 0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31,
 	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xcb, 0x02, 0x0a, 0x1e, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x6f, 0x22, 0x9d, 0x03, 0x0a, 0x1e, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e,
@@ -312,31 +777,121 @@ var file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDesc = []
 	0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61,
 	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22,
-	0x66, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
-	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x61, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c, 0x6b,
-	0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52,
-	0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x95, 0x01, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72,
-	0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d,
-	0x69, 0x6e, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69,
-	0x6e, 0x41, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x32, 0x0a,
-	0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x26, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x22, 0xf9, 0x01, 0x0a, 0x19, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x27, 0x0a, 0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x74,
+	0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
+	0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0xca, 0x01,
+	0x0a, 0x21, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f,
+	0x63, 0x68, 0x61, 0x74, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3b, 0x0a,
+	0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0xb5, 0x02, 0x0a, 0x18, 0x50,
+	0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x72, 0x61, 0x74, 0x65,
+	0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x61, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6b, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x61, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
 	0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x2a,
-	0x44, 0x0a, 0x06, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x45, 0x4e,
-	0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x4d, 0x41, 0x4c, 0x45,
-	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x4d,
-	0x41, 0x4c, 0x45, 0x10, 0x02, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x78, 0x66, 0x72, 0x72, 0x2f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x74,
-	0x61, 0x6c, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x61,
-	0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x69, 0x74, 0x79, 0x43, 0x6f,
+	0x64, 0x65, 0x22, 0x91, 0x03, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x6d, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x4b, 0x6d, 0x12, 0x48, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x72, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c, 0x6b, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x0d, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x15,
+	0x6d, 0x61, 0x78, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78,
+	0x57, 0x61, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x36, 0x0a, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x15, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x2a, 0x64, 0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x4f, 0x43, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x4f, 0x43, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x52,
+	0x59, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x43, 0x49, 0x54, 0x59, 0x10, 0x02, 0x2a, 0x44, 0x0a, 0x06,
+	0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x4d, 0x41, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x46, 0x45, 0x4d, 0x41, 0x4c, 0x45,
+	0x10, 0x02, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x78, 0x66, 0x72, 0x72, 0x2f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x74, 0x61, 0x6c, 0x6b,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -351,26 +906,36 @@ func file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescGZIP
 	return file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDescData
 }
 
-var file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_randomtalk_chat_v1_user_match_requested_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_randomtalk_chat_v1_user_match_requested_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_randomtalk_chat_v1_user_match_requested_notification_proto_goTypes = []any{
-	(Gender)(0),                            // 0: randomtalk.chat.v1.Gender
-	(*UserMatchRequestedNotification)(nil), // 1: randomtalk.chat.v1.UserMatchRequestedNotification
-	(*UserAttributes)(nil),                 // 2: randomtalk.chat.v1.UserAttributes
-	(*UserPreferences)(nil),                // 3: randomtalk.chat.v1.UserPreferences
-	(*timestamppb.Timestamp)(nil),          // 4: google.protobuf.Timestamp
+	(LocationScope)(0),                        // 0: randomtalk.chat.v1.LocationScope
+	(Gender)(0),                               // 1: randomtalk.chat.v1.Gender
+	(*UserMatchRequestedNotification)(nil),    // 2: randomtalk.chat.v1.UserMatchRequestedNotification
+	(*MatchResponseNotification)(nil),         // 3: randomtalk.chat.v1.MatchResponseNotification
+	(*MatchRequestCancelledNotification)(nil), // 4: randomtalk.chat.v1.MatchRequestCancelledNotification
+	(*PartnerRatedNotification)(nil),          // 5: randomtalk.chat.v1.PartnerRatedNotification
+	(*UserAttributes)(nil),                    // 6: randomtalk.chat.v1.UserAttributes
+	(*UserLocation)(nil),                      // 7: randomtalk.chat.v1.UserLocation
+	(*UserPreferences)(nil),                   // 8: randomtalk.chat.v1.UserPreferences
+	(*timestamppb.Timestamp)(nil),             // 9: google.protobuf.Timestamp
 }
 var file_randomtalk_chat_v1_user_match_requested_notification_proto_depIdxs = []int32{
-	2, // 0: randomtalk.chat.v1.UserMatchRequestedNotification.user_attributes:type_name -> randomtalk.chat.v1.UserAttributes
-	3, // 1: randomtalk.chat.v1.UserMatchRequestedNotification.user_preferences:type_name -> randomtalk.chat.v1.UserPreferences
-	4, // 2: randomtalk.chat.v1.UserMatchRequestedNotification.occurred_at:type_name -> google.protobuf.Timestamp
-	0, // 3: randomtalk.chat.v1.UserAttributes.gender:type_name -> randomtalk.chat.v1.Gender
-	0, // 4: randomtalk.chat.v1.UserPreferences.gender:type_name -> randomtalk.chat.v1.Gender
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	6,  // 0: randomtalk.chat.v1.UserMatchRequestedNotification.user_attributes:type_name -> randomtalk.chat.v1.UserAttributes
+	8,  // 1: randomtalk.chat.v1.UserMatchRequestedNotification.user_preferences:type_name -> randomtalk.chat.v1.UserPreferences
+	9,  // 2: randomtalk.chat.v1.UserMatchRequestedNotification.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: randomtalk.chat.v1.MatchResponseNotification.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: randomtalk.chat.v1.MatchRequestCancelledNotification.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: randomtalk.chat.v1.PartnerRatedNotification.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 6: randomtalk.chat.v1.UserAttributes.gender:type_name -> randomtalk.chat.v1.Gender
+	7,  // 7: randomtalk.chat.v1.UserAttributes.location:type_name -> randomtalk.chat.v1.UserLocation
+	1,  // 8: randomtalk.chat.v1.UserPreferences.gender:type_name -> randomtalk.chat.v1.Gender
+	0,  // 9: randomtalk.chat.v1.UserPreferences.location_scope:type_name -> randomtalk.chat.v1.LocationScope
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_randomtalk_chat_v1_user_match_requested_notification_proto_init() }
@@ -383,8 +948,8 @@ func file_randomtalk_chat_v1_user_match_requested_notification_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_randomtalk_chat_v1_user_match_requested_notification_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
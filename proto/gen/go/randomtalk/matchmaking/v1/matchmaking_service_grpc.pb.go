@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchMakingService_FindMatch_FullMethodName = "/randomtalk.matchmaking.v1.MatchMakingService/FindMatch"
-	MatchMakingService_GetMatch_FullMethodName  = "/randomtalk.matchmaking.v1.MatchMakingService/GetMatch"
+	MatchMakingService_FindMatch_FullMethodName        = "/randomtalk.matchmaking.v1.MatchMakingService/FindMatch"
+	MatchMakingService_GetMatch_FullMethodName         = "/randomtalk.matchmaking.v1.MatchMakingService/GetMatch"
+	MatchMakingService_ListWaitingUsers_FullMethodName = "/randomtalk.matchmaking.v1.MatchMakingService/ListWaitingUsers"
+	MatchMakingService_ListUserMatches_FullMethodName  = "/randomtalk.matchmaking.v1.MatchMakingService/ListUserMatches"
+	MatchMakingService_GetLastMatch_FullMethodName     = "/randomtalk.matchmaking.v1.MatchMakingService/GetLastMatch"
+	MatchMakingService_WatchMatches_FullMethodName     = "/randomtalk.matchmaking.v1.MatchMakingService/WatchMatches"
 )
 
 // MatchMakingServiceClient is the client API for MatchMakingService service.
@@ -31,6 +35,14 @@ type MatchMakingServiceClient interface {
 	FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (*FindMatchResponse, error)
 	// Retrieves a match details by its ID.
 	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error)
+	// Lists the users waiting in the matchmaking pool.
+	ListWaitingUsers(ctx context.Context, in *ListWaitingUsersRequest, opts ...grpc.CallOption) (*ListWaitingUsersResponse, error)
+	// Lists the matches of an user from the most recent one.
+	ListUserMatches(ctx context.Context, in *ListUserMatchesRequest, opts ...grpc.CallOption) (*ListUserMatchesResponse, error)
+	// Retrieves the most recent match of an user.
+	GetLastMatch(ctx context.Context, in *GetLastMatchRequest, opts ...grpc.CallOption) (*GetLastMatchResponse, error)
+	// Streams the matches created from now on.
+	WatchMatches(ctx context.Context, in *WatchMatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMatchesResponse], error)
 }
 
 type matchMakingServiceClient struct {
@@ -61,6 +73,55 @@ func (c *matchMakingServiceClient) GetMatch(ctx context.Context, in *GetMatchReq
 	return out, nil
 }
 
+func (c *matchMakingServiceClient) ListWaitingUsers(ctx context.Context, in *ListWaitingUsersRequest, opts ...grpc.CallOption) (*ListWaitingUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWaitingUsersResponse)
+	err := c.cc.Invoke(ctx, MatchMakingService_ListWaitingUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchMakingServiceClient) ListUserMatches(ctx context.Context, in *ListUserMatchesRequest, opts ...grpc.CallOption) (*ListUserMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserMatchesResponse)
+	err := c.cc.Invoke(ctx, MatchMakingService_ListUserMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchMakingServiceClient) GetLastMatch(ctx context.Context, in *GetLastMatchRequest, opts ...grpc.CallOption) (*GetLastMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLastMatchResponse)
+	err := c.cc.Invoke(ctx, MatchMakingService_GetLastMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchMakingServiceClient) WatchMatches(ctx context.Context, in *WatchMatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMatchesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchMakingService_ServiceDesc.Streams[0], MatchMakingService_WatchMatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMatchesRequest, WatchMatchesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchMakingService_WatchMatchesClient = grpc.ServerStreamingClient[WatchMatchesResponse]
+
 // MatchMakingServiceServer is the server API for MatchMakingService service.
 // All implementations must embed UnimplementedMatchMakingServiceServer
 // for forward compatibility.
@@ -69,6 +130,14 @@ type MatchMakingServiceServer interface {
 	FindMatch(context.Context, *FindMatchRequest) (*FindMatchResponse, error)
 	// Retrieves a match details by its ID.
 	GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error)
+	// Lists the users waiting in the matchmaking pool.
+	ListWaitingUsers(context.Context, *ListWaitingUsersRequest) (*ListWaitingUsersResponse, error)
+	// Lists the matches of an user from the most recent one.
+	ListUserMatches(context.Context, *ListUserMatchesRequest) (*ListUserMatchesResponse, error)
+	// Retrieves the most recent match of an user.
+	GetLastMatch(context.Context, *GetLastMatchRequest) (*GetLastMatchResponse, error)
+	// Streams the matches created from now on.
+	WatchMatches(*WatchMatchesRequest, grpc.ServerStreamingServer[WatchMatchesResponse]) error
 	mustEmbedUnimplementedMatchMakingServiceServer()
 }
 
@@ -85,6 +154,18 @@ func (UnimplementedMatchMakingServiceServer) FindMatch(context.Context, *FindMat
 func (UnimplementedMatchMakingServiceServer) GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
 }
+func (UnimplementedMatchMakingServiceServer) ListWaitingUsers(context.Context, *ListWaitingUsersRequest) (*ListWaitingUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWaitingUsers not implemented")
+}
+func (UnimplementedMatchMakingServiceServer) ListUserMatches(context.Context, *ListUserMatchesRequest) (*ListUserMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserMatches not implemented")
+}
+func (UnimplementedMatchMakingServiceServer) GetLastMatch(context.Context, *GetLastMatchRequest) (*GetLastMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastMatch not implemented")
+}
+func (UnimplementedMatchMakingServiceServer) WatchMatches(*WatchMatchesRequest, grpc.ServerStreamingServer[WatchMatchesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMatches not implemented")
+}
 func (UnimplementedMatchMakingServiceServer) mustEmbedUnimplementedMatchMakingServiceServer() {}
 func (UnimplementedMatchMakingServiceServer) testEmbeddedByValue()                            {}
 
@@ -142,6 +223,71 @@ func _MatchMakingService_GetMatch_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchMakingService_ListWaitingUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWaitingUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchMakingServiceServer).ListWaitingUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchMakingService_ListWaitingUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchMakingServiceServer).ListWaitingUsers(ctx, req.(*ListWaitingUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchMakingService_ListUserMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchMakingServiceServer).ListUserMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchMakingService_ListUserMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchMakingServiceServer).ListUserMatches(ctx, req.(*ListUserMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchMakingService_GetLastMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchMakingServiceServer).GetLastMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchMakingService_GetLastMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchMakingServiceServer).GetLastMatch(ctx, req.(*GetLastMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchMakingService_WatchMatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchMakingServiceServer).WatchMatches(m, &grpc.GenericServerStream[WatchMatchesRequest, WatchMatchesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchMakingService_WatchMatchesServer = grpc.ServerStreamingServer[WatchMatchesResponse]
+
 // MatchMakingService_ServiceDesc is the grpc.ServiceDesc for MatchMakingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,7 +303,25 @@ var MatchMakingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMatch",
 			Handler:    _MatchMakingService_GetMatch_Handler,
 		},
+		{
+			MethodName: "ListWaitingUsers",
+			Handler:    _MatchMakingService_ListWaitingUsers_Handler,
+		},
+		{
+			MethodName: "ListUserMatches",
+			Handler:    _MatchMakingService_ListUserMatches_Handler,
+		},
+		{
+			MethodName: "GetLastMatch",
+			Handler:    _MatchMakingService_GetLastMatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMatches",
+			Handler:       _MatchMakingService_WatchMatches_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "randomtalk/matchmaking/v1/matchmaking_service.proto",
 }